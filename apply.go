@@ -0,0 +1,62 @@
+package datatables
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// Counts holds the record counts of a DataTable request.
+//
+// Fields:
+//   - Total: The number of records before filtering, the recordsTotal of the
+//     response.
+//   - Filtered: The number of records after filtering, the recordsFiltered of
+//     the response.
+type Counts struct {
+	Total    int64
+	Filtered int64
+}
+
+// ApplyTo applies the request handling of the DataTable to the given query
+// and returns it with the search, ordering, and pagination applied, together
+// with the record counts, which are computed right away.
+//
+// The query is not executed, so that callers can run it and serialize the
+// rows themselves, for instance into protobuf messages, while reusing the
+// filters, the search, the ordering, the pagination, the interceptors, and
+// the BeforeQuery hooks of the DataTable. The given query replaces the one
+// the DataTable was created with; a nil query keeps it. No render step,
+// hook, or callback running on the fetched rows is applied. The columns
+// denied by the column policy are omitted from the SELECT list.
+//
+// Returns an error if the DataTable is invalid, a count query fails, the
+// global search has to be applied in memory because of computed columns, or
+// a column is masked with MaskColumn, since the masks apply to fetched rows.
+func (dt *DataTable) ApplyTo(q *gorm.DB) (*gorm.DB, Counts, error) {
+	if q != nil {
+		dt.tx = q
+	}
+	if err := dt.Validate(); err != nil {
+		return nil, Counts{}, err
+	}
+	if err := dt.applyCursor(); err != nil {
+		return nil, Counts{}, err
+	}
+	dt.prepare()
+	if dt.searchesInMemory() {
+		return nil, Counts{}, errors.New(dt.translate(MsgApplyToSearch, "the search of computed columns is not supported by ApplyTo"))
+	}
+	if len(dt.masks) > 0 {
+		return nil, Counts{}, errors.New(dt.translate(MsgApplyToMasks, "masked columns are not supported by ApplyTo"))
+	}
+
+	baseQuery := dt.buildBaseQuery()
+	filteredQuery := dt.buildFilteredQuery(baseQuery)
+	total, filtered, err := dt.countRecords(dt.buildCountQuery(baseQuery), filteredQuery)
+	if err != nil {
+		return nil, Counts{}, err
+	}
+	query := dt.omitDeniedColumns(dt.buildFetchQuery(filteredQuery)).WithContext(dt.context())
+	return query, Counts{Total: total, Filtered: filtered}, nil
+}
@@ -0,0 +1,83 @@
+package datatables
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+func TestApplyTo(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE active = ?")).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(25))
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE active = ? AND `name` LIKE ?")).
+		WithArgs(true, "%John%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+	mock.ExpectQuery(qm("SELECT * FROM `users` WHERE active = ? AND `name` LIKE ? ORDER BY `name` DESC LIMIT ? OFFSET ?")).
+		WithArgs(true, "%John%", 10, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(11, "John Doe"))
+
+	dt := New(nil).Model(&User{})
+	dt.Req(Request{
+		Draw:    1,
+		Start:   10,
+		Length:  10,
+		Search:  Search{Value: "John"},
+		Order:   []Order{{Column: 0, Dir: "desc"}},
+		Columns: []ColumnRequest{{Name: "name", Data: "name", Searchable: true, Orderable: true}},
+	})
+
+	query, counts, err := dt.ApplyTo(db.Where("active = ?", true))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if counts != (Counts{Total: 25, Filtered: 12}) {
+		t.Errorf("expected counts {25 12}, got %+v", counts)
+	}
+
+	var users []User
+	if err := query.Find(&users).Error; err != nil {
+		t.Fatalf("failed to execute query: %v", err)
+	}
+	if len(users) != 1 || users[0].Name != "John Doe" {
+		t.Errorf("expected John Doe, got %+v", users)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+
+	if _, _, err := New(db).ApplyTo(nil); err == nil {
+		t.Errorf("expected validation error, got nil")
+	}
+}
+
+func TestApplyToKeepsQuery(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	dt := New(db).Model(&User{})
+	dt.Req(Request{Draw: 1, Length: -1})
+
+	query, counts, err := dt.ApplyTo(nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if counts != (Counts{Total: 3, Filtered: 3}) {
+		t.Errorf("expected counts {3 3}, got %+v", counts)
+	}
+
+	var result []map[string]any
+	stmt := query.Session(&gorm.Session{DryRun: true}).Find(&result).Statement
+	if want, got := "SELECT * FROM `users`", strings.TrimSpace(stmt.SQL.String()); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package datatables
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AuditEntry describes a processed DataTable request for audit purposes.
+//
+// Fields:
+//   - Time: When the request was processed.
+//   - Actor: Who made the request, as resolved by the actor function.
+//   - Table: The table that was queried.
+//   - Search: The global search value applied.
+//   - Order: The ordering applied, as "column direction" pairs.
+//   - Rows: The number of rows returned.
+//   - RecordsTotal: The total number of records.
+//   - RecordsFiltered: The number of records after filtering.
+//   - Error: The error message if the request failed, empty otherwise.
+type AuditEntry struct {
+	Time            time.Time
+	Actor           string
+	Table           string
+	Search          string
+	Order           []string
+	Rows            int
+	RecordsTotal    int64
+	RecordsFiltered int64
+	Error           string
+}
+
+// AuditSink stores audit entries.
+type AuditSink interface {
+	Record(ctx context.Context, entry AuditEntry) error
+}
+
+// AuditSinkFunc is an adapter that allows the use of an ordinary function
+// as an AuditSink.
+type AuditSinkFunc func(ctx context.Context, entry AuditEntry) error
+
+// Record calls f(ctx, entry).
+func (f AuditSinkFunc) Record(ctx context.Context, entry AuditEntry) error {
+	return f(ctx, entry)
+}
+
+// NewLoggerAuditSink returns an AuditSink writing each entry as an info
+// record to the given logger.
+func NewLoggerAuditSink(logger *slog.Logger) AuditSink {
+	return AuditSinkFunc(func(ctx context.Context, entry AuditEntry) error {
+		logger.LogAttrs(ctx, slog.LevelInfo, "datatables: audit",
+			slog.Time("time", entry.Time),
+			slog.String("actor", entry.Actor),
+			slog.String("table", entry.Table),
+			slog.String("search", entry.Search),
+			slog.String("order", strings.Join(entry.Order, ", ")),
+			slog.Int("rows", entry.Rows),
+			slog.Int64("records_total", entry.RecordsTotal),
+			slog.Int64("records_filtered", entry.RecordsFiltered),
+			slog.String("error", entry.Error),
+		)
+		return nil
+	})
+}
+
+// NewDBAuditSink returns an AuditSink inserting each entry into the given
+// table, which must have the columns time, actor, table_name, search,
+// order_by, rows, records_total, records_filtered, and error.
+func NewDBAuditSink(db *gorm.DB, table string) AuditSink {
+	return AuditSinkFunc(func(ctx context.Context, entry AuditEntry) error {
+		return db.WithContext(ctx).Table(table).Create(map[string]any{
+			"time":             entry.Time,
+			"actor":            entry.Actor,
+			"table_name":       entry.Table,
+			"search":           entry.Search,
+			"order_by":         strings.Join(entry.Order, ", "),
+			"rows":             entry.Rows,
+			"records_total":    entry.RecordsTotal,
+			"records_filtered": entry.RecordsFiltered,
+			"error":            entry.Error,
+		}).Error
+	})
+}
+
+// Audit enables audit logging of the DataTable requests.
+//
+// After each Make call, an AuditEntry is recorded to the sink with the actor
+// resolved from the context of the DataTable's gorm statement, the search and
+// ordering applied, and the number of rows returned. Failed requests are
+// recorded too. If the sink fails, the request fails with the sink error so
+// no access goes unaudited. The actor function may be nil.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) Audit(sink AuditSink, actor func(ctx context.Context) string) *DataTable {
+	dt.auditSink = sink
+	dt.auditActor = actor
+	return dt
+}
+
+// audit records an audit entry for the given result or error. It does
+// nothing if auditing is disabled.
+func (dt *DataTable) audit(res *result, err error) error {
+	if dt.auditSink == nil {
+		return nil
+	}
+
+	ctx := dt.context()
+	entry := AuditEntry{
+		Time:   time.Now(),
+		Table:  dt.tableName(),
+		Search: dt.req.Search.Value,
+	}
+	if dt.auditActor != nil {
+		entry.Actor = dt.auditActor(ctx)
+	}
+	entry.Order = dt.orderPairs()
+	if res != nil {
+		entry.Rows = res.rows
+		entry.RecordsTotal = res.total
+		entry.RecordsFiltered = res.filtered
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	return dt.auditSink.Record(ctx, entry)
+}
+
+// orderPairs returns the ordering of the request as "column direction"
+// pairs.
+func (dt *DataTable) orderPairs() []string {
+	var pairs []string
+	for _, order := range dt.req.Order {
+		if order.Column >= 0 && order.Column < len(dt.req.Columns) {
+			pairs = append(pairs, fmt.Sprintf("%s %s", dt.req.Columns[order.Column].Data, strings.ToLower(order.Dir)))
+		}
+	}
+	return pairs
+}
@@ -0,0 +1,131 @@
+package datatables
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+type actorKey struct{}
+
+func TestAudit(t *testing.T) {
+	db, mock := newMockDB(t)
+	ctx := context.WithValue(context.Background(), actorKey{}, "alice")
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE `name` LIKE ?")).
+		WithArgs("%John%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(qm("SELECT * FROM `users` WHERE `name` LIKE ? ORDER BY `name` DESC LIMIT ?")).
+		WithArgs("%John%", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+
+	var entries []AuditEntry
+	dt := New(db.WithContext(ctx))
+	dt.Model(&User{})
+	dt.Audit(AuditSinkFunc(func(ctx context.Context, entry AuditEntry) error {
+		entries = append(entries, entry)
+		return nil
+	}), func(ctx context.Context) string {
+		return ctx.Value(actorKey{}).(string)
+	})
+	dt.Req(Request{
+		Draw:   1,
+		Length: 10,
+		Search: Search{Value: "John"},
+		Order:  []Order{{Column: 0, Dir: "DESC"}},
+		Columns: []ColumnRequest{
+			{Name: "name", Data: "name", Searchable: true, Orderable: true},
+		},
+	})
+
+	if _, err := dt.Make(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 audit entry, got %d", len(entries))
+	}
+	entry := entries[0]
+	expected := AuditEntry{
+		Time:            entry.Time,
+		Actor:           "alice",
+		Table:           "users",
+		Search:          "John",
+		Order:           []string{"name desc"},
+		Rows:            1,
+		RecordsTotal:    5,
+		RecordsFiltered: 1,
+	}
+	if !reflect.DeepEqual(entry, expected) {
+		t.Errorf("expected %+v, got %+v", expected, entry)
+	}
+
+	t.Run("failed_request", func(t *testing.T) {
+		mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+			WillReturnError(errors.New("boom"))
+
+		if _, err := dt.Make(); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if last := entries[len(entries)-1]; last.Error != "boom" {
+			t.Errorf("expected failed request to be audited, got %+v", last)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestAuditSinkError(t *testing.T) {
+	sinkErr := errors.New("sink unavailable")
+	dt := New(nil)
+	dt.Audit(AuditSinkFunc(func(ctx context.Context, entry AuditEntry) error {
+		return sinkErr
+	}), nil)
+
+	if err := dt.audit(&result{}, nil); !errors.Is(err, sinkErr) {
+		t.Errorf("expected sink error, got %v", err)
+	}
+}
+
+func TestLoggerAuditSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewLoggerAuditSink(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	err := sink.Record(context.Background(), AuditEntry{Actor: "alice", Table: "users", Rows: 3})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, want := range []string{"actor=alice", "table=users", "rows=3"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected log to contain %q, got %q", want, buf.String())
+		}
+	}
+}
+
+func TestDBAuditSink(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(qm("INSERT INTO `audit_logs`")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	sink := NewDBAuditSink(db, "audit_logs")
+	if err := sink.Record(context.Background(), AuditEntry{Actor: "alice", Table: "users"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package datatables
+
+import (
+	"encoding/json"
+	"html/template"
+	"strings"
+)
+
+// Builder renders the HTML table skeleton and the DataTables initialization
+// options of a table definition, so that the column configuration is not
+// duplicated in templates.
+//
+// Fields:
+//   - ID: The id attribute of the table element.
+//   - AjaxURL: The URL of the endpoint serving the table.
+//   - Method: The HTTP method of the ajax requests, GET when empty.
+//   - Class: The class attribute of the table element.
+//   - Order: The initial ordering, by column index.
+//   - Options: Additional DataTables options, merged into the generated
+//     ones and taking precedence over them.
+//   - Definition: The definition of the table.
+type Builder struct {
+	ID         string
+	AjaxURL    string
+	Method     string
+	Class      string
+	Order      []Order
+	Options    map[string]any
+	Definition *TableDefinition
+}
+
+// NewBuilder returns a Builder for the table with the given element id,
+// served at the given URL.
+func NewBuilder(id, ajaxURL string, definition *TableDefinition) *Builder {
+	return &Builder{ID: id, AjaxURL: ajaxURL, Class: "display", Definition: definition}
+}
+
+// HTML returns the table element with a header row holding the label of
+// every column listed by DataTable.ColumnsJSON, hidden ones included, since
+// DataTables expects a header cell per column.
+func (b *Builder) HTML() template.HTML {
+	dt := b.Definition.New(nil, Request{})
+
+	var sb strings.Builder
+	sb.WriteString(`<table id="` + template.HTMLEscapeString(b.ID) + `"`)
+	if b.Class != "" {
+		sb.WriteString(` class="` + template.HTMLEscapeString(b.Class) + `"`)
+	}
+	sb.WriteString("><thead><tr>")
+	for _, col := range dt.clientColumns() {
+		sb.WriteString("<th>" + template.HTMLEscapeString(col["title"].(string)) + "</th>")
+	}
+	sb.WriteString("</tr></thead></table>")
+	return template.HTML(sb.String())
+}
+
+// InitOptions returns the DataTables initialization options: server-side
+// processing from the ajax URL, the columns as returned by
+// DataTable.ColumnsJSON, the initial order, and the searching,
+// ordering, paging, and length menu settings of the configuration.
+func (b *Builder) InitOptions() map[string]any {
+	dt := b.Definition.New(nil, Request{})
+	method := b.Method
+	if method == "" {
+		method = "GET"
+	}
+
+	order := make([][]any, 0, len(b.Order))
+	for _, o := range b.Order {
+		order = append(order, []any{o.Column, strings.ToLower(o.Dir)})
+	}
+
+	options := map[string]any{
+		"serverSide": true,
+		"processing": true,
+		"ajax":       map[string]any{"url": b.AjaxURL, "type": method},
+		"columns":    dt.clientColumns(),
+		"order":      order,
+		"searching":  dt.config.Searchable,
+		"ordering":   dt.config.Orderable,
+		"paging":     dt.config.Paginate,
+	}
+	if len(dt.config.AllowedLengths) > 0 {
+		options["lengthMenu"] = dt.config.AllowedLengths
+	}
+	for key, value := range b.Options {
+		options[key] = value
+	}
+	return options
+}
+
+// Script returns a script element initializing the table with the
+// initialization options. Returns an error if an additional option cannot
+// be encoded as JSON.
+func (b *Builder) Script() (template.HTML, error) {
+	selector, err := json.Marshal("#" + b.ID)
+	if err != nil {
+		return "", err
+	}
+	options, err := json.Marshal(b.InitOptions())
+	if err != nil {
+		return "", err
+	}
+	return template.HTML("<script>new DataTable(" + string(selector) + ", " + string(options) + ");</script>"), nil
+}
@@ -0,0 +1,75 @@
+package datatables
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Searchable = false
+	cfg.AllowedLengths = []int{10, 25}
+	definition := &TableDefinition{
+		Model:  &User{},
+		Config: &cfg,
+		Columns: []Column{
+			{Name: "ID", Data: "id", Orderable: true},
+			{Name: "Name <full>", Data: "name", Searchable: true, Orderable: true},
+			{Name: "secret_key", Data: "secret", Label: "Secret"},
+		},
+		Only: []string{"id", "name"},
+	}
+	b := NewBuilder("users", "/users", definition)
+	b.Order = []Order{{Column: 1, Dir: "DESC"}}
+	b.Options = map[string]any{"pageLength": 25}
+
+	t.Run("html", func(t *testing.T) {
+		expected := `<table id="users" class="display"><thead><tr><th>ID</th><th>Name &lt;full&gt;</th><th>Secret</th></tr></thead></table>`
+		if got := string(b.HTML()); got != expected {
+			t.Errorf("expected %s, got %s", expected, got)
+		}
+	})
+
+	t.Run("init options", func(t *testing.T) {
+		b, err := json.Marshal(b.InitOptions())
+		if err != nil {
+			t.Fatalf("failed to encode options: %v", err)
+		}
+		var got, expected map[string]any
+		_ = json.Unmarshal(b, &got)
+		_ = json.Unmarshal([]byte(`{
+			"serverSide": true,
+			"processing": true,
+			"ajax": {"url": "/users", "type": "GET"},
+			"columns": [
+				{"data": "id", "name": "ID", "title": "ID", "searchable": false, "orderable": true, "visible": true},
+				{"data": "name", "name": "Name <full>", "title": "Name <full>", "searchable": true, "orderable": true, "visible": true},
+				{"data": "secret", "name": "secret_key", "title": "Secret", "searchable": false, "orderable": false, "visible": false}
+			],
+			"order": [[1, "desc"]],
+			"searching": false,
+			"ordering": true,
+			"paging": true,
+			"lengthMenu": [10, 25],
+			"pageLength": 25
+		}`), &expected)
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("script", func(t *testing.T) {
+		script, err := b.Script()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !strings.HasPrefix(string(script), `<script>new DataTable("#users", {`) {
+			t.Errorf("unexpected script %s", script)
+		}
+		if strings.Contains(string(script), "<full>") {
+			t.Errorf("expected the labels to be escaped in the script, got %s", script)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package datatables
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Canonicalize returns the canonical form of the request, so that requests
+// asking for the same rows compare equal regardless of how the client
+// happened to send them. It is the basis of Hash.
+//
+// The draw counter is cleared, the columns are sorted by data and name with
+// the order column indices remapped accordingly, the order directions are
+// normalized to "asc" or "desc", the regex flag of an empty search is
+// cleared, the filter names and signed filters are sorted and deduplicated,
+// and empty slices are set to nil. The given request is not modified.
+//
+// Note that rows in ResponseFormatArray or with Config.PreserveColumnOrder
+// follow the order of the request columns, which the canonical form loses.
+func Canonicalize(req Request) Request {
+	req.Draw = 0
+	req.Search = canonicalSearch(req.Search)
+
+	indices := make([]int, len(req.Columns))
+	for i := range indices {
+		indices[i] = i
+	}
+	slices.SortStableFunc(indices, func(a, b int) int {
+		return cmp.Or(
+			strings.Compare(req.Columns[a].Data, req.Columns[b].Data),
+			strings.Compare(req.Columns[a].Name, req.Columns[b].Name),
+		)
+	})
+	positions := make(map[int]int, len(indices))
+	columns := make([]ColumnRequest, len(indices))
+	for i, index := range indices {
+		positions[index] = i
+		columns[i] = req.Columns[index]
+		columns[i].Search = canonicalSearch(columns[i].Search)
+	}
+	req.Columns = columns
+
+	order := make([]Order, len(req.Order))
+	for i, o := range req.Order {
+		if position, ok := positions[o.Column]; ok {
+			o.Column = position
+		}
+		if strings.ToUpper(o.Dir) == orderDescending {
+			o.Dir = strings.ToLower(orderDescending)
+		} else {
+			o.Dir = strings.ToLower(orderAscending)
+		}
+		order[i] = o
+	}
+	req.Order = order
+
+	req.Filters = canonicalStrings(req.Filters)
+	req.SignedFilters = canonicalStrings(req.SignedFilters)
+	if len(req.Columns) == 0 {
+		req.Columns = nil
+	}
+	if len(req.Order) == 0 {
+		req.Order = nil
+	}
+	if len(req.Extensions) == 0 {
+		req.Extensions = nil
+	}
+	return req
+}
+
+// Hash returns a hex-encoded SHA-256 hash of the canonical form of the
+// request, see Canonicalize, to be used as a cache key: requests asking for
+// the same rows have the same hash.
+func Hash(req Request) string {
+	sum := sha256.Sum256(canonicalBytes(req))
+	return hex.EncodeToString(sum[:])
+}
+
+// canonicalBytes returns the encoding of the canonical form of the request.
+// The extensions that cannot be encoded to JSON are formatted with fmt.
+func canonicalBytes(req Request) []byte {
+	req = Canonicalize(req)
+	b, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Appendf(nil, "%#v", req)
+	}
+	return b
+}
+
+// canonicalSearch returns the search with the regex flag cleared when the
+// search value is empty.
+func canonicalSearch(search Search) Search {
+	if search.Value == "" {
+		return Search{}
+	}
+	return search
+}
+
+// canonicalStrings returns the values sorted and deduplicated, or nil when
+// there are none.
+func canonicalStrings(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	return slices.Compact(slices.Sorted(slices.Values(values)))
+}
@@ -0,0 +1,84 @@
+package datatables
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCanonicalize(t *testing.T) {
+	req := Request{
+		Draw:   3,
+		Length: 10,
+		Search: Search{Regex: true},
+		Order:  []Order{{Column: 0, Dir: "DESC"}, {Column: 1}},
+		Columns: []ColumnRequest{
+			{Data: "name", Searchable: true, Search: Search{Value: "John"}},
+			{Data: "id", Orderable: true, Search: Search{Regex: true}},
+		},
+		Filters:       []string{"mine", "active", "mine"},
+		SignedFilters: []string{},
+	}
+
+	expected := Request{
+		Length: 10,
+		Order:  []Order{{Column: 1, Dir: "desc"}, {Column: 0, Dir: "asc"}},
+		Columns: []ColumnRequest{
+			{Data: "id", Orderable: true},
+			{Data: "name", Searchable: true, Search: Search{Value: "John"}},
+		},
+		Filters: []string{"active", "mine"},
+	}
+	if got := Canonicalize(req); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+	if req.Columns[0].Data != "name" || req.Order[0].Dir != "DESC" {
+		t.Errorf("expected the request to be left unmodified, got %+v", req)
+	}
+}
+
+func TestHash(t *testing.T) {
+	base := Request{
+		Draw:   1,
+		Length: 10,
+		Order:  []Order{{Column: 1, Dir: "asc"}},
+		Columns: []ColumnRequest{
+			{Data: "id"},
+			{Data: "name", Search: Search{Value: "John"}},
+		},
+		Extensions: map[string]any{"tenant": "acme"},
+	}
+
+	same := []Request{
+		{
+			Draw:   7,
+			Length: 10,
+			Order:  []Order{{Column: 0}},
+			Columns: []ColumnRequest{
+				{Data: "name", Search: Search{Value: "John"}},
+				{Data: "id", Search: Search{Regex: true}},
+			},
+			Extensions: map[string]any{"tenant": "acme"},
+		},
+	}
+	for _, req := range same {
+		if Hash(req) != Hash(base) {
+			t.Errorf("expected %+v to hash like %+v", req, base)
+		}
+	}
+
+	different := []Request{
+		{Length: 10, Order: []Order{{Column: 1, Dir: "desc"}}, Columns: base.Columns, Extensions: base.Extensions},
+		{Length: 25, Order: base.Order, Columns: base.Columns, Extensions: base.Extensions},
+		{Length: 10, Order: base.Order, Columns: base.Columns, Extensions: map[string]any{"tenant": "other"}},
+		{Length: 10, Order: base.Order, Columns: base.Columns[:1], Extensions: base.Extensions},
+	}
+	for _, req := range different {
+		if Hash(req) == Hash(base) {
+			t.Errorf("expected %+v to hash differently from %+v", req, base)
+		}
+	}
+
+	if h := Hash(Request{Extensions: map[string]any{"fn": func() {}}}); len(h) != 64 {
+		t.Errorf("expected a hex-encoded SHA-256 hash, got %q", h)
+	}
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"gorm.io/gorm/schema"
+)
+
+// field is a column of the model.
+type field struct {
+	Column string // The database column, used as the column name and data.
+	Title  string // The header of the client-side column.
+}
+
+// model is the gorm model the files are generated for.
+type model struct {
+	Package string
+	Type    string
+	Fields  []field
+}
+
+// gormModelFields are the fields of an embedded gorm.Model.
+var gormModelFields = []string{"ID", "CreatedAt", "UpdatedAt", "DeletedAt"}
+
+// columnTypes are the qualified types of fields stored in a single column.
+var columnTypes = map[string]bool{
+	"time.Time":          true,
+	"sql.NullBool":       true,
+	"sql.NullByte":       true,
+	"sql.NullFloat64":    true,
+	"sql.NullInt16":      true,
+	"sql.NullInt32":      true,
+	"sql.NullInt64":      true,
+	"sql.NullString":     true,
+	"sql.NullTime":       true,
+	"gorm.DeletedAt":     true,
+	"uuid.UUID":          true,
+	"decimal.Decimal":    true,
+	"datatypes.JSON":     true,
+	"datatypes.Date":     true,
+	"datatypes.Time":     true,
+	"datatypes.JSONMap":  true,
+	"datatypes.JSONType": true,
+}
+
+// parseModel finds the struct type of the given name in the Go source and
+// returns its columns. Fields ignored by gorm and relations are left out.
+func parseModel(src []byte, typeName string) (*model, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var st *ast.StructType
+	ast.Inspect(file, func(n ast.Node) bool {
+		if spec, ok := n.(*ast.TypeSpec); ok && spec.Name.Name == typeName {
+			st, _ = spec.Type.(*ast.StructType)
+		}
+		return st == nil
+	})
+	if st == nil {
+		return nil, fmt.Errorf("struct type %s not found", typeName)
+	}
+
+	m := &model{Package: file.Name.Name, Type: typeName}
+	naming := schema.NamingStrategy{}
+	for _, f := range st.Fields.List {
+		tag := reflect.StructTag(strings.Trim(litValue(f.Tag), "`"))
+		settings := schema.ParseTagSetting(tag.Get("gorm"), ";")
+		if _, ignored := settings["-"]; ignored || tag.Get("gorm") == "-" {
+			continue
+		}
+
+		if len(f.Names) == 0 {
+			if typeString(f.Type) == "gorm.Model" {
+				for _, name := range gormModelFields {
+					m.Fields = append(m.Fields, field{Column: naming.ColumnName("", name), Title: title(name)})
+				}
+			}
+			continue
+		}
+		if !isColumnType(f.Type) {
+			continue
+		}
+		for _, name := range f.Names {
+			if !name.IsExported() {
+				continue
+			}
+			column := settings["COLUMN"]
+			if column == "" {
+				column = naming.ColumnName("", name.Name)
+			}
+			m.Fields = append(m.Fields, field{Column: column, Title: title(name.Name)})
+		}
+	}
+	return m, nil
+}
+
+// litValue returns the value of the given literal, or an empty string.
+func litValue(lit *ast.BasicLit) string {
+	if lit == nil {
+		return ""
+	}
+	return lit.Value
+}
+
+// typeString returns the source form of simple type expressions.
+func typeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return typeString(t.X) + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X)
+	case *ast.ArrayType:
+		return "[]" + typeString(t.Elt)
+	case *ast.IndexExpr:
+		return typeString(t.X)
+	default:
+		return ""
+	}
+}
+
+// isColumnType reports whether a field of the given type is stored in a
+// single column, as opposed to a relation.
+func isColumnType(expr ast.Expr) bool {
+	typ := strings.TrimPrefix(typeString(expr), "*")
+	switch {
+	case typ == "[]byte":
+		return true
+	case strings.HasPrefix(typ, "[]"), typ == "":
+		return false
+	case strings.Contains(typ, "."):
+		return columnTypes[typ]
+	default:
+		return !ast.IsExported(typ)
+	}
+}
+
+// title returns the column header for the given field name, splitting
+// words on case changes: CreatedAt becomes "Created At" and ID stays "ID".
+func title(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// tableTemplate generates the table definition and its handler.
+var tableTemplate = template.Must(template.New("table").Parse(`// Generated by datatables-gen from the {{.Type}} model. Edit freely.
+
+package {{.Package}}
+
+import (
+	"net/http"
+
+	datatables "github.com/ZihxS/golang-gorm-datatables"
+	"gorm.io/gorm"
+)
+
+// {{.Type}}Table defines the DataTable listing {{.Type}} records.
+var {{.Type}}Table = &datatables.TableDefinition{
+	Model: &{{.Type}}{},
+	Columns: []datatables.Column{
+{{- range .Fields}}
+		{Name: "{{.Column}}", Data: "{{.Column}}", Label: {{printf "%q" .Title}}, Searchable: true, Orderable: true},
+{{- end}}
+	},
+}
+
+// {{.Type}}TableHandler returns the http.Handler serving {{.Type}}Table.
+func {{.Type}}TableHandler(db *gorm.DB) http.Handler {
+	return datatables.Handler(func(r *http.Request, req datatables.Request) (*datatables.DataTable, error) {
+		return {{.Type}}Table.New(db.WithContext(r.Context()), req), nil
+	})
+}
+`))
+
+// testTemplate generates the tests of the table definition.
+var testTemplate = template.Must(template.New("test").Parse(`// Generated by datatables-gen from the {{.Type}} model. Edit freely.
+
+package {{.Package}}
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test{{.Type}}TableColumns(t *testing.T) {
+	expected := []string{ {{- range $i, $f := .Fields}}{{if $i}}, {{end}}"{{$f.Column}}"{{end -}} }
+	if len({{.Type}}Table.Columns) != len(expected) {
+		t.Fatalf("expected %d columns, got %d", len(expected), len({{.Type}}Table.Columns))
+	}
+	for i, col := range {{.Type}}Table.Columns {
+		if col.Data != expected[i] {
+			t.Errorf("expected column %d to be %q, got %q", i, expected[i], col.Data)
+		}
+	}
+}
+
+func Test{{.Type}}TableHandlerInvalidRequest(t *testing.T) {
+	rec := httptest.NewRecorder()
+	{{.Type}}TableHandler(nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?draw=x", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rec.Code)
+	}
+}
+`))
+
+// clientColumn is a column of the DataTables client-side configuration.
+type clientColumn struct {
+	Data  string `json:"data"`
+	Name  string `json:"name"`
+	Title string `json:"title"`
+}
+
+// generate returns the files generated for the model, keyed by name: the
+// table definition and handler, their tests, and the client-side columns.
+func generate(m *model) (map[string][]byte, error) {
+	base := strings.ToLower(m.Type) + "_table"
+	files := make(map[string][]byte, 3)
+	for name, tmpl := range map[string]*template.Template{
+		base + ".go":      tableTemplate,
+		base + "_test.go": testTemplate,
+	} {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, m); err != nil {
+			return nil, err
+		}
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("format %s: %w", name, err)
+		}
+		files[name] = src
+	}
+
+	columns := make([]clientColumn, len(m.Fields))
+	for i, f := range m.Fields {
+		columns[i] = clientColumn{Data: f.Column, Name: f.Column, Title: f.Title}
+	}
+	b, err := json.MarshalIndent(columns, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	files[strings.ToLower(m.Type)+"_columns.json"] = append(b, '\n')
+	return files, nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const userSource = `package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type User struct {
+	gorm.Model
+	Name      string
+	Email     string ` + "`gorm:\"column:email_address\"`" + `
+	Secret    string ` + "`gorm:\"-\"`" + `
+	LastLogin *time.Time
+	APIKey    string
+	Profile   Profile
+	Orders    []Order
+	internal  int
+}
+`
+
+func TestParseModel(t *testing.T) {
+	m, err := parseModel([]byte(userSource), "User")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := &model{
+		Package: "models",
+		Type:    "User",
+		Fields: []field{
+			{Column: "id", Title: "ID"},
+			{Column: "created_at", Title: "Created At"},
+			{Column: "updated_at", Title: "Updated At"},
+			{Column: "deleted_at", Title: "Deleted At"},
+			{Column: "name", Title: "Name"},
+			{Column: "email_address", Title: "Email"},
+			{Column: "last_login", Title: "Last Login"},
+			{Column: "api_key", Title: "API Key"},
+		},
+	}
+	if !reflect.DeepEqual(m, expected) {
+		t.Errorf("expected %+v, got %+v", expected, m)
+	}
+
+	if _, err := parseModel([]byte(userSource), "Order"); err == nil {
+		t.Error("expected an error for a missing type")
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "user.go")
+	if err := os.WriteFile(file, []byte(userSource), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := run([]string{"-file", file, "-type", "User"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	table, err := os.ReadFile(filepath.Join(dir, "user_table.go"))
+	if err != nil {
+		t.Fatalf("expected the table file, got %v", err)
+	}
+	for _, want := range []string{
+		"package models",
+		"var UserTable = &datatables.TableDefinition{",
+		`{Name: "email_address", Data: "email_address", Label: "Email", Searchable: true, Orderable: true},`,
+		"func UserTableHandler(db *gorm.DB) http.Handler {",
+	} {
+		if !strings.Contains(string(table), want) {
+			t.Errorf("expected the table file to contain %q", want)
+		}
+	}
+	for _, name := range []string{"user_table_test.go", "user_columns.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected %s to be written, got %v", name, err)
+		}
+	}
+
+	if err := run([]string{"-file", file, "-type", "User"}); err == nil {
+		t.Error("expected an error when the files exist")
+	}
+	if err := run([]string{"-file", file, "-type", "User", "-force"}); err != nil {
+		t.Errorf("expected -force to overwrite the files, got %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+// Command datatables-gen scaffolds a DataTable for a gorm model.
+//
+// It reads the Go file declaring the model and writes, next to it or in the
+// output directory, a ready-to-edit Go file with the table definition and
+// its HTTP handler, the matching tests, and the columns of the client-side
+// DataTables configuration as JSON:
+//
+//	datatables-gen -file models/user.go -type User
+//
+// writes models/user_table.go, models/user_table_test.go, and
+// models/user_columns.json. Existing files are not overwritten unless -force
+// is set.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "datatables-gen:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the command line arguments and writes the generated files.
+func run(args []string) error {
+	flags := flag.NewFlagSet("datatables-gen", flag.ContinueOnError)
+	file := flags.String("file", "", "Go file declaring the model (required)")
+	typeName := flags.String("type", "", "name of the model struct type (required)")
+	out := flags.String("out", "", "output directory (default: the directory of -file)")
+	force := flags.Bool("force", false, "overwrite existing files")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" || *typeName == "" {
+		flags.Usage()
+		return errors.New("-file and -type are required")
+	}
+	if *out == "" {
+		*out = filepath.Dir(*file)
+	}
+
+	src, err := os.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+	m, err := parseModel(src, *typeName)
+	if err != nil {
+		return err
+	}
+	files, err := generate(m)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := filepath.Join(*out, name)
+		if _, err := os.Stat(path); err == nil && !*force {
+			return fmt.Errorf("%s already exists, use -force to overwrite it", path)
+		} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	for _, name := range names {
+		path := filepath.Join(*out, name)
+		if err := os.WriteFile(path, files[name], 0o644); err != nil {
+			return err
+		}
+		fmt.Println("wrote", path)
+	}
+	return nil
+}
@@ -1,7 +1,12 @@
 package datatables
 
 import (
+	"errors"
+	"log/slog"
 	"slices"
+	"strings"
+
+	"gorm.io/gorm/clause"
 )
 
 // Column represents a single column in a DataTable.
@@ -13,13 +18,70 @@ import (
 //   - Orderable: A boolean indicating whether the column is orderable.
 //   - Name: The display name of the column.
 //   - Data: The data property name of the column.
+//   - Type: An optional type hint, such as ColumnTypeUUID.
+//   - SQL: An optional SQL column or expression backing the Data key, such as
+//     "customers.name AS customer". When empty, Name is used.
 //   - RenderFunc: An optional function that can be used to render the column value.
+//   - Label: An optional title of the column, shown in the table header and
+//     export headers instead of the Name, such as "Email address" for an
+//     email_address column.
+//   - Relation: The optional name of the relation of the model holding the
+//     column, such as "Profile", in which case Name is a column of the
+//     related table. The column is searched with an EXISTS subquery on the
+//     related table, and is neither ordered nor selected, its values coming
+//     from the relations preloaded with With.
+//   - Renders: Optional functions rendering the orthogonal data of the
+//     column, keyed by rendering type, such as RenderSort and RenderExport.
+//     The cells of the column are then objects holding the value of each
+//     type, the display value defaulting to the output of RenderFunc, to be
+//     read with the columns.render option of DataTables.
+//   - ExportFunc: An optional function rendering the value of the column in
+//     the exports, such as a plain formatted number where RenderFunc renders
+//     HTML, see ExportValue.
 type Column struct {
-	Searchable bool
-	Orderable  bool
-	Name       string
-	Data       string
-	RenderFunc func(map[string]any) any
+	Searchable bool                                `json:"searchable" yaml:"searchable"`
+	Orderable  bool                                `json:"orderable" yaml:"orderable"`
+	Name       string                              `json:"name" yaml:"name"`
+	Data       string                              `json:"data" yaml:"data"`
+	Type       string                              `json:"type" yaml:"type"`
+	SQL        string                              `json:"sql" yaml:"sql"`
+	RenderFunc func(map[string]any) any            `json:"-" yaml:"-"`
+	Label      string                              `json:"label" yaml:"label"`
+	Relation   string                              `json:"relation" yaml:"relation"`
+	Renders    map[string]func(map[string]any) any `json:"-" yaml:"-"`
+	ExportFunc func(map[string]any) any            `json:"-" yaml:"-"`
+}
+
+// Rendering types of the orthogonal data of a column, see Column.Renders.
+const (
+	RenderDisplay = "display" // The value shown in the table.
+	RenderSort    = "sort"    // The value the table is sorted on.
+	RenderExport  = "export"  // The value written to the exports.
+)
+
+// expression returns the SQL expression backing the column, that is its SQL
+// field without a trailing alias, or its Name when SQL is empty.
+func (c Column) expression() string {
+	if c.SQL == "" {
+		return c.Name
+	}
+	expr := strings.TrimSpace(c.SQL)
+	if i := strings.LastIndex(strings.ToUpper(expr), " AS "); i != -1 && identifierPattern.MatchString(strings.Trim(strings.TrimSpace(expr[i+4:]), "`\"")) {
+		expr = strings.TrimSpace(expr[:i])
+	}
+	return expr
+}
+
+// sqlColumn returns the column reference used to search and order on the
+// column. A column backed by an SQL expression is referenced raw.
+func (c Column) sqlColumn() clause.Column {
+	if c.SQL != "" {
+		return clause.Column{Name: c.expression(), Raw: true}
+	}
+	if c.Relation != "" {
+		return clause.Column{Table: relationAlias, Name: c.Name}
+	}
+	return clause.Column{Name: c.Name}
 }
 
 // initColumnsMap initializes the columnsMap field of DataTable with the
@@ -38,8 +100,18 @@ func (dt *DataTable) initColumnsMap() {
 // whitelist and blacklist constraints. If both whitelist and blacklist are empty,
 // all columns are allowed. If the whitelist is non-empty, only columns explicitly
 // listed are allowed. If the blacklist is non-empty and the whitelist is empty,
-// only columns not listed in the blacklist are allowed.
+// only columns not listed in the blacklist are allowed. Columns excluded with
+// Except or denied by the column policy are never allowed, nor, in strict
+// mode, the columns that were not declared.
 func (dt *DataTable) isColumnAllowed(name string) bool {
+	if dt.deniedColumns[name] || dt.excludedColumns[name] {
+		return false
+	}
+
+	if dt.config.Strict && !dt.declaredColumns[name] {
+		return false
+	}
+
 	if len(dt.whitelistColumns) == 0 && len(dt.blacklistColumns) == 0 {
 		return true
 	}
@@ -55,6 +127,17 @@ func (dt *DataTable) isColumnAllowed(name string) bool {
 // field exists, it is overwritten. The column is added to the columnsMap
 // with the Data field as the key.
 func (dt *DataTable) AddColumn(col Column) *DataTable {
+	if dt.declaredColumns == nil {
+		dt.declaredColumns = make(map[string]bool)
+	}
+	dt.declaredColumns[col.Data] = true
+	return dt.addColumn(col)
+}
+
+// addColumn adds the column like AddColumn, without declaring it. It is used
+// for the columns coming from the request.
+func (dt *DataTable) addColumn(col Column) *DataTable {
+	dt.plans = nil
 	if _, ok := dt.columnsMap[col.Data]; !ok {
 		dt.columns = append(dt.columns, col)
 	}
@@ -72,7 +155,13 @@ func (dt *DataTable) AddColumns(columns ...Column) *DataTable {
 			Data:       v.Data,
 			Searchable: v.Searchable,
 			Orderable:  v.Orderable,
+			Type:       v.Type,
+			SQL:        v.SQL,
 			RenderFunc: v.RenderFunc,
+			Label:      v.Label,
+			Relation:   v.Relation,
+			Renders:    v.Renders,
+			ExportFunc: v.ExportFunc,
 		}
 		dt.AddColumn(newCol)
 	}
@@ -88,6 +177,7 @@ func (dt *DataTable) AddColumns(columns ...Column) *DataTable {
 // The RenderFunc field of the column is replaced with a new one, and the new
 // column is stored in the columnsMap with the Data field as the key.
 func (dt *DataTable) EditColumn(name string, editFunc func(any) any) *DataTable {
+	dt.plans = nil
 	if col, exists := dt.columnsMap[name]; exists {
 		col.RenderFunc = func(row map[string]any) any {
 			value := row[col.Data]
@@ -109,6 +199,7 @@ func (dt *DataTable) EditColumn(name string, editFunc func(any) any) *DataTable
 // exists, it is removed from the DataTable. If a column does not exist, the
 // function does nothing.
 func (dt *DataTable) RemoveColumn(data ...string) *DataTable {
+	dt.plans = nil
 	exclude := make(map[string]bool)
 	for _, d := range data {
 		exclude[d] = true
@@ -147,16 +238,59 @@ func (dt *DataTable) RemoveColumn(data ...string) *DataTable {
 // whitelisted will be included in the final response. If no columns are passed,
 // this function does nothing.
 func (dt *DataTable) WhitelistColumn(columns ...string) *DataTable {
+	dt.plans = nil
 	for _, col := range columns {
 		dt.whitelistColumns[col] = true
 	}
 	return dt
 }
 
+// ColumnsFromModel declares a searchable and orderable column for each
+// database field of the model, keyed by its column name. The model must be
+// a struct set with Model; otherwise the error is returned by Validate.
+func (dt *DataTable) ColumnsFromModel() *DataTable {
+	s := dt.modelSchema()
+	if s == nil {
+		dt.addError(errors.New(dt.translate(MsgModelNotStruct, "columns from model: the model is not a struct")))
+		return dt
+	}
+	for _, field := range s.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		dt.AddColumn(Column{Name: field.DBName, Data: field.DBName, Searchable: true, Orderable: true})
+	}
+	return dt
+}
+
+// warnUndeclaredColumns logs a warning listing the request columns that were
+// not declared, which strict mode ignores. It does nothing unless strict mode
+// is enabled and a logger is set.
+func (dt *DataTable) warnUndeclaredColumns() {
+	if !dt.config.Strict || dt.logger == nil {
+		return
+	}
+	var undeclared []string
+	for _, col := range dt.req.Columns {
+		if !dt.declaredColumns[col.Data] {
+			undeclared = append(undeclared, col.Data)
+		}
+	}
+	if len(undeclared) == 0 {
+		return
+	}
+	dt.logger.LogAttrs(dt.context(), slog.LevelWarn, "datatables: undeclared request columns ignored",
+		slog.String("table", dt.tableName()),
+		slog.Any("columns", undeclared),
+	)
+}
+
 // BlacklistColumn marks one or more columns as blacklisted. Columns that are
-// blacklisted will be excluded from the final response. If no columns are passed,
-// this function does nothing.
+// blacklisted can't be searched or ordered on, are removed from the rows of
+// the response, and are not selected when Config.ProjectColumns is enabled.
+// If no columns are passed, this function does nothing.
 func (dt *DataTable) BlacklistColumn(columns ...string) *DataTable {
+	dt.plans = nil
 	for _, col := range columns {
 		dt.blacklistColumns[col] = true
 	}
@@ -168,10 +302,17 @@ func (dt *DataTable) BlacklistColumn(columns ...string) *DataTable {
 func (dt *DataTable) FinalizeResponseColumns(data []map[string]any) []map[string]any {
 	for _, row := range data {
 		for keyCol := range row {
-			if !slices.Contains(dt.selectedColumns, keyCol) {
+			if !slices.Contains(dt.selectedColumns, keyCol) && !dt.isSelectedRawValue(keyCol) {
 				delete(row, keyCol)
 			}
 		}
 	}
 	return data
 }
+
+// isSelectedRawValue returns true if the key holds the raw value, kept when
+// Config.IncludeRawValues is set, of a selected column.
+func (dt *DataTable) isSelectedRawValue(key string) bool {
+	data, ok := strings.CutSuffix(key, rawValueSuffix)
+	return ok && dt.config.IncludeRawValues && slices.Contains(dt.selectedColumns, data)
+}
@@ -1,8 +1,13 @@
 package datatables
 
 import (
+	"bytes"
+	"log/slog"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
 )
 
 func TestInitColumnsMap(t *testing.T) {
@@ -289,3 +294,173 @@ func TestFinalizeResponseColumns(t *testing.T) {
 		})
 	}
 }
+
+func TestColumnExpression(t *testing.T) {
+	tests := []struct {
+		name     string
+		col      Column
+		expected string
+	}{
+		{name: "name", col: Column{Name: "name", Data: "name"}, expected: "name"},
+		{name: "sql", col: Column{Name: "Customer", Data: "customer", SQL: "customers.name"}, expected: "customers.name"},
+		{name: "sql_with_alias", col: Column{Data: "customer", SQL: "customers.name AS customer"}, expected: "customers.name"},
+		{name: "expression_with_alias", col: Column{Data: "label", SQL: "CONCAT(first, ' ', last) as `label`"}, expected: "CONCAT(first, ' ', last)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := tt.col.expression(); actual != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, actual)
+			}
+		})
+	}
+}
+
+type Invoice struct {
+	ID         int
+	CustomerID int
+}
+
+func TestColumnSQL(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `invoices`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(2)))
+	mock.ExpectQuery(qm("SELECT count(*) FROM `invoices` WHERE customers.name LIKE ?")).
+		WithArgs("%John%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+	mock.ExpectQuery(qm("SELECT customers.name AS `customer` FROM `invoices` WHERE customers.name LIKE ? ORDER BY customers.name DESC LIMIT ?")).
+		WithArgs("%John%", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"customer"}).AddRow("John"))
+
+	cfg := defaultConfig()
+	cfg.ProjectColumns = true
+	dt := New(db, WithConfig(cfg))
+	dt.Model(&Invoice{})
+	dt.AddColumn(Column{Data: "customer", SQL: "customers.name AS customer", Searchable: true, Orderable: true})
+	dt.Req(Request{
+		Draw:    1,
+		Length:  10,
+		Search:  Search{Value: "John"},
+		Order:   []Order{{Column: 0, Dir: "desc"}},
+		Columns: []ColumnRequest{{Data: "customer", Searchable: true, Orderable: true}},
+	})
+
+	if _, err := dt.Make(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestBlacklistColumnOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		project bool
+		selects string
+	}{
+		{"select_all", false, "*"},
+		{"projection", true, "`id`, `name`"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+
+			mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+			mock.ExpectQuery(qm("SELECT " + tt.selects + " FROM `users` LIMIT ?")).
+				WithArgs(10).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}).AddRow(1, "John", 25))
+
+			cfg := defaultConfig()
+			cfg.ProjectColumns = tt.project
+			dt := New(db, WithConfig(cfg)).Model(&User{}).
+				AddColumns(
+					Column{Name: "id", Data: "id"},
+					Column{Name: "name", Data: "name"},
+					Column{Name: "age", Data: "age"},
+				).
+				BlacklistColumn("age").
+				Req(Request{Draw: 1, Length: 10})
+
+			res, err := dt.Make()
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			expected := []map[string]any{{"id": 1, "name": "John"}}
+			if !reflect.DeepEqual(res["data"], expected) {
+				t.Errorf("expected %v, got %v", expected, res["data"])
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestStrictMode(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(2)))
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE `name` LIKE ?")).
+		WithArgs("%John%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+	mock.ExpectQuery(qm("SELECT * FROM `users` WHERE `name` LIKE ? LIMIT ?")).
+		WithArgs("%John%", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John"))
+
+	var buf bytes.Buffer
+	cfg := defaultConfig()
+	cfg.Strict = true
+	dt := New(db, WithConfig(cfg), WithLogger(slog.New(slog.NewTextHandler(&buf, nil)))).
+		Model(&User{}).
+		AddColumn(Column{Name: "name", Data: "name", Searchable: true, Orderable: true}).
+		Req(Request{
+			Draw:   1,
+			Length: 10,
+			Search: Search{Value: "John"},
+			Order:  []Order{{Column: 1, Dir: "desc"}},
+			Columns: []ColumnRequest{
+				{Data: "name", Searchable: true, Orderable: true},
+				{Data: "password", Searchable: true, Orderable: true},
+			},
+		})
+
+	if _, err := dt.Make(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, ok := dt.columnsMap["password"]; ok {
+		t.Error("expected undeclared column not to be added")
+	}
+	if !strings.Contains(buf.String(), "undeclared request columns ignored") || !strings.Contains(buf.String(), "password") {
+		t.Errorf("expected a warning about the password column, got %q", buf.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestColumnsFromModel(t *testing.T) {
+	db, _ := newMockDB(t)
+	dt := New(db).Model(&User{}).ColumnsFromModel()
+
+	var names []string
+	for _, col := range dt.columns {
+		if !col.Searchable || !col.Orderable || col.Name != col.Data {
+			t.Errorf("unexpected column %+v", col)
+		}
+		names = append(names, col.Data)
+	}
+	if expected := []string{"id", "name"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected columns %v, got %v", expected, names)
+	}
+
+	t.Run("string_model", func(t *testing.T) {
+		dt := New(db).Model("users").ColumnsFromModel()
+		if err := dt.Validate(); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
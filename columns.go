@@ -0,0 +1,42 @@
+package datatables
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// ColumnsJSON returns the defined columns in the DataTables client format,
+// as a JSON array of objects with the data, name, title, orderable,
+// searchable, and visible options, so that single-page applications can
+// fetch the column configuration from the server instead of hardcoding it.
+//
+// The title is the column label returned by Label. Columns left out of the
+// response with Only, Except, or the access lists are marked not visible,
+// and columns denied by the column policy are not listed at all.
+func (dt *DataTable) ColumnsJSON() ([]byte, error) {
+	return json.Marshal(dt.clientColumns())
+}
+
+// clientColumns returns the defined columns in the DataTables client
+// format.
+func (dt *DataTable) clientColumns() []map[string]any {
+	ctx := dt.context()
+	columns := make([]map[string]any, 0, len(dt.columns))
+	for _, col := range dt.columns {
+		col = dt.columnsMap[col.Data]
+		if dt.columnPolicy != nil && !dt.columnPolicy(ctx, col) {
+			continue
+		}
+		visible := dt.isColumnAllowed(col.Data) &&
+			(len(dt.selectedColumns) == 0 || slices.Contains(dt.selectedColumns, col.Data))
+		columns = append(columns, map[string]any{
+			"data":       col.Data,
+			"name":       col.Name,
+			"title":      dt.Label(col.Data),
+			"orderable":  col.Orderable,
+			"searchable": col.Searchable,
+			"visible":    visible,
+		})
+	}
+	return columns
+}
@@ -0,0 +1,57 @@
+package datatables
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestColumnsJSON(t *testing.T) {
+	dt := New(nil).AddColumns(
+		Column{Name: "id", Data: "id", Orderable: true},
+		Column{Name: "name", Data: "name", Searchable: true, Orderable: true},
+		Column{Name: "email", Data: "email", Searchable: true},
+		Column{Name: "token", Data: "token"},
+		Column{Name: "salary", Data: "salary"},
+	)
+	dt.Except("email")
+	dt.ColumnPolicy(func(ctx context.Context, col Column) bool {
+		return col.Data != "salary"
+	})
+
+	b, err := dt.ColumnsJSON()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var got, expected []map[string]any
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("failed to decode columns: %v", err)
+	}
+	_ = json.Unmarshal([]byte(`[
+		{"data": "id", "name": "id", "title": "id", "orderable": true, "searchable": false, "visible": true},
+		{"data": "name", "name": "name", "title": "name", "orderable": true, "searchable": true, "visible": true},
+		{"data": "email", "name": "email", "title": "email", "orderable": false, "searchable": true, "visible": false},
+		{"data": "token", "name": "token", "title": "token", "orderable": false, "searchable": false, "visible": true}
+	]`), &expected)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	dt.Only("id")
+	_ = json.Unmarshal(mustColumnsJSON(t, dt), &got)
+	if got[1]["visible"] != false || got[0]["visible"] != true {
+		t.Errorf("expected only the id column to be visible, got %v", got)
+	}
+}
+
+func mustColumnsJSON(t *testing.T, dt *DataTable) []byte {
+	t.Helper()
+
+	b, err := dt.ColumnsJSON()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return b
+}
@@ -0,0 +1,62 @@
+package datatables
+
+import "log/slog"
+
+// AddColumnFunc adds a computed column whose value is produced by the given
+// function instead of being read from the database.
+//
+// The column is never searched, ordered on, or selected, since there is no
+// SQL column backing it. Use Column.SQL instead when the value can be
+// computed by the database. Returns the updated DataTable instance.
+func (dt *DataTable) AddColumnFunc(data string, fn func(map[string]any) any) *DataTable {
+	if dt.computedColumns == nil {
+		dt.computedColumns = make(map[string]bool)
+	}
+	dt.computedColumns[data] = true
+	return dt.AddColumn(Column{Name: data, Data: data, RenderFunc: fn})
+}
+
+// detectComputedColumns marks the columns without an SQL backing as computed
+// and disables searching and ordering on them, so that no invalid SQL
+// referencing a non-existent column is generated.
+//
+// Besides the columns added with AddColumnFunc, a column with a RenderFunc
+// and no SQL field is considered computed when the model is a struct that has
+// no field matching its Name or Data. A warning is logged when a computed
+// column was marked searchable or orderable.
+func (dt *DataTable) detectComputedColumns() {
+	fields := dt.modelFields()
+	for data, col := range dt.columnsMap {
+		if !dt.computedColumns[data] {
+			if col.RenderFunc == nil || col.SQL != "" || col.Relation != "" || fields == nil || fields[col.Name] || fields[data] {
+				continue
+			}
+			if dt.computedColumns == nil {
+				dt.computedColumns = make(map[string]bool)
+			}
+			dt.computedColumns[data] = true
+		}
+		if !col.Searchable && !col.Orderable {
+			continue
+		}
+		if dt.logger != nil {
+			dt.logger.LogAttrs(dt.context(), slog.LevelWarn, "datatables: computed column is not searchable or orderable",
+				slog.String("table", dt.tableName()),
+				slog.String("column", data),
+			)
+		}
+		col.Searchable = false
+		col.Orderable = false
+		dt.columnsMap[data] = col
+	}
+}
+
+// modelFields returns the set of field names and database column names of
+// the DataTable's model, or nil if the model is not a struct that can be
+// parsed. The set is shared and must not be modified.
+func (dt *DataTable) modelFields() map[string]bool {
+	if info := dt.modelInfo(); info != nil {
+		return info.fields
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package datatables
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestAddColumnFunc(t *testing.T) {
+	dt := New(nil)
+	dt.AddColumnFunc("full_name", func(row map[string]any) any { return row["name"] })
+
+	col, ok := dt.columnsMap["full_name"]
+	if !ok || col.RenderFunc == nil {
+		t.Fatalf("expected computed column to be added with a render function")
+	}
+	if !dt.computedColumns["full_name"] {
+		t.Errorf("expected column to be marked as computed")
+	}
+}
+
+func TestDetectComputedColumns(t *testing.T) {
+	db, _ := newMockDB(t)
+	var buf bytes.Buffer
+
+	render := func(row map[string]any) any { return row["name"] }
+	dt := New(db).Model(&User{})
+	dt.SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+	dt.AddColumns(
+		Column{Name: "name", Data: "name", Searchable: true, Orderable: true, RenderFunc: render},
+		Column{Name: "badge", Data: "badge", Searchable: true, Orderable: true, RenderFunc: render},
+		Column{Name: "label", Data: "label", Searchable: true, SQL: "UPPER(name)", RenderFunc: render},
+	)
+	dt.detectComputedColumns()
+
+	if col := dt.columnsMap["name"]; !col.Searchable || !col.Orderable {
+		t.Errorf("expected model column with render function to stay searchable and orderable")
+	}
+	if col := dt.columnsMap["badge"]; col.Searchable || col.Orderable {
+		t.Errorf("expected computed column not to be searchable or orderable")
+	}
+	if col := dt.columnsMap["label"]; !col.Searchable {
+		t.Errorf("expected column backed by SQL to stay searchable")
+	}
+	if !strings.Contains(buf.String(), "column=badge") {
+		t.Errorf("expected a warning for the computed column, got %q", buf.String())
+	}
+}
+
+func TestDetectComputedColumnsLogContext(t *testing.T) {
+	db, _ := newMockDB(t)
+	handler := &contextHandler{Handler: slog.NewTextHandler(&bytes.Buffer{}, nil)}
+	ctx := context.WithValue(context.Background(), contextKey{}, "request")
+
+	dt := New(db, WithContext(ctx)).Model(&User{}).SetLogger(slog.New(handler))
+	dt.AddColumn(Column{Name: "badge", Data: "badge", Searchable: true, RenderFunc: func(row map[string]any) any { return row["name"] }})
+	dt.detectComputedColumns()
+
+	if len(handler.values) != 1 || handler.values[0] != "request" {
+		t.Errorf("expected the warning to be logged with the context of the DataTable, got %v", handler.values)
+	}
+}
+
+func TestComputedColumnsSkippedInSQL(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE `name` LIKE ?")).
+		WithArgs("%John%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+	mock.ExpectQuery(qm("SELECT `name` FROM `users` WHERE `name` LIKE ? LIMIT ?")).
+		WithArgs("%John%", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("John"))
+
+	cfg := defaultConfig()
+	cfg.ProjectColumns = true
+	dt := New(db, WithConfig(cfg)).Model(&User{})
+	dt.AddColumn(Column{Name: "name", Data: "name"})
+	dt.AddColumnFunc("greeting", func(row map[string]any) any { return "Hello " + row["name"].(string) })
+	dt.Req(Request{
+		Draw:   1,
+		Length: 10,
+		Search: Search{Value: "John"},
+		Order:  []Order{{Column: 1, Dir: "asc"}},
+		Columns: []ColumnRequest{
+			{Data: "name", Name: "name", Searchable: true, Orderable: true},
+			{Data: "greeting", Searchable: true, Orderable: true},
+		},
+	})
+
+	res, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	rows := res["data"].([]map[string]any)
+	if rows[0]["greeting"] != "Hello John" {
+		t.Errorf("expected computed value, got %v", rows[0]["greeting"])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
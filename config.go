@@ -1,5 +1,7 @@
 package datatables
 
+import "errors"
+
 // Config holds the configuration options for a DataTable.
 //
 // The Config struct allows customization of various features
@@ -11,20 +13,126 @@ package datatables
 //   - Paginate: Enables or disables pagination.
 //   - Union: Allows the use of UNION in queries.
 //   - Distinct: Enables DISTINCT selection in queries.
+//   - DistinctColumns: Counts the distinct combinations of these columns
+//     instead of the records, for listings that are a DISTINCT over several
+//     columns.
 //   - CaseInsensitive: Enables case-insensitive searches.
-//   - ResponseFormat: Specifies the format of the response.
+//   - LowerColumns: Makes case-insensitive searches compare LOWER(column)
+//     with LOWER(value), so that they also match on case-sensitive
+//     collations, at the cost of the indexes on the searched columns.
+//   - SearchCollation: Forces the collation of the search comparisons, such
+//     as utf8mb4_general_ci on MySQL, regardless of the column collation.
+//   - ResponseFormat: Specifies the format of the rows, ResponseFormatObject
+//     (default) or ResponseFormatArray.
+//   - PreserveColumnOrder: Encodes the keys of the object rows in the order
+//     of the request columns, as reordered on screen, instead of
+//     alphabetically. The rows are then OrderedRow values.
 //   - GroupBy: Specifies columns for GROUP BY clause.
 //   - Having: Specifies conditions for HAVING clause.
 //   - DefaultSort: Specifies default sorting for columns.
+//   - ResponseSchema: Specifies the keys of the response envelope.
+//   - AllowedLengths: Restricts the page lengths clients may request.
+//   - RejectInvalidLength: Rejects disallowed page lengths instead of
+//     clamping them to the nearest allowed value.
+//   - ProjectColumns: Selects only the defined columns in the data query
+//     instead of SELECT *.
+//   - CursorPagination: Adds an opaque nextCursor to the responses and
+//     accepts it back in the cursor request parameter, for infinite scroll
+//     clients.
+//   - SkipHiddenHeavy: Leaves the heavy columns, see HeavyColumns, that are
+//     hidden on the client out of the SELECT of the data query. They are
+//     fetched again once the client shows them.
+//   - QueryComment: A comment written before the generated queries, such as
+//     the name of the table endpoint.
+//   - QueryHints: Optimizer hints added to the generated queries, such as
+//     MAX_EXECUTION_TIME(1000) on MySQL or pg_hint_plan hints on Postgres.
+//   - StableOrder: Appends the primary key of the model to the ordering, so
+//     that rows with equal values in the ordered columns keep the same order
+//     from one page to the next.
+//   - Strict: Allows only the columns declared with AddColumn, AddColumns,
+//     AddColumnFunc, or ColumnsFromModel to be searched and ordered on. The
+//     other columns of the request are ignored with a logged warning.
+//   - UnknownColumns: How the request columns that are neither declared nor
+//     fields of the model are handled: UnknownColumnsIgnore (default),
+//     UnknownColumnsWarn, or UnknownColumnsFail.
+//   - InMemoryLimit: The maximum number of rows fetched to search the
+//     computed columns made searchable with SearchComputed. Zero means
+//     10000.
+//   - RelationsFormat: Includes the relations preloaded with With in the
+//     rows, RelationsNested or RelationsFlat. Empty leaves them out.
+//   - IncludeRawValues: Adds the value of each column with a RenderFunc or
+//     Renders, as fetched before rendering, to the rows under the key of the
+//     column suffixed with _raw, so that the client can sort on it while
+//     displaying the rendered value. The columns obfuscated with
+//     ObfuscateIDs or masked with MaskColumn are left out, so that their
+//     raw values are never leaked.
+//   - SkipQueryInspection: Leaves the query of the DataTable uninspected,
+//     so that UNION, DISTINCT, GROUP BY, and HAVING are only handled as set
+//     with Union, Distinct, GroupBy, and Having.
 type Config struct {
-	Searchable      bool
-	Orderable       bool
-	Paginate        bool
-	Union           bool
-	Distinct        bool
-	CaseInsensitive bool
-	ResponseFormat  string
-	GroupBy         []string
-	Having          []string
-	DefaultSort     map[string]string
+	Searchable          bool              `json:"searchable" yaml:"searchable"`
+	Orderable           bool              `json:"orderable" yaml:"orderable"`
+	Paginate            bool              `json:"paginate" yaml:"paginate"`
+	Union               bool              `json:"union" yaml:"union"`
+	Distinct            bool              `json:"distinct" yaml:"distinct"`
+	DistinctColumns     []string          `json:"distinctColumns" yaml:"distinctColumns"`
+	CaseInsensitive     bool              `json:"caseInsensitive" yaml:"caseInsensitive"`
+	LowerColumns        bool              `json:"lowerColumns" yaml:"lowerColumns"`
+	SearchCollation     string            `json:"searchCollation" yaml:"searchCollation"`
+	ResponseFormat      string            `json:"responseFormat" yaml:"responseFormat"`
+	GroupBy             []string          `json:"groupBy" yaml:"groupBy"`
+	Having              []string          `json:"having" yaml:"having"`
+	DefaultSort         map[string]string `json:"defaultSort" yaml:"defaultSort"`
+	ResponseSchema      ResponseSchema    `json:"responseSchema" yaml:"responseSchema"`
+	AllowedLengths      []int             `json:"allowedLengths" yaml:"allowedLengths"`
+	RejectInvalidLength bool              `json:"rejectInvalidLength" yaml:"rejectInvalidLength"`
+	ProjectColumns      bool              `json:"projectColumns" yaml:"projectColumns"`
+	RelationsFormat     string            `json:"relationsFormat" yaml:"relationsFormat"`
+	CursorPagination    bool              `json:"cursorPagination" yaml:"cursorPagination"`
+	QueryComment        string            `json:"queryComment" yaml:"queryComment"`
+	QueryHints          []string          `json:"queryHints" yaml:"queryHints"`
+	StableOrder         bool              `json:"stableOrder" yaml:"stableOrder"`
+	InMemoryLimit       int               `json:"inMemoryLimit" yaml:"inMemoryLimit"`
+	Strict              bool              `json:"strict" yaml:"strict"`
+	UnknownColumns      string            `json:"unknownColumns" yaml:"unknownColumns"`
+	PreserveColumnOrder bool              `json:"preserveColumnOrder" yaml:"preserveColumnOrder"`
+	SkipHiddenHeavy     bool              `json:"skipHiddenHeavy" yaml:"skipHiddenHeavy"`
+	SkipQueryInspection bool              `json:"skipQueryInspection" yaml:"skipQueryInspection"`
+	IncludeRawValues    bool              `json:"includeRawValues" yaml:"includeRawValues"`
+}
+
+// defaultConfig returns the configuration used by New: searching, ordering,
+// and pagination are enabled.
+func defaultConfig() Config {
+	return Config{
+		Searchable: true,
+		Orderable:  true,
+		Paginate:   true,
+	}
+}
+
+// validate checks the values of the config that are written into the SQL as
+// is: the search collation, the query comment, and the optimizer hints.
+func (c Config) validate() error {
+	return errors.Join(validateCollation(c), validateHints(c))
+}
+
+// ResponseSchema customizes the envelope of the response returned by Make,
+// so the DataTable can serve grid clients that expect different keys.
+//
+// Each key field renames the corresponding top-level key of the response.
+// An empty value keeps the DataTables default, and "-" removes the key.
+//
+// Fields:
+//   - Wrap: When set, the whole response is nested under this key.
+//   - Draw: The key of the draw counter (default "draw").
+//   - RecordsTotal: The key of the total records count (default "recordsTotal").
+//   - RecordsFiltered: The key of the filtered records count (default "recordsFiltered").
+//   - Data: The key of the rows (default "data").
+type ResponseSchema struct {
+	Wrap            string `json:"wrap" yaml:"wrap"`
+	Draw            string `json:"draw" yaml:"draw"`
+	RecordsTotal    string `json:"recordsTotal" yaml:"recordsTotal"`
+	RecordsFiltered string `json:"recordsFiltered" yaml:"recordsFiltered"`
+	Data            string `json:"data" yaml:"data"`
 }
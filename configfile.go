@@ -0,0 +1,135 @@
+package datatables
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Supported configuration file formats.
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// ConfigFile is the file representation of a DataTable configuration, so
+// table behavior can be tuned per environment without recompiling.
+//
+// Fields:
+//   - Config: The DataTable configuration. Omitted options keep the
+//     defaults used by New.
+//   - Profiles: Named column sets, keyed by profile name.
+type ConfigFile struct {
+	Config   Config              `json:"config" yaml:"config"`
+	Profiles map[string][]Column `json:"profiles" yaml:"profiles"`
+}
+
+// LoadConfig reads and validates the configuration file at the given path.
+// The format is chosen from the file extension (.json, .yaml, or .yml).
+func LoadConfig(path string) (*ConfigFile, error) {
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	if format == "yml" {
+		format = FormatYAML
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfig(data, format)
+}
+
+// ParseConfig decodes and validates a configuration in the given format
+// (FormatJSON or FormatYAML). Unknown fields are rejected so typos do not go
+// unnoticed.
+func ParseConfig(data []byte, format string) (*ConfigFile, error) {
+	file := &ConfigFile{Config: defaultConfig()}
+
+	switch format {
+	case FormatJSON:
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(file); err != nil {
+			return nil, fmt.Errorf("invalid json config: %w", err)
+		}
+	case FormatYAML:
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(file); err != nil {
+			return nil, fmt.Errorf("invalid yaml config: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config format %q", format)
+	}
+
+	if err := file.Validate(); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// Validate checks the configuration file. The default sort directions must
+// be asc or desc, the response format, unknown columns policy, and
+// relations format must be empty or one of their constants, the allowed
+// lengths must be positive or -1 (all rows), the search collation, query
+// comment, and optimizer hints must be safe to write into the SQL, and
+// every profile column must have a unique Data field.
+func (f *ConfigFile) Validate() error {
+	var errs []error
+
+	for _, column := range slices.Sorted(maps.Keys(f.Config.DefaultSort)) {
+		dir := f.Config.DefaultSort[column]
+		if d := strings.ToUpper(dir); d != orderAscending && d != orderDescending {
+			errs = append(errs, fmt.Errorf("invalid sort direction %q for column %q", dir, column))
+		}
+	}
+
+	errs = append(errs,
+		validateOption("responseFormat", f.Config.ResponseFormat, ResponseFormatObject, ResponseFormatArray),
+		validateOption("unknownColumns", f.Config.UnknownColumns, UnknownColumnsIgnore, UnknownColumnsWarn, UnknownColumnsFail),
+		validateOption("relationsFormat", f.Config.RelationsFormat, RelationsNested, RelationsFlat),
+	)
+	for i, length := range f.Config.AllowedLengths {
+		if length <= 0 && length != -1 {
+			errs = append(errs, fmt.Errorf("allowedLengths[%d]: invalid length %d, must be positive or -1", i, length))
+		}
+	}
+
+	if err := f.Config.validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(f.Profiles)) {
+		columns := f.Profiles[name]
+		seen := make(map[string]bool)
+		for i, col := range columns {
+			if col.Data == "" {
+				errs = append(errs, fmt.Errorf("profile %q: column %d has no data", name, i))
+				continue
+			}
+			if seen[col.Data] {
+				errs = append(errs, fmt.Errorf("profile %q: duplicate column %q", name, col.Data))
+			}
+			seen[col.Data] = true
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateOption returns an error qualified with the given field name if the
+// value is neither empty nor one of the allowed values.
+func validateOption(field, value string, allowed ...string) error {
+	if value == "" || slices.Contains(allowed, value) {
+		return nil
+	}
+	return fmt.Errorf("%s: invalid value %q, must be one of %s", field, value, strings.Join(allowed, ", "))
+}
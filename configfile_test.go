@@ -0,0 +1,132 @@
+package datatables
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	expected := &ConfigFile{
+		Config: Config{
+			Searchable:      true,
+			Orderable:       false,
+			Paginate:        true,
+			CaseInsensitive: true,
+			DefaultSort:     map[string]string{"name": "asc"},
+			ResponseSchema:  ResponseSchema{Data: "rows"},
+		},
+		Profiles: map[string][]Column{
+			"user-public": {
+				{Name: "id", Data: "id", Orderable: true},
+				{Name: "name", Data: "name", Searchable: true, Orderable: true},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		data   string
+	}{
+		{
+			name:   "json",
+			format: FormatJSON,
+			data: `{
+				"config": {
+					"orderable": false,
+					"caseInsensitive": true,
+					"defaultSort": {"name": "asc"},
+					"responseSchema": {"data": "rows"}
+				},
+				"profiles": {
+					"user-public": [
+						{"name": "id", "data": "id", "orderable": true},
+						{"name": "name", "data": "name", "searchable": true, "orderable": true}
+					]
+				}
+			}`,
+		},
+		{
+			name:   "yaml",
+			format: FormatYAML,
+			data: `
+config:
+  orderable: false
+  caseInsensitive: true
+  defaultSort:
+    name: asc
+  responseSchema:
+    data: rows
+profiles:
+  user-public:
+    - {name: id, data: id, orderable: true}
+    - {name: name, data: name, searchable: true, orderable: true}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := ParseConfig([]byte(tt.data), tt.format)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(file, expected) {
+				t.Errorf("expected %+v, got %+v", expected, file)
+			}
+		})
+	}
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		data   string
+		errMsg string
+	}{
+		{name: "unsupported_format", format: "toml", data: "", errMsg: "unsupported config format"},
+		{name: "unknown_field", format: FormatJSON, data: `{"config": {"pagination": true}}`, errMsg: "unknown field"},
+		{name: "unknown_yaml_field", format: FormatYAML, data: "config:\n  pagination: true\n", errMsg: "not found"},
+		{name: "invalid_sort", format: FormatJSON, data: `{"config": {"defaultSort": {"name": "up"}}}`, errMsg: `invalid sort direction "up"`},
+		{name: "invalid_response_format", format: FormatJSON, data: `{"config": {"responseFormat": "csv"}}`, errMsg: `responseFormat: invalid value "csv"`},
+		{name: "invalid_unknown_columns", format: FormatYAML, data: "config:\n  unknownColumns: reject\n", errMsg: `unknownColumns: invalid value "reject"`},
+		{name: "invalid_relations_format", format: FormatJSON, data: `{"config": {"relationsFormat": "tree"}}`, errMsg: `relationsFormat: invalid value "tree"`},
+		{name: "invalid_allowed_length", format: FormatJSON, data: `{"config": {"allowedLengths": [10, 0]}}`, errMsg: "allowedLengths[1]: invalid length 0"},
+		{name: "negative_allowed_length", format: FormatYAML, data: "config:\n  allowedLengths: [-5]\n", errMsg: "allowedLengths[0]: invalid length -5"},
+		{name: "missing_data", format: FormatYAML, data: "profiles:\n  p:\n    - {name: id}\n", errMsg: "column 0 has no data"},
+		{name: "duplicate_column", format: FormatYAML, data: "profiles:\n  p:\n    - {data: id}\n    - {data: id}\n", errMsg: `duplicate column "id"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseConfig([]byte(tt.data), tt.format)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "users.yml")
+	if err := os.WriteFile(path, []byte("config:\n  paginate: false\n"), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	file, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if file.Config.Paginate || !file.Config.Searchable {
+		t.Errorf("expected paginate to be disabled and defaults kept, got %+v", file.Config)
+	}
+
+	if _, err := LoadConfig(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected error for missing file, got nil")
+	}
+}
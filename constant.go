@@ -13,6 +13,7 @@ const (
 	queryDistinct = "DISTINCT"          // SQL DISTINCT keyword.
 	queryGroupBy  = "GROUP BY"          // SQL GROUP BY clause.
 	queryHaving   = "HAVING"            // SQL HAVING clause.
+	queryOrderBy  = "ORDER BY"          // SQL ORDER BY clause.
 	queryCount    = "COUNT(*) AS count" // SQL COUNT function with alias.
 )
 
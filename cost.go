@@ -0,0 +1,84 @@
+package datatables
+
+import (
+	"context"
+	"time"
+)
+
+// CostThresholds sets the limits above which a draw is reported to the cost
+// warning callback registered with OnCostWarning. A zero limit is not
+// checked.
+//
+// Fields:
+//   - Duration: The maximum time a draw may take.
+//   - ScannedRows: The maximum estimated number of rows a draw may scan.
+type CostThresholds struct {
+	Duration    time.Duration
+	ScannedRows int64
+}
+
+// CostReport describes a draw that exceeded the cost thresholds.
+//
+// Fields:
+//   - Table: The table that was queried.
+//   - Duration: How long the draw took.
+//   - ScannedRows: The estimated number of rows scanned: the total records,
+//     which the count queries visit, plus the rows skipped and returned by
+//     the data query.
+//   - Rows: The number of rows returned.
+//   - RecordsTotal: The total number of records.
+//   - RecordsFiltered: The number of records after filtering.
+//   - Error: The error of the draw, if any.
+type CostReport struct {
+	Table           string
+	Duration        time.Duration
+	ScannedRows     int64
+	Rows            int
+	RecordsTotal    int64
+	RecordsFiltered int64
+	Error           error
+}
+
+// costWarning holds the thresholds and the callback set with OnCostWarning.
+type costWarning struct {
+	thresholds CostThresholds
+	fn         func(ctx context.Context, report CostReport)
+}
+
+// OnCostWarning registers a callback invoked when a draw exceeds one of the
+// given thresholds, so that slow tables can be alerted on without wiring an
+// APM into every handler. The callback is called with the context of the
+// DataTable after the draw, whether it succeeded or not. Setting it again
+// replaces the previous callback.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) OnCostWarning(thresholds CostThresholds, fn func(ctx context.Context, report CostReport)) *DataTable {
+	dt.costWarning = &costWarning{thresholds: thresholds, fn: fn}
+	return dt
+}
+
+// checkCost reports the draw started at the given time to the cost warning
+// callback if it exceeded a threshold.
+func (dt *DataTable) checkCost(start time.Time, res *result, err error) {
+	if dt.costWarning == nil {
+		return
+	}
+
+	report := CostReport{
+		Table:    dt.tableName(),
+		Duration: time.Since(start),
+		Error:    err,
+	}
+	if res != nil {
+		report.Rows = res.rows
+		report.RecordsTotal = res.total
+		report.RecordsFiltered = res.filtered
+		report.ScannedRows = res.total + int64(max(dt.req.Start, 0)) + int64(res.rows)
+	}
+
+	limits := dt.costWarning.thresholds
+	if (limits.Duration > 0 && report.Duration > limits.Duration) ||
+		(limits.ScannedRows > 0 && report.ScannedRows > limits.ScannedRows) {
+		dt.costWarning.fn(dt.context(), report)
+	}
+}
@@ -0,0 +1,79 @@
+package datatables
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestOnCostWarning(t *testing.T) {
+	tests := []struct {
+		name       string
+		thresholds CostThresholds
+		wantReport bool
+	}{
+		{name: "scanned rows exceeded", thresholds: CostThresholds{ScannedRows: 100}, wantReport: true},
+		{name: "scanned rows within", thresholds: CostThresholds{ScannedRows: 1000}},
+		{name: "duration within", thresholds: CostThresholds{Duration: time.Hour}},
+		{name: "no thresholds"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+
+			mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(500))
+			mock.ExpectQuery(qm("SELECT * FROM `users` LIMIT ? OFFSET ?")).
+				WithArgs(10, 20).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+
+			var reports []CostReport
+			dt := New(db).Model(&User{})
+			dt.OnCostWarning(tt.thresholds, func(ctx context.Context, report CostReport) {
+				reports = append(reports, report)
+			})
+			dt.Req(Request{
+				Draw:    1,
+				Start:   20,
+				Length:  10,
+				Columns: []ColumnRequest{{Name: "name", Data: "name"}},
+			})
+
+			if _, err := dt.Make(); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if !tt.wantReport {
+				if len(reports) != 0 {
+					t.Errorf("expected no report, got %+v", reports)
+				}
+				return
+			}
+			if len(reports) != 1 {
+				t.Fatalf("expected 1 report, got %d", len(reports))
+			}
+			report := reports[0]
+			if report.Table != "users" || report.ScannedRows != 521 || report.Rows != 1 ||
+				report.RecordsTotal != 500 || report.RecordsFiltered != 500 || report.Error != nil {
+				t.Errorf("unexpected report %+v", report)
+			}
+		})
+	}
+}
+
+func TestOnCostWarningError(t *testing.T) {
+	var reports []CostReport
+	dt := New(nil).OnCostWarning(CostThresholds{Duration: time.Nanosecond}, func(ctx context.Context, report CostReport) {
+		reports = append(reports, report)
+	})
+
+	if _, err := dt.Make(); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(reports) != 1 || reports[0].Error == nil {
+		t.Errorf("expected a report with the error, got %+v", reports)
+	}
+}
@@ -0,0 +1,76 @@
+package datatables
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Counter counts the records of a grouped query, that is the number of
+// groups it returns. It is used for the total and filtered counts when the
+// query has a GROUP BY clause.
+type Counter interface {
+	Count(query *gorm.DB) (int64, error)
+}
+
+// CounterFunc is an adapter that allows the use of an ordinary function as a
+// Counter.
+type CounterFunc func(query *gorm.DB) (int64, error)
+
+// Count calls f(query).
+func (f CounterFunc) Count(query *gorm.DB) (int64, error) {
+	return f(query)
+}
+
+// SubqueryCounter counts the groups by wrapping the whole query in a
+// subquery. It is exact for any query, including ones with HAVING
+// conditions, and is the strategy used for filtered counts by default.
+var SubqueryCounter Counter = CounterFunc(countSubquery)
+
+// GroupKeyCounter counts the groups of a subquery selecting only a constant
+// per group, which is cheaper than wrapping the whole query, since no column
+// is selected or ordered on. It is exact on every dialect, counting the
+// group of NULL keys like GROUP BY returns it. Queries with HAVING
+// conditions, which may depend on the selected columns, fall back to
+// SubqueryCounter.
+var GroupKeyCounter Counter = CounterFunc(countGroupKeys)
+
+// SetCounter sets the strategy used to count grouped queries, such as
+// GroupKeyCounter. When no counter is set, the total count uses GORM's Count
+// and the filtered count uses SubqueryCounter.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) SetCounter(counter Counter) *DataTable {
+	dt.counter = counter
+	return dt
+}
+
+// countSubquery counts the rows returned by the query by wrapping it in a
+// subquery.
+func countSubquery(query *gorm.DB) (int64, error) {
+	var count int64
+	subQuery := query.Session(&gorm.Session{})
+	err := query.Session(&gorm.Session{NewDB: true}).
+		Table("(?) subquery", subQuery).
+		Select(queryCount).
+		Scan(&count).Error
+	return count, err
+}
+
+// countGroupKeys counts the groups of the GROUP BY columns of the query with
+// a subquery selecting 1 per group, falling back to countSubquery when the
+// query is not grouped by columns or has HAVING conditions.
+func countGroupKeys(query *gorm.DB) (int64, error) {
+	c, ok := query.Statement.Clauses[queryGroupBy]
+	if !ok {
+		return countSubquery(query)
+	}
+	groupBy, ok := c.Expression.(clause.GroupBy)
+	if !ok || len(groupBy.Columns) == 0 || len(groupBy.Having) > 0 {
+		return countSubquery(query)
+	}
+
+	groups := query.Session(&gorm.Session{}).Clauses(clause.Select{Expression: clause.Expr{SQL: "1"}})
+	groups.Statement.Selects = nil
+	delete(groups.Statement.Clauses, queryOrderBy)
+	return countSubquery(groups)
+}
@@ -0,0 +1,99 @@
+package datatables
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+func TestGroupKeyCounter(t *testing.T) {
+	tests := []struct {
+		name  string
+		query func(*gorm.DB) *gorm.DB
+		sql   string
+		args  []driver.Value
+	}{
+		{
+			name:  "group_keys",
+			query: func(db *gorm.DB) *gorm.DB { return db.Model(&User{}).Where("id > ?", 0).Group("name").Group("age") },
+			sql:   "SELECT COUNT(*) AS count FROM (SELECT 1 FROM `users` WHERE id > ? GROUP BY `name`,`age`) subquery",
+			args:  []driver.Value{0},
+		},
+		{
+			// The group of NULL keys is a row of the subquery, so it is
+			// counted like GROUP BY returns it, unlike with COUNT(DISTINCT).
+			name: "null_keys",
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Model(&User{}).Select("name, COUNT(*) AS total").Where("name IS NULL OR age > ?", 18).Group("name").Order("total DESC")
+			},
+			sql:  "SELECT COUNT(*) AS count FROM (SELECT 1 FROM `users` WHERE name IS NULL OR age > ? GROUP BY `name`) subquery",
+			args: []driver.Value{18},
+		},
+		{
+			name:  "having_falls_back_to_subquery",
+			query: func(db *gorm.DB) *gorm.DB { return db.Model(&User{}).Group("name").Having("COUNT(*) > ?", 1) },
+			sql:   "SELECT COUNT(*) AS count FROM (SELECT * FROM `users` GROUP BY `name` HAVING COUNT(*) > ?) subquery",
+			args:  []driver.Value{1},
+		},
+		{
+			name:  "not_grouped",
+			query: func(db *gorm.DB) *gorm.DB { return db.Model(&User{}) },
+			sql:   "SELECT COUNT(*) AS count FROM (SELECT * FROM `users`) subquery",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			mock.ExpectQuery(qm(tt.sql)).
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(7)))
+
+			query := tt.query(db)
+			selects := len(query.Statement.Selects)
+			count, err := GroupKeyCounter.Count(query)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(query.Statement.Selects) != selects {
+				t.Errorf("expected the counted query to be left unchanged")
+			}
+			if count != 7 {
+				t.Errorf("expected count 7, got %d", count)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestSetCounter(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT COUNT(*) AS count FROM (SELECT 1 FROM `users` GROUP BY `name`) subquery")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(9)))
+	mock.ExpectQuery(qm("SELECT COUNT(*) AS count FROM (SELECT 1 FROM `users` WHERE `name` LIKE ? GROUP BY `name`) subquery")).
+		WithArgs("%John%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(2)))
+
+	dt := New(db.Model(&User{}).Group("name")).SetCounter(GroupKeyCounter)
+	dt.Req(Request{
+		Draw:    1,
+		Search:  Search{Value: "John"},
+		Columns: []ColumnRequest{{Name: "name", Data: "name", Searchable: true}},
+	})
+
+	total, filtered, err := dt.Counts()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if total != 9 || filtered != 2 {
+		t.Errorf("expected counts 9 and 2, got %d and %d", total, filtered)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
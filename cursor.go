@@ -0,0 +1,71 @@
+package datatables
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// nextCursorKey is the response key holding the cursor of the next page.
+const nextCursorKey = "nextCursor"
+
+// errInvalidCursor is returned by decodeCursor for a malformed token.
+var errInvalidCursor = errors.New("invalid cursor")
+
+// cursor is the content of the opaque cursor tokens.
+type cursor struct {
+	Offset int `json:"offset"`
+	After  any `json:"after,omitempty"`
+}
+
+// encodeCursor returns the opaque token of the given cursor.
+func encodeCursor(c cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor parses an opaque token returned by encodeCursor.
+func decodeCursor(token string) (cursor, error) {
+	var c cursor
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+		err = dec.Decode(&c)
+	}
+	if err != nil || c.Offset < 0 {
+		return cursor{}, errInvalidCursor
+	}
+	c.After = filterValue(c.After)
+	return c, nil
+}
+
+// applyCursor sets the start of the request from its cursor when cursor
+// pagination is enabled and the request carries one. The cursor of a
+// request paginated with KeysetPaginator is checked as well, before any
+// query is run. Returns an error if the cursor is invalid.
+func (dt *DataTable) applyCursor() error {
+	_, keyset := dt.paginator.(keysetPaginator)
+	if dt.req.Cursor == "" || !dt.config.CursorPagination && !(keyset && dt.config.Paginate) {
+		return nil
+	}
+	c, err := decodeCursor(dt.req.Cursor)
+	if err != nil {
+		return errors.New(dt.translate(MsgInvalidCursor, "invalid cursor"))
+	}
+	if dt.config.CursorPagination {
+		dt.req.Start = c.Offset
+	}
+	return nil
+}
+
+// nextCursor returns the cursor of the page following the current one, or
+// nil if the current page is the last one.
+func (dt *DataTable) nextCursor(filtered int64, rows int) any {
+	next := dt.req.Start + rows
+	if rows == 0 || int64(next) >= filtered {
+		return nil
+	}
+	return encodeCursor(cursor{Offset: next})
+}
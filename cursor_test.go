@@ -0,0 +1,98 @@
+package datatables
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestCursorPagination(t *testing.T) {
+	tests := []struct {
+		name       string
+		cursor     string
+		offset     int
+		nextCursor any
+	}{
+		{name: "first page", offset: 0, nextCursor: encodeCursor(cursor{Offset: 2})},
+		{name: "next page", cursor: encodeCursor(cursor{Offset: 2}), offset: 2, nextCursor: encodeCursor(cursor{Offset: 4})},
+		{name: "last page", cursor: encodeCursor(cursor{Offset: 4}), offset: 4, nextCursor: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+
+			mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(6))
+			query := "SELECT * FROM `users` LIMIT ?"
+			args := []driver.Value{2}
+			if tt.offset > 0 {
+				query += " OFFSET ?"
+				args = append(args, tt.offset)
+			}
+			mock.ExpectQuery(qm(query)).
+				WithArgs(args...).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe").AddRow(2, "Jane Doe"))
+
+			cfg := defaultConfig()
+			cfg.CursorPagination = true
+			dt := New(db, WithConfig(cfg)).Model(&User{})
+			dt.Req(Request{
+				Length:  2,
+				Cursor:  tt.cursor,
+				Columns: []ColumnRequest{{Name: "name", Data: "name"}},
+			})
+
+			response, err := dt.Make()
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if response[nextCursorKey] != tt.nextCursor {
+				t.Errorf("expected nextCursor %v, got %v", tt.nextCursor, response[nextCursorKey])
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestCursorPaginationInvalidCursor(t *testing.T) {
+	db, _ := newMockDB(t)
+
+	cfg := defaultConfig()
+	cfg.CursorPagination = true
+	dt := New(db, WithConfig(cfg)).Model(&User{})
+	dt.Req(Request{Length: 2, Cursor: "not a cursor"})
+
+	if _, err := dt.Make(); err == nil {
+		t.Error("expected an error for an invalid cursor")
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		want    cursor
+		wantErr bool
+	}{
+		{name: "valid", token: encodeCursor(cursor{Offset: 20}), want: cursor{Offset: 20}},
+		{name: "not base64", token: "***", wantErr: true},
+		{name: "not json", token: "bm90IGpzb24", wantErr: true},
+		{name: "negative offset", token: encodeCursor(cursor{Offset: -1}), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeCursor(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeCursor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
@@ -1,28 +1,36 @@
 package datatables
 
 import (
+	"log/slog"
 	"maps"
-	"runtime"
-	"sync"
+	"slices"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 // New returns a new DataTable with the given Gorm DB and default configuration.
-func New(tx *gorm.DB) *DataTable {
+//
+// Options can be passed to configure the DataTable declaratively in one
+// expression instead of a long builder chain:
+//
+//	dt := datatables.New(db, datatables.WithConfig(cfg), datatables.WithColumns(cols...))
+func New(tx *gorm.DB, opts ...Option) *DataTable {
 	dt := &DataTable{
-		tx: tx,
-		config: Config{
-			Searchable: true,
-			Orderable:  true,
-			Paginate:   true,
-		},
+		tx:               tx,
+		config:           defaultConfig(),
 		additionalData:   make(map[string]any),
 		whitelistColumns: make(map[string]bool),
 		blacklistColumns: make(map[string]bool),
+		excludedColumns:  make(map[string]bool),
 		columnsMap:       make(map[string]Column),
+		masks:            make(map[string]MaskFunc),
+		presets:          make(map[string]func(*gorm.DB) *gorm.DB),
 	}
 	dt.initColumnsMap()
+	for _, opt := range opts {
+		opt(dt)
+	}
 	return dt
 }
 
@@ -32,92 +40,254 @@ func New(tx *gorm.DB) *DataTable {
 //  1. Validate the DataTable configuration.
 //  2. Execute the query and get the total records count, filtered records count
 //     and the actual data.
-//  3. Run the custom column rendering functions in parallel.
-//  4. Apply the row attributes in parallel.
-//  5. Apply the custom columns in parallel.
-//  6. If selected columns are defined, it will filter the columns for the response.
-//  7. Merge the additional data into the response.
-//  8. Return the response.
+//  3. Run the BeforeRender hooks, the OnRow callbacks, and the BatchRender
+//     hooks, and prime the loaders.
+//  4. Number the rows and run the custom column rendering functions.
+//  5. Apply the custom columns.
+//  6. Apply the row attributes.
+//  7. If selected columns are defined, it will filter the columns for the response.
+//  8. Run the AfterRender hooks.
+//  9. Compute the meta fields, the summary row, the next cursor, and the
+//     data version, and apply the transformer, if any, or convert the rows
+//     to arrays when the response format is ResponseFormatArray.
+//  10. Merge the additional data and meta fields into the response and shape
+//     it according to the response schema.
+//  11. Return the response.
 //
 // The function returns a DataTables compatible response or an error if it
 // occurs.
 func (dt *DataTable) Make() (map[string]any, error) {
+	res, err := dt.make()
+	if err != nil {
+		return nil, err
+	}
+	return dt.buildResponse(res), nil
+}
+
+// result holds the outcome of processing a DataTable request before it is
+// shaped into a response.
+type result struct {
+	total    int64
+	filtered int64
+	rows     int
+	data     any
+	meta     map[string]any
+}
+
+// make runs the whole DataTable pipeline described in Make and returns its
+// result. The outcome is recorded to the audit sink and reported to the
+// metrics recorder and the cost warning callback, if they are set.
+func (dt *DataTable) make() (*result, error) {
+	dt.record()
+	start := time.Now()
+	res, err := dt.run()
+	dt.checkCost(start, res, err)
+	if auditErr := dt.audit(res, err); auditErr != nil && err == nil {
+		err = auditErr
+	}
+	if err != nil {
+		dt.observeRequest(0, err)
+		return nil, err
+	}
+	dt.observeRequest(res.rows, nil)
+	return res, nil
+}
+
+// run validates the DataTable, processes the query, and renders the rows.
+func (dt *DataTable) run() (*result, error) {
+	dt.logDuration(phaseParse, dt.parseDuration, nil, slog.Int("columns", len(dt.req.Columns)))
+
+	start := time.Now()
 	if err := dt.Validate(); err != nil {
+		dt.logPhase(phaseValidate, start, err)
 		return nil, err
 	}
+	dt.logPhase(phaseValidate, start, nil)
 
 	data, total, filtered, err := dt.processQuery()
 	if err != nil {
 		return nil, err
 	}
+	return dt.render(data.([]map[string]any), total, filtered)
+}
 
-	var (
-		wg        sync.WaitGroup
-		mu        sync.Mutex
-		semChan   = make(chan struct{}, runtime.NumCPU()*2)
-		dataSlice = data.([]map[string]any)
-	)
-
-	if noCol, ok := dt.columnsMap["no"]; ok {
-		wg.Add(len(dataSlice))
-		for i := range dataSlice {
-			go func(i int) {
-				defer wg.Done()
-				semChan <- struct{}{}
-				defer func() { <-semChan }()
-				mu.Lock()
-				defer mu.Unlock()
-				row := dataSlice[i]
-				row[noCol.Data] = dt.req.Start + i + 1
-			}(i)
-		}
+// render renders the fetched rows, removes the hidden columns, and computes
+// the meta fields and the shape of the data of the result.
+func (dt *DataTable) render(dataSlice []map[string]any, total, filtered int64) (*result, error) {
+	_, p := dt.beginPhase(phaseRender)
+
+	dt.applyUUIDColumns(dataSlice)
+
+	if err := runRenderHooks(dt.beforeRender, dataSlice); err != nil {
+		p.end(err, "rows", int64(len(dataSlice)))
+		return nil, err
 	}
 
-	wg.Add(len(dataSlice))
-	for _, row := range dataSlice {
-		go func(row map[string]any) {
-			defer wg.Done()
-			semChan <- struct{}{}
-			defer func() { <-semChan }()
-			mu.Lock()
-			defer mu.Unlock()
-			for _, col := range dt.columns {
-				if renderFunc := dt.columnsMap[col.Data].RenderFunc; renderFunc != nil {
-					row[col.Data] = renderFunc(row)
-				}
-			}
-		}(row)
+	if err := dt.runOnRow(dataSlice); err != nil {
+		p.end(err, "rows", int64(len(dataSlice)))
+		return nil, err
 	}
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		mu.Lock()
-		defer mu.Unlock()
-		dt.applyCustomColumns(dataSlice)
-	}()
+	if err := dt.runBatchRender(dataSlice); err != nil {
+		p.end(err, "rows", int64(len(dataSlice)))
+		return nil, err
+	}
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		mu.Lock()
-		defer mu.Unlock()
-		dt.applyRowAttributes(dataSlice)
-	}()
+	if err := dt.primeLoaders(dataSlice); err != nil {
+		p.end(err, "rows", int64(len(dataSlice)))
+		return nil, err
+	}
 
-	wg.Wait()
+	dt.renderRows(dataSlice, filtered)
+	dt.applyCustomColumns(dataSlice)
+	dt.applyRowAttributes(dataSlice)
+	p.end(nil, "rows", int64(len(dataSlice)))
+
+	dt.removeHiddenColumns(dataSlice)
 
 	if len(dt.selectedColumns) > 0 {
-		data = dt.FinalizeResponseColumns(dataSlice)
+		dataSlice = dt.FinalizeResponseColumns(dataSlice)
 	}
 
-	response := map[string]any{
-		"draw":            dt.req.Draw,
-		"recordsTotal":    total,
-		"recordsFiltered": filtered,
-		"data":            data,
+	if err := runRenderHooks(dt.afterRender, dataSlice); err != nil {
+		return nil, err
 	}
-	maps.Copy(response, dt.additionalData)
 
-	return response, nil
+	meta := dt.computeMeta(total, filtered, dataSlice)
+	if dt.summary != nil {
+		meta[summaryKey] = dt.summary
+	}
+	if _, ok := dt.paginator.(CursorPaginator); ok && dt.config.Paginate {
+		meta[nextCursorKey] = nil
+		if dt.pageCursor != "" {
+			meta[nextCursorKey] = dt.pageCursor
+		}
+	} else if dt.config.CursorPagination {
+		meta[nextCursorKey] = dt.nextCursor(filtered, len(dataSlice))
+	}
+	if dt.version != nil {
+		version := dt.version.current
+		if version == "" {
+			var err error
+			if version, err = dt.dataVersion(); err != nil {
+				return nil, err
+			}
+		}
+		meta[versionKey] = version
+	}
+	if dt.recordingID != "" {
+		meta[recordingKey] = dt.recordingID
+	}
+
+	return &result{
+		total:    total,
+		filtered: filtered,
+		rows:     len(dataSlice),
+		meta:     meta,
+		data:     dt.shapeData(dataSlice),
+	}, nil
+}
+
+// rawValueSuffix is appended to the key of a rendered column to hold its
+// value before rendering when Config.IncludeRawValues is set.
+const rawValueSuffix = "_raw"
+
+// renderRows sets the index column, if any, and runs the rendering functions
+// of the columns on every row, in a single pass over the rows. The columns
+// with a rendering function are looked up once rather than per row. Their
+// values before rendering are kept when Config.IncludeRawValues is set, and
+// the cells of the columns with orthogonal data are set to objects holding
+// the value of each rendering type. The values of the columns obfuscated
+// with ObfuscateIDs are encoded after rendering, including those of the
+// fetched fields that are not defined as columns.
+func (dt *DataTable) renderRows(data []map[string]any, filtered int64) {
+	renderers := make([]Column, 0, len(dt.columns))
+	for _, col := range dt.columns {
+		if col := dt.columnsMap[col.Data]; col.RenderFunc != nil || len(col.Renders) > 0 || dt.isObfuscated(col.Data) {
+			renderers = append(renderers, col)
+		}
+	}
+	var undefined []string
+	for _, data := range slices.Sorted(maps.Keys(dt.obfuscated)) {
+		if _, exists := dt.columnsMap[data]; !exists {
+			undefined = append(undefined, data)
+		}
+	}
+	idx := dt.indexColumn
+	if idx == nil && len(renderers) == 0 && len(undefined) == 0 {
+		return
+	}
+
+	var errs []RenderError
+	for i, row := range data {
+		if idx != nil {
+			row[idx.data] = idx.number(dt.req.Start, i, filtered)
+		}
+		for _, col := range renderers {
+			if dt.includesRawValue(col.Data) {
+				row[col.Data+rawValueSuffix] = row[col.Data]
+			}
+			var cell map[string]any
+			if len(col.Renders) > 0 {
+				cell = make(map[string]any, len(col.Renders)+1)
+				for _, typ := range slices.Sorted(maps.Keys(col.Renders)) {
+					value, err := dt.renderValue(col.Renders[typ], row)
+					if err != nil {
+						errs = append(errs, RenderError{Row: i, Column: col.Data, Err: err})
+					}
+					cell[typ] = value
+				}
+			}
+			if _, ok := cell[RenderDisplay]; ok {
+				row[col.Data] = cell
+				continue
+			}
+			value := row[col.Data]
+			if col.RenderFunc != nil {
+				var err error
+				if value, err = dt.renderValue(col.RenderFunc, row); err != nil {
+					errs = append(errs, RenderError{Row: i, Column: col.Data, Err: err})
+				}
+			}
+			if codec, ok := dt.obfuscated[col.Data]; ok {
+				value = encodeID(codec, value)
+			}
+			if cell != nil {
+				cell[RenderDisplay] = value
+				row[col.Data] = cell
+				continue
+			}
+			row[col.Data] = value
+		}
+		for _, data := range undefined {
+			if value, ok := row[data]; ok {
+				row[data] = encodeID(dt.obfuscated[data], value)
+			}
+		}
+	}
+	dt.reportRenderErrors(errs)
+}
+
+// renderValue runs the rendering function on the row. When render errors are
+// isolated, a failing function yields the fallback value and its error.
+func (dt *DataTable) renderValue(render func(map[string]any) any, row map[string]any) (any, error) {
+	if dt.renderIsolation == nil {
+		return render(row), nil
+	}
+	value, err := renderCell(render, row)
+	if err != nil {
+		return dt.renderIsolation.fallback, err
+	}
+	return value, nil
+}
+
+// includesRawValue reports whether the raw value of the column is added to
+// the rows: Config.IncludeRawValues is set and the column is neither
+// obfuscated with ObfuscateIDs nor masked.
+func (dt *DataTable) includesRawValue(data string) bool {
+	if _, ok := dt.obfuscated[data]; ok || !dt.config.IncludeRawValues {
+		return false
+	}
+	_, masked := dt.masks[data]
+	return !masked
 }
@@ -1,7 +1,9 @@
 package datatables
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -170,3 +172,248 @@ func TestMakeValidationError(t *testing.T) {
 		t.Errorf("expected error 'model is required', got '%v'", err)
 	}
 }
+
+func TestIncludeRawValues(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+	mock.ExpectQuery(qm("SELECT * FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}).AddRow(1, "John Doe", 25))
+
+	dt := New(db).Model(&User{})
+	dt.config.IncludeRawValues = true
+	dt.Req(Request{
+		Draw:   1,
+		Length: -1,
+		Columns: []ColumnRequest{
+			{Name: "id", Data: "id"},
+			{Name: "name", Data: "name"},
+			{Name: "age", Data: "age"},
+		},
+	})
+	dt.EditColumn("name", func(v any) any { return "<b>" + v.(string) + "</b>" }).
+		EditColumn("age", func(v any) any { return v }).
+		BlacklistColumn("age")
+
+	response, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []map[string]any{{"id": 1, "name": "<b>John Doe</b>", "name_raw": "John Doe"}}
+	if data := normalizeResponse(response["data"].([]map[string]any)); !reflect.DeepEqual(data, expected) {
+		t.Errorf("expected data %v, got %v", expected, data)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestIncludeRawValuesHidesProtectedColumns(t *testing.T) {
+	db, mock := newMockDB(t)
+	codec, _ := NewAESIDCodec([]byte("0123456789abcdef"))
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+	mock.ExpectQuery(qm("SELECT * FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}).AddRow(7, "John Doe", 25))
+
+	dt := New(db).Model(&User{})
+	dt.config.IncludeRawValues = true
+	dt.Req(Request{
+		Draw:   1,
+		Length: -1,
+		Columns: []ColumnRequest{
+			{Name: "id", Data: "id"},
+			{Name: "name", Data: "name"},
+			{Name: "age", Data: "age"},
+		},
+	})
+	dt.ObfuscateIDs(codec).
+		MaskColumn("name", func(string) string { return "J***" }).
+		EditColumn("name", func(v any) any { return "<b>" + v.(string) + "</b>" }).
+		EditColumn("age", func(v any) any { return v })
+
+	response, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	row := response["data"].([]map[string]any)[0]
+	for _, key := range []string{"id_raw", "name_raw"} {
+		if value, ok := row[key]; ok {
+			t.Errorf("expected %s to be left out, got %v", key, value)
+		}
+	}
+	if id, err := codec.Decode(row["id"].(string)); err != nil || id != 7 {
+		t.Errorf("expected encoded id 7, got %v (%v)", row["id"], err)
+	}
+	if row["name"] != "<b>J***</b>" {
+		t.Errorf("expected masked name, got %v", row["name"])
+	}
+	if _, ok := row["age_raw"]; !ok {
+		t.Errorf("expected age_raw, got %v", row)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestOrthogonalData(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+	mock.ExpectQuery(qm("SELECT * FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}).AddRow(1, "John Doe", 25))
+
+	dt := New(db).Model(&User{})
+	dt.Req(Request{
+		Draw:   1,
+		Length: -1,
+		Columns: []ColumnRequest{
+			{Name: "id", Data: "id"},
+			{Name: "name", Data: "name"},
+			{Name: "age", Data: "age"},
+		},
+	})
+	dt.AddColumns(
+		Column{
+			Name: "name",
+			Data: "name",
+			RenderFunc: func(row map[string]any) any {
+				return "<b>" + row["name"].(string) + "</b>"
+			},
+			Renders: map[string]func(map[string]any) any{
+				RenderSort: func(row map[string]any) any {
+					return strings.ToLower(row["name"].(string))
+				},
+				RenderExport: func(row map[string]any) any { return row["name"] },
+			},
+		},
+		Column{
+			Name: "age",
+			Data: "age",
+			Renders: map[string]func(map[string]any) any{
+				RenderDisplay: func(row map[string]any) any { return fmt.Sprintf("%v years", row["age"]) },
+			},
+		},
+	)
+
+	response, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []map[string]any{{
+		"id": 1,
+		"name": map[string]any{
+			RenderDisplay: "<b>John Doe</b>",
+			RenderSort:    "john doe",
+			RenderExport:  "John Doe",
+		},
+		"age": map[string]any{RenderDisplay: "25 years"},
+	}}
+	if data := normalizeResponse(response["data"].([]map[string]any)); !reflect.DeepEqual(data, expected) {
+		t.Errorf("expected data %v, got %v", expected, data)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+// newMockDB returns a gorm DB backed by sqlmock using the MySQL dialector.
+// The underlying connection is closed when the test finishes.
+func newMockDB(t testing.TB) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() { dbMock.Close() })
+
+	dialector := mysql.New(mysql.Config{
+		Conn:                      dbMock,
+		SkipInitializeWithVersion: true,
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm DB: %v", err)
+	}
+
+	return db, mock
+}
+
+// namedDialector reports the given name instead of the name of the wrapped
+// dialector, to exercise dialect-specific SQL with sqlmock.
+type namedDialector struct {
+	gorm.Dialector
+	name string
+}
+
+// Name returns the dialect name.
+func (d namedDialector) Name() string {
+	return d.name
+}
+
+// newMockDBWithDialect works like newMockDB but the DB reports the given
+// dialect name. The SQL is still quoted like MySQL.
+func newMockDBWithDialect(t *testing.T, name string) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock := newMockDB(t)
+	db.Dialector = namedDialector{Dialector: db.Dialector, name: name}
+	return db, mock
+}
+
+// benchmarkRows is the page length used by the benchmarks.
+const benchmarkRows = 10000
+
+func BenchmarkMake(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		b.StopTimer()
+		db, mock := newMockDB(b)
+		rows := sqlmock.NewRows([]string{"id", "name", "age"})
+		for i := range benchmarkRows {
+			rows.AddRow(i, "John Doe", 25)
+		}
+		mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(benchmarkRows)))
+		mock.ExpectQuery(qm("SELECT * FROM `users`")).WillReturnRows(rows)
+
+		dt := New(db).Model(&User{}).
+			EditColumn("name", func(v any) any { return v }).
+			WithNumber()
+		dt.Req(Request{
+			Draw:   1,
+			Length: benchmarkRows,
+			Columns: []ColumnRequest{
+				{Name: "id", Data: "id", Searchable: true, Orderable: true},
+				{Name: "name", Data: "name", Searchable: true, Orderable: true},
+				{Name: "age", Data: "age", Searchable: true, Orderable: true},
+			},
+		})
+		b.StartTimer()
+
+		if _, err := dt.Make(); err != nil {
+			b.Fatalf("expected no error, got %v", err)
+		}
+	}
+}
+
+func BenchmarkReq(b *testing.B) {
+	req := Request{Draw: 1, Length: 10}
+	for i := range 20 {
+		data := fmt.Sprintf("col_%d", i)
+		req.Columns = append(req.Columns, ColumnRequest{Name: data, Data: data, Searchable: true})
+	}
+
+	b.ReportAllocs()
+	dt := New(nil)
+	for b.Loop() {
+		dt.Req(req)
+	}
+}
@@ -0,0 +1,46 @@
+package datatablestest
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// UpdateEnv is the environment variable that, when set to a non-empty
+// value, makes AssertGolden write the golden files instead of comparing
+// against them.
+const UpdateEnv = "DATATABLES_UPDATE_GOLDEN"
+
+// AssertGolden compares the JSON encoding of the response with the golden
+// file testdata/<name>.golden and reports a test error on mismatch. Run the
+// tests with DATATABLES_UPDATE_GOLDEN=1 to create or update the golden
+// files.
+func AssertGolden(t testing.TB, name string, response any) {
+	t.Helper()
+
+	got, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		t.Fatalf("datatablestest: encode response: %v", err)
+	}
+	got = append(got, '\n')
+
+	path := filepath.Join("testdata", name+".golden")
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("datatablestest: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("datatablestest: %v", err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("datatablestest: %v (run with %s=1 to create it)", err, UpdateEnv)
+	}
+	if string(got) != string(expected) {
+		t.Errorf("datatablestest: response does not match %s:\ngot:\n%s\nexpected:\n%s", path, got, expected)
+	}
+}
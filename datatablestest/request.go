@@ -0,0 +1,182 @@
+// Package datatablestest provides helpers to unit-test table definitions:
+// a fluent builder of DataTables requests, golden-response assertions, and
+// an in-memory data source standing in for the database.
+//
+//	db := (&datatablestest.DataSource{
+//		Columns: []string{"id", "name"},
+//		Rows:    [][]any{{1, "John"}, {2, "Jane"}},
+//	}).DB(t)
+//	req := datatablestest.NewRequest().Column("name", true, true).Length(10).Build()
+//	response, err := UserTable.New(db, req).Make()
+//	datatablestest.AssertGolden(t, "users", response)
+package datatablestest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+
+	datatables "github.com/ZihxS/golang-gorm-datatables"
+)
+
+// RequestBuilder builds DataTables requests fluently. The zero value is
+// not usable, use NewRequest.
+type RequestBuilder struct {
+	req datatables.Request
+}
+
+// NewRequest returns a RequestBuilder for a first draw without columns.
+func NewRequest() *RequestBuilder {
+	return &RequestBuilder{req: datatables.Request{Draw: 1}}
+}
+
+// Draw sets the draw counter.
+func (b *RequestBuilder) Draw(draw int) *RequestBuilder {
+	b.req.Draw = draw
+	return b
+}
+
+// Start sets the index of the first row of the page.
+func (b *RequestBuilder) Start(start int) *RequestBuilder {
+	b.req.Start = start
+	return b
+}
+
+// Length sets the page length.
+func (b *RequestBuilder) Length(length int) *RequestBuilder {
+	b.req.Length = length
+	return b
+}
+
+// Search sets the global search.
+func (b *RequestBuilder) Search(value string, regex bool) *RequestBuilder {
+	b.req.Search = datatables.Search{Value: value, Regex: regex}
+	return b
+}
+
+// Column adds a column with the given data, used as its name too.
+func (b *RequestBuilder) Column(data string, searchable, orderable bool) *RequestBuilder {
+	b.req.Columns = append(b.req.Columns, datatables.ColumnRequest{
+		Data:       data,
+		Name:       data,
+		Searchable: searchable,
+		Orderable:  orderable,
+	})
+	return b
+}
+
+// ColumnSearch sets the search of the column with the given data. It does
+// nothing if the column was not added.
+func (b *RequestBuilder) ColumnSearch(data, value string, regex bool) *RequestBuilder {
+	for i := range b.req.Columns {
+		if b.req.Columns[i].Data == data {
+			b.req.Columns[i].Search = datatables.Search{Value: value, Regex: regex}
+		}
+	}
+	return b
+}
+
+// Hide marks the column with the given data as hidden on the client. It
+// does nothing if the column was not added.
+func (b *RequestBuilder) Hide(data string) *RequestBuilder {
+	for i := range b.req.Columns {
+		if b.req.Columns[i].Data == data {
+			b.req.Columns[i].Hidden = true
+		}
+	}
+	return b
+}
+
+// Order adds an ordering on the column with the given data, in the given
+// direction, asc or desc. It does nothing if the column was not added.
+func (b *RequestBuilder) Order(data, dir string) *RequestBuilder {
+	for i, col := range b.req.Columns {
+		if col.Data == data {
+			b.req.Order = append(b.req.Order, datatables.Order{Column: i, Dir: dir})
+			break
+		}
+	}
+	return b
+}
+
+// Filter adds a filter preset.
+func (b *RequestBuilder) Filter(names ...string) *RequestBuilder {
+	b.req.Filters = append(b.req.Filters, names...)
+	return b
+}
+
+// SignedFilter adds signed filter tokens issued with datatables.SignFilter.
+func (b *RequestBuilder) SignedFilter(tokens ...string) *RequestBuilder {
+	b.req.SignedFilters = append(b.req.SignedFilters, tokens...)
+	return b
+}
+
+// Cursor sets the cursor of an infinite scroll request.
+func (b *RequestBuilder) Cursor(cursor string) *RequestBuilder {
+	b.req.Cursor = cursor
+	return b
+}
+
+// Build returns the request.
+func (b *RequestBuilder) Build() datatables.Request {
+	req := b.req
+	req.Columns = append([]datatables.ColumnRequest(nil), b.req.Columns...)
+	req.Order = append([]datatables.Order(nil), b.req.Order...)
+	req.Filters = append([]string(nil), b.req.Filters...)
+	req.SignedFilters = append([]string(nil), b.req.SignedFilters...)
+	return req
+}
+
+// Values returns the request encoded as the parameters sent by DataTables,
+// as parsed by datatables.ParseRequest.
+func (b *RequestBuilder) Values() url.Values {
+	values := url.Values{
+		"draw":          {strconv.Itoa(b.req.Draw)},
+		"start":         {strconv.Itoa(b.req.Start)},
+		"length":        {strconv.Itoa(b.req.Length)},
+		"search[value]": {b.req.Search.Value},
+		"search[regex]": {strconv.FormatBool(b.req.Search.Regex)},
+	}
+	for i, col := range b.req.Columns {
+		prefix := "columns[" + strconv.Itoa(i) + "]"
+		values.Set(prefix+"[data]", col.Data)
+		values.Set(prefix+"[name]", col.Name)
+		values.Set(prefix+"[searchable]", strconv.FormatBool(col.Searchable))
+		values.Set(prefix+"[orderable]", strconv.FormatBool(col.Orderable))
+		values.Set(prefix+"[search][value]", col.Search.Value)
+		values.Set(prefix+"[search][regex]", strconv.FormatBool(col.Search.Regex))
+		values.Set(prefix+"[visible]", strconv.FormatBool(!col.Hidden))
+	}
+	for i, order := range b.req.Order {
+		prefix := "order[" + strconv.Itoa(i) + "]"
+		values.Set(prefix+"[column]", strconv.Itoa(order.Column))
+		values.Set(prefix+"[dir]", order.Dir)
+	}
+	for _, name := range b.req.Filters {
+		values.Add("filter", name)
+	}
+	for _, token := range b.req.SignedFilters {
+		values.Add("signed_filter", token)
+	}
+	if b.req.Cursor != "" {
+		values.Set("cursor", b.req.Cursor)
+	}
+	return values
+}
+
+// HTTPRequest returns an HTTP request carrying the request parameters, in
+// the query string for GET and as a form body for POST.
+func (b *RequestBuilder) HTTPRequest(method, target string) *http.Request {
+	if method == http.MethodGet {
+		sep := "?"
+		if strings.Contains(target, "?") {
+			sep = "&"
+		}
+		return httptest.NewRequest(method, target+sep+b.Values().Encode(), nil)
+	}
+	r := httptest.NewRequest(method, target, strings.NewReader(b.Values().Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
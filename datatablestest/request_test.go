@@ -0,0 +1,53 @@
+package datatablestest
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	datatables "github.com/ZihxS/golang-gorm-datatables"
+)
+
+func TestRequestBuilder(t *testing.T) {
+	b := NewRequest().
+		Draw(2).
+		Start(10).
+		Length(5).
+		Search("john", false).
+		Column("id", false, true).
+		Column("name", true, true).
+		ColumnSearch("name", "^J", true).
+		Hide("id").
+		Order("name", "desc").
+		Filter("active").
+		SignedFilter("e30.c2ln")
+
+	expected := datatables.Request{
+		Draw:   2,
+		Start:  10,
+		Length: 5,
+		Search: datatables.Search{Value: "john"},
+		Columns: []datatables.ColumnRequest{
+			{Data: "id", Name: "id", Orderable: true, Hidden: true},
+			{Data: "name", Name: "name", Searchable: true, Orderable: true, Search: datatables.Search{Value: "^J", Regex: true}},
+		},
+		Order:         []datatables.Order{{Column: 1, Dir: "desc"}},
+		Filters:       []string{"active"},
+		SignedFilters: []string{"e30.c2ln"},
+	}
+	if got := b.Build(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		t.Run(method, func(t *testing.T) {
+			parsed, err := datatables.ParseRequest(b.HTTPRequest(method, "/users"))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(*parsed, expected) {
+				t.Errorf("expected %+v, got %+v", expected, *parsed)
+			}
+		})
+	}
+}
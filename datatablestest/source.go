@@ -0,0 +1,136 @@
+package datatablestest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// DataSource is an in-memory stand-in for the database of a DataTable.
+//
+// Count queries return the number of rows and data queries return the rows,
+// paginated with the LIMIT and OFFSET of the query. The SQL is not otherwise
+// evaluated: searches, filters, and ordering are not applied, so the rows
+// should be the ones the query is expected to return. Use it to test the
+// columns, renderers, and response shape of a table definition; use sqlmock
+// to assert the generated SQL.
+type DataSource struct {
+	Columns []string
+	Rows    [][]any
+}
+
+// DB returns a GORM database, using the MySQL dialector, that reads from the
+// data source. The database is closed when the test ends.
+func (s *DataSource) DB(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	sqlDB := sql.OpenDB(sourceConnector{s})
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("datatablestest: open gorm DB: %v", err)
+	}
+	return db
+}
+
+// query returns the columns and rows answering the given query.
+func (s *DataSource) query(query string, args []driver.NamedValue) ([]string, [][]any) {
+	lower := strings.ToLower(query)
+	if strings.HasPrefix(lower, "select count(") {
+		return []string{"count"}, [][]any{{int64(len(s.Rows))}}
+	}
+
+	rows := s.Rows
+	offset, limit := 0, -1
+	// GORM appends the LIMIT and OFFSET arguments last, in this order.
+	n := len(args)
+	if strings.Contains(lower, "offset ?") && n > 0 {
+		offset = int(toInt64(args[n-1].Value))
+		n--
+	}
+	if strings.Contains(lower, "limit ?") && n > 0 {
+		limit = int(toInt64(args[n-1].Value))
+	}
+	if offset > len(rows) {
+		offset = len(rows)
+	}
+	rows = rows[offset:]
+	if limit >= 0 && limit < len(rows) {
+		rows = rows[:limit]
+	}
+	return s.Columns, rows
+}
+
+func toInt64(v driver.Value) int64 {
+	switch v := v.(type) {
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	}
+	return 0
+}
+
+var errReadOnly = errors.New("datatablestest: data source is read-only")
+
+type sourceConnector struct{ source *DataSource }
+
+func (c sourceConnector) Connect(context.Context) (driver.Conn, error) {
+	return sourceConn(c), nil
+}
+
+func (c sourceConnector) Driver() driver.Driver { return sourceDriver{} }
+
+type sourceDriver struct{}
+
+func (sourceDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("datatablestest: use DataSource.DB")
+}
+
+type sourceConn struct{ source *DataSource }
+
+func (c sourceConn) Prepare(string) (driver.Stmt, error) { return nil, errReadOnly }
+func (c sourceConn) Close() error                        { return nil }
+func (c sourceConn) Begin() (driver.Tx, error)           { return nil, errReadOnly }
+
+func (c sourceConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	columns, rows := c.source.query(query, args)
+	return &sourceRows{columns: columns, rows: rows}, nil
+}
+
+type sourceRows struct {
+	columns []string
+	rows    [][]any
+}
+
+func (r *sourceRows) Columns() []string { return r.columns }
+func (r *sourceRows) Close() error      { return nil }
+
+func (r *sourceRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	for i := range dest {
+		if i >= len(r.rows[0]) {
+			break
+		}
+		v, err := driver.DefaultParameterConverter.ConvertValue(r.rows[0][i])
+		if err != nil {
+			return err
+		}
+		dest[i] = v
+	}
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,36 @@
+package datatablestest
+
+import (
+	"testing"
+
+	datatables "github.com/ZihxS/golang-gorm-datatables"
+)
+
+type user struct {
+	ID   int
+	Name string
+}
+
+var userTable = &datatables.TableDefinition{
+	Model: &user{},
+	Columns: []datatables.Column{
+		{Name: "name", Data: "name", Searchable: true, Orderable: true, RenderFunc: func(row map[string]any) any {
+			return "Mr. " + row["name"].(string)
+		}},
+	},
+}
+
+func TestDataSource(t *testing.T) {
+	db := (&DataSource{
+		Columns: []string{"id", "name"},
+		Rows:    [][]any{{1, "John"}, {2, "Jane"}, {3, "Jim"}},
+	}).DB(t)
+
+	req := NewRequest().Column("name", true, true).Start(1).Length(1).Build()
+	response, err := userTable.New(db, req).Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	AssertGolden(t, "users", response)
+}
@@ -0,0 +1,49 @@
+package datatablestest
+
+import (
+	"database/sql/driver"
+	"regexp"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	datatables "github.com/ZihxS/golang-gorm-datatables"
+)
+
+// Pattern returns the sqlmock query pattern matching the statement exactly.
+func Pattern(stmt datatables.Statement) string {
+	return regexp.QuoteMeta(stmt.SQL)
+}
+
+// Args returns the statement arguments as sqlmock expects them.
+func Args(stmt datatables.Statement) []driver.Value {
+	args := make([]driver.Value, len(stmt.Vars))
+	for i, v := range stmt.Vars {
+		args[i] = v
+	}
+	return args
+}
+
+// Expect registers the expectation of the statement on the mock, with its
+// arguments, and returns it so the result can be set.
+func Expect(mock sqlmock.Sqlmock, stmt datatables.Statement) *sqlmock.ExpectedQuery {
+	return mock.ExpectQuery(Pattern(stmt)).WithArgs(Args(stmt)...)
+}
+
+// ExpectMake registers the expectations of the queries Make runs for the
+// DataTable: the total and filtered counts, returning the given counts, and
+// the data query, returning the rows. Counts the DataTable does not compute
+// with a plain COUNT query are not expected, see DataTable.Statements.
+// Returns an error if the DataTable is invalid.
+func ExpectMake(mock sqlmock.Sqlmock, dt *datatables.DataTable, total, filtered int64, rows *sqlmock.Rows) error {
+	totalStmt, filteredStmt, dataStmt, err := dt.Statements()
+	if err != nil {
+		return err
+	}
+	if totalStmt.SQL != "" {
+		Expect(mock, totalStmt).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(total))
+	}
+	if filteredStmt.SQL != "" {
+		Expect(mock, filteredStmt).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(filtered))
+	}
+	Expect(mock, dataStmt).WillReturnRows(rows)
+	return nil
+}
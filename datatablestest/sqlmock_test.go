@@ -0,0 +1,40 @@
+package datatablestest
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestExpectMake(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm DB: %v", err)
+	}
+
+	req := NewRequest().Column("name", true, true).Search("J", false).Order("name", "desc").Length(10).Build()
+	dt := userTable.New(db, req)
+
+	rows := sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John").AddRow(2, "Jane")
+	if err := ExpectMake(mock, dt, 3, 2, rows); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	response, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if response["recordsTotal"] != int64(3) || response["recordsFiltered"] != int64(2) {
+		t.Errorf("unexpected counts in %v", response)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package datatables
+
+import "gorm.io/gorm"
+
+// TableDefinition is a reusable, declarative definition of a DataTable.
+//
+// Large applications can declare each table once, with its columns, render
+// functions, configuration, relations, and filters, and produce a fresh
+// DataTable for every request with New. A definition is never mutated by
+// the DataTables it produces, so it is safe to share between goroutines.
+//
+// The static parts of the requests, such as the columns matched by the
+// search, the columns ordered by, and the projection, are compiled once per
+// shape of the request columns and cached by the definition, so that each
+// draw only binds its search value and order directions. The cache is not
+// used by a DataTable whose columns or configuration are changed after New.
+//
+// Fields:
+//   - Model: The model or table name queried by the DataTable.
+//   - Config: The configuration; nil keeps the defaults used by New.
+//   - Columns: The server-defined columns, including their render functions.
+//     They take precedence over the columns sent in the request.
+//   - Relations: The relations to preload.
+//   - Filters: The filters always applied to the query.
+//   - Only: The columns included in the response; empty includes all.
+//   - Options: Additional options applied when the DataTable is created.
+type TableDefinition struct {
+	Model     any
+	Config    *Config
+	Columns   []Column
+	Relations []string
+	Filters   []func(*gorm.DB) *gorm.DB
+	Only      []string
+	Options   []Option
+	plans     planCache
+}
+
+// New returns a new DataTable for the given Gorm DB and request, configured
+// from the definition. The columns of the definition are added before the
+// request is applied, so numeric column data and Strict mode resolve
+// against them.
+func (d *TableDefinition) New(db *gorm.DB, req Request) *DataTable {
+	dt := New(db, d.Options...)
+	if d.Config != nil {
+		dt.SetConfig(*d.Config)
+	}
+	if d.Model != nil {
+		dt.Model(d.Model)
+	}
+	dt.AddColumns(d.Columns...)
+	dt.With(d.Relations...)
+	for _, filter := range d.Filters {
+		dt.Filter(filter)
+	}
+	if len(d.Only) > 0 {
+		dt.Only(append([]string(nil), d.Only...)...)
+	}
+	dt.Req(req)
+	dt.plans = &d.plans
+	return dt
+}
@@ -0,0 +1,153 @@
+package datatables
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+var usersDefinition = &TableDefinition{
+	Model:  &User{},
+	Config: &Config{Searchable: true, Paginate: true},
+	Columns: []Column{
+		{Name: "id", Data: "id"},
+		{Name: "name", Data: "name", Searchable: true, RenderFunc: func(row map[string]any) any {
+			return "Mr. " + row["name"].(string)
+		}},
+	},
+	Filters: []func(*gorm.DB) *gorm.DB{
+		func(db *gorm.DB) *gorm.DB { return db.Where("active = ?", true) },
+	},
+	Only: []string{"id", "name"},
+}
+
+func TestTableDefinitionNew(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE active = ?")).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE active = ? AND `name` LIKE ?")).
+		WithArgs(true, "%John%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(qm("SELECT * FROM `users` WHERE active = ? AND `name` LIKE ? LIMIT ?")).
+		WithArgs(true, "%John%", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}).AddRow(1, "John Doe", 30))
+
+	dt := usersDefinition.New(db, Request{
+		Draw:   1,
+		Length: 10,
+		Search: Search{Value: "John"},
+		Columns: []ColumnRequest{
+			{Name: "id", Data: "id"},
+			{Name: "name", Data: "name", Searchable: true},
+		},
+	})
+
+	response, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	row := response["data"].([]map[string]any)[0]
+	if row["name"] != "Mr. John Doe" {
+		t.Errorf("expected render function from definition, got %v", row["name"])
+	}
+	if _, ok := row["age"]; ok {
+		t.Errorf("expected age to be excluded by Only, got %v", row)
+	}
+	if dt.config.Orderable {
+		t.Errorf("expected config from definition, got %+v", dt.config)
+	}
+
+	other := usersDefinition.New(db, Request{Draw: 1})
+	if other == dt || len(other.filters) != 1 || len(usersDefinition.Only) != 2 {
+		t.Errorf("expected a fresh DataTable without mutating the definition")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestTableDefinitionNumericColumnData(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE active = ?")).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE active = ? AND `name` LIKE ?")).
+		WithArgs(true, "%John%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(qm("SELECT * FROM `users` WHERE active = ? AND `name` LIKE ? LIMIT ?")).
+		WithArgs(true, "%John%", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+
+	dt := usersDefinition.New(db, Request{
+		Draw:   1,
+		Length: 10,
+		Search: Search{Value: "John"},
+		Columns: []ColumnRequest{
+			{Data: "0"},
+			{Data: "1", Searchable: true},
+		},
+	})
+
+	if _, ok := dt.columnsMap["1"]; ok {
+		t.Errorf("expected the numeric column data to resolve to the definition columns, got %v", dt.columnsMap)
+	}
+	response, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if row := response["data"].([]map[string]any)[0]; row["name"] != "Mr. John Doe" {
+		t.Errorf("expected render function from definition, got %v", row["name"])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestTableDefinitionStrict(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE active = ?")).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE active = ? AND `name` LIKE ?")).
+		WithArgs(true, "%John%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(qm("SELECT * FROM `users` WHERE active = ? AND `name` LIKE ? LIMIT ?")).
+		WithArgs(true, "%John%", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+
+	definition := &TableDefinition{
+		Model:   usersDefinition.Model,
+		Config:  &Config{Searchable: true, Paginate: true, Strict: true},
+		Columns: usersDefinition.Columns,
+		Filters: usersDefinition.Filters,
+	}
+	dt := definition.New(db, Request{
+		Draw:   1,
+		Length: 10,
+		Search: Search{Value: "John"},
+		Columns: []ColumnRequest{
+			{Data: "name", Searchable: true},
+			{Data: "password", Searchable: true},
+		},
+	})
+
+	if _, ok := dt.columnsMap["password"]; ok {
+		t.Error("expected the undeclared column not to be added")
+	}
+	if _, ok := dt.columnsMap["name"]; !ok {
+		t.Error("expected the definition column to be kept")
+	}
+	if _, err := dt.Make(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package datatables
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"gorm.io/gorm/clause"
+)
+
+// ErrRowNotFound is returned by Detail when no row of the table has the
+// requested primary key.
+var ErrRowNotFound = errors.New("row not found")
+
+// Detail fetches the full row with the given primary key, including the
+// heavy columns the list leaves out (see HeavyColumns), for child rows and
+// expand use cases.
+//
+// The row is fetched with the base query of the DataTable, so its filters,
+// row policies, and filter presets apply and a row the table does not show
+// cannot be fetched. It is rendered like the rows of Make, with the
+// BeforeRender hooks and the OnRow callbacks, and the hidden columns are
+// removed. The model must be a struct with a primary key.
+//
+// When the primary key column is obfuscated with ObfuscateIDs, a string ID
+// is decoded with the codec first, and ErrInvalidID is returned if it
+// cannot be. Returns ErrRowNotFound if no row matches.
+func (dt *DataTable) Detail(id any) (map[string]any, error) {
+	if dt.err != nil {
+		return nil, dt.err
+	}
+	s := dt.modelSchema()
+	if s == nil || s.PrioritizedPrimaryField == nil {
+		return nil, errors.New(dt.translate(MsgDetailModel, "detail requires a model with a primary key"))
+	}
+	if codec, ok := dt.obfuscated[s.PrioritizedPrimaryField.DBName]; ok {
+		if encoded, ok := id.(string); ok {
+			decoded, err := codec.Decode(encoded)
+			if err != nil {
+				return nil, ErrInvalidID
+			}
+			id = decoded
+		}
+	}
+
+	dt.prepare()
+	query := dt.buildBaseQuery().
+		Where(clause.Eq{
+			Column: clause.Column{Table: clause.CurrentTable, Name: s.PrioritizedPrimaryField.DBName},
+			Value:  id,
+		}).
+		Limit(1)
+	rows, err := dt.executeQuery(dt.intercept(phaseFetch, query).WithContext(dt.context()), 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, ErrRowNotFound
+	}
+
+	dt.removeDeniedColumns(rows)
+	dt.applyUUIDColumns(rows)
+	dt.applyMasks(rows)
+	if err := runRenderHooks(dt.beforeRender, rows); err != nil {
+		return nil, err
+	}
+	if err := dt.runOnRow(rows); err != nil {
+		return nil, err
+	}
+	if err := dt.runBatchRender(rows); err != nil {
+		return nil, err
+	}
+	if err := dt.primeLoaders(rows); err != nil {
+		return nil, err
+	}
+	dt.renderRows(rows, 1)
+	dt.applyCustomColumns(rows)
+	dt.applyRowAttributes(rows)
+	dt.removeHiddenColumns(rows)
+	return rows[0], nil
+}
+
+// DetailHandler returns an http.Handler serving the detail of a row: the
+// primary key is read from the "id" parameter and passed to Detail on the
+// DataTable returned by build, which decodes it when the primary key column
+// is obfuscated with ObfuscateIDs, and the row is written as JSON.
+//
+// A missing or undecodable id is answered with 400 Bad Request and an
+// unknown one with 404 Not Found, with the error message under the "error"
+// key. Other errors, which may reveal details of the database, are logged
+// with the logger of the DataTable, if any, and answered with 500 Internal
+// Server Error and a generic message.
+func DetailHandler(build func(r *http.Request) (*DataTable, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.FormValue("id")
+		if id == "" {
+			writeJSON(w, http.StatusBadRequest, map[string]any{"error": "missing id"})
+			return
+		}
+
+		dt, err := build(r)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]any{"error": detailError})
+			return
+		}
+
+		row, err := dt.Detail(id)
+		switch {
+		case errors.Is(err, ErrInvalidID):
+			writeJSON(w, http.StatusBadRequest, map[string]any{"error": err.Error()})
+		case errors.Is(err, ErrRowNotFound):
+			writeJSON(w, http.StatusNotFound, map[string]any{"error": err.Error()})
+		case err != nil:
+			if dt.logger != nil {
+				dt.logger.LogAttrs(dt.context(), slog.LevelError, "datatables: detail failed",
+					slog.String("table", dt.tableName()),
+					slog.String("error", err.Error()),
+				)
+			}
+			writeJSON(w, http.StatusInternalServerError, map[string]any{"error": detailError})
+		default:
+			writeJSON(w, http.StatusOK, row)
+		}
+	})
+}
+
+// detailError is the message of the internal errors answered by
+// DetailHandler.
+const detailError = "internal server error"
@@ -0,0 +1,152 @@
+package datatables
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+func TestDetail(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT * FROM `articles` WHERE summary <> '' AND `articles`.`id` = ? LIMIT ?")).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "body"}).AddRow(1, "Hello", "Long body"))
+
+	dt := New(db).Model(&Article{}).
+		Filter(func(db *gorm.DB) *gorm.DB { return db.Where("summary <> ''") }).
+		AddColumn(Column{Name: "title", Data: "title", RenderFunc: func(row map[string]any) any {
+			return "# " + row["title"].(string)
+		}}).
+		HeavyColumns("body").
+		BeforeRender(func(data []map[string]any) error {
+			data[0]["body"] = strings.ToUpper(data[0]["body"].(string))
+			return nil
+		}).
+		OnRow(func(i int, row map[string]any) error {
+			row["position"] = i
+			return nil
+		})
+
+	row, err := dt.Detail(1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := map[string]any{"id": 1, "title": "# Hello", "body": "LONG BODY", "position": 0}
+	if !reflect.DeepEqual(row, expected) {
+		t.Errorf("expected %v, got %v", expected, row)
+	}
+
+	t.Run("not_found", func(t *testing.T) {
+		mock.ExpectQuery(qm("SELECT * FROM `articles` WHERE summary <> '' AND `articles`.`id` = ? LIMIT ?")).
+			WithArgs(2, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		if _, err := dt.Detail(2); !errors.Is(err, ErrRowNotFound) {
+			t.Errorf("expected ErrRowNotFound, got %v", err)
+		}
+	})
+
+	t.Run("no_primary_key", func(t *testing.T) {
+		if _, err := New(db).Model("articles").Detail(1); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestDetailHandler(t *testing.T) {
+	db, mock := newMockDB(t)
+	handler := DetailHandler(func(r *http.Request) (*DataTable, error) {
+		return New(db).Model(&Article{}), nil
+	})
+
+	mock.ExpectQuery(qm("SELECT * FROM `articles` WHERE `articles`.`id` = ? LIMIT ?")).
+		WithArgs("7", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "body"}).AddRow(7, "Long body"))
+	mock.ExpectQuery(qm("SELECT * FROM `articles` WHERE `articles`.`id` = ? LIMIT ?")).
+		WithArgs("8", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	tests := []struct {
+		target string
+		status int
+	}{
+		{"/articles/detail?id=7", http.StatusOK},
+		{"/articles/detail?id=8", http.StatusNotFound},
+		{"/articles/detail", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+		if rec.Code != tt.status {
+			t.Errorf("%s: expected status %d, got %d: %s", tt.target, tt.status, rec.Code, rec.Body)
+		}
+		if tt.status == http.StatusOK {
+			var row map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &row); err != nil || row["body"] != "Long body" {
+				t.Errorf("unexpected body %s", rec.Body)
+			}
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestDetailHandlerObfuscatedIDs(t *testing.T) {
+	db, mock := newMockDB(t)
+	codec, _ := NewAESIDCodec([]byte("0123456789abcdef"))
+	handler := DetailHandler(func(r *http.Request) (*DataTable, error) {
+		return New(db).Model(&Article{}).AddColumn(Column{Name: "id", Data: "id"}).ObfuscateIDs(codec), nil
+	})
+
+	encoded, _ := codec.Encode(7)
+	mock.ExpectQuery(qm("SELECT * FROM `articles` WHERE `articles`.`id` = ? LIMIT ?")).
+		WithArgs(7, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "body"}).AddRow(7, "Long body"))
+	mock.ExpectQuery(qm("SELECT * FROM `articles` WHERE `articles`.`id` = ? LIMIT ?")).
+		WithArgs(7, 1).
+		WillReturnError(errors.New("dial tcp 10.0.0.5:3306: connection refused"))
+
+	tests := []struct {
+		target string
+		status int
+		error  string
+	}{
+		{"/articles/detail?id=" + encoded, http.StatusOK, ""},
+		{"/articles/detail?id=" + encoded, http.StatusInternalServerError, detailError},
+		{"/articles/detail?id=7", http.StatusBadRequest, ErrInvalidID.Error()},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+		if rec.Code != tt.status {
+			t.Errorf("%s: expected status %d, got %d: %s", tt.target, tt.status, rec.Code, rec.Body)
+		}
+		var body map[string]any
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("unexpected body %s", rec.Body)
+		}
+		if tt.error != "" && body["error"] != tt.error {
+			t.Errorf("%s: expected error %q, got %v", tt.target, tt.error, body["error"])
+		}
+		if tt.status == http.StatusOK && body["id"] != encoded {
+			t.Errorf("expected the encoded id %s, got %v", encoded, body["id"])
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,26 @@
+package datatables
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// countDistinct counts the distinct combinations of Config.DistinctColumns
+// in the given query by wrapping a SELECT DISTINCT of the columns in a
+// subquery. The same form is used on every dialect, so the combinations
+// holding NULL values are counted alike everywhere, unlike with
+// COUNT(DISTINCT a, b), which skips them on MySQL.
+func (dt *DataTable) countDistinct(query *gorm.DB) (int64, error) {
+	var count int64
+	selects := make([]clause.Column, len(dt.config.DistinctColumns))
+	for i, name := range dt.config.DistinctColumns {
+		selects[i] = clause.Column{Name: name}
+	}
+
+	subQuery := query.Session(&gorm.Session{}).Clauses(clause.Select{Distinct: true, Columns: selects})
+	err := query.Session(&gorm.Session{NewDB: true}).
+		Table("(?) subquery", subQuery).
+		Select(queryCount).
+		Scan(&count).Error
+	return count, err
+}
@@ -0,0 +1,65 @@
+package datatables
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestCountDistinct(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT COUNT(*) AS count FROM (SELECT DISTINCT `user_id`,`day` FROM `users`) subquery")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(12)))
+	mock.ExpectQuery(qm("SELECT COUNT(*) AS count FROM (SELECT DISTINCT `user_id`,`day` FROM `users` WHERE `name` LIKE ?) subquery")).
+		WithArgs("%John%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(4)))
+
+	cfg := defaultConfig()
+	cfg.Distinct = true
+	cfg.DistinctColumns = []string{"user_id", "day"}
+	dt := New(db, WithConfig(cfg)).Model(&User{})
+	dt.Req(Request{
+		Draw:    1,
+		Search:  Search{Value: "John"},
+		Columns: []ColumnRequest{{Name: "name", Data: "name", Searchable: true}},
+	})
+
+	total, filtered, err := dt.Counts()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if total != 12 || filtered != 4 {
+		t.Errorf("expected counts 12 and 4, got %d and %d", total, filtered)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+// The combinations holding NULL values are rows of the SELECT DISTINCT
+// subquery, so they are counted alike on every dialect.
+func TestCountDistinctNullsOnEveryDialect(t *testing.T) {
+	for _, dialect := range []string{"mysql", "postgres", "sqlite"} {
+		t.Run(dialect, func(t *testing.T) {
+			db, mock := newMockDBWithDialect(t, dialect)
+
+			mock.ExpectQuery(qm("SELECT COUNT(*) AS count FROM (SELECT DISTINCT `user_id`,`day` FROM `users` WHERE day IS NULL) subquery")).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(3)))
+
+			dt := New(db).Model(&User{})
+			dt.config.DistinctColumns = []string{"user_id", "day"}
+
+			count, err := dt.countDistinct(db.Model(&User{}).Where("day IS NULL"))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if count != 3 {
+				t.Errorf("expected count 3, got %d", count)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package datatables
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// explainPrefix returns the EXPLAIN statement prefix for the given dialect
+// name. An empty string is returned when the dialect is not supported.
+func explainPrefix(dialect string) string {
+	switch dialect {
+	case "mysql", "postgres", "postgresql":
+		return "EXPLAIN "
+	case "sqlite", "sqlite3":
+		return "EXPLAIN QUERY PLAN "
+	default:
+		return ""
+	}
+}
+
+// Explain runs EXPLAIN on the filtered data query and returns the plan rows.
+//
+// The query is rendered with GORM's DryRun feature and then wrapped in the
+// EXPLAIN statement of the current dialect (EXPLAIN for MySQL and Postgres,
+// EXPLAIN QUERY PLAN for SQLite). This is useful to build a diagnostics
+// endpoint showing why a table is slow. Returns an error if the DataTable is
+// invalid or the dialect does not support EXPLAIN.
+func (dt *DataTable) Explain(ctx context.Context) ([]map[string]any, error) {
+	if err := dt.Validate(); err != nil {
+		return nil, err
+	}
+
+	prefix := explainPrefix(dt.tx.Dialector.Name())
+	if prefix == "" {
+		return nil, errors.New(dt.translatef(MsgUnsupportedDialect, "explain is not supported for dialect %s", dt.tx.Dialector.Name()))
+	}
+
+	if err := dt.applyCursor(); err != nil {
+		return nil, err
+	}
+	dt.prepare()
+	var result []map[string]any
+	stmt := dt.buildDataQuery().Session(&gorm.Session{DryRun: true}).Find(&result).Statement
+
+	var plan []map[string]any
+	err := dt.tx.Session(&gorm.Session{NewDB: true}).WithContext(ctx).
+		Raw(prefix+stmt.SQL.String(), stmt.Vars...).
+		Scan(&plan).Error
+	return plan, err
+}
@@ -0,0 +1,79 @@
+package datatables
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestExplainPrefix(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		expected string
+	}{
+		{dialect: "mysql", expected: "EXPLAIN "},
+		{dialect: "postgres", expected: "EXPLAIN "},
+		{dialect: "sqlite", expected: "EXPLAIN QUERY PLAN "},
+		{dialect: "sqlserver", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			if got := explainPrefix(tt.dialect); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer dbMock.Close()
+
+	dialector := mysql.New(mysql.Config{
+		Conn:                      dbMock,
+		SkipInitializeWithVersion: true,
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm DB: %v", err)
+	}
+
+	mock.ExpectQuery(qm("EXPLAIN SELECT * FROM `users` WHERE (`id` LIKE ? OR `name` LIKE ?) LIMIT ?")).
+		WithArgs("%John%", "%John%", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "select_type", "table"}).
+			AddRow(1, "SIMPLE", "users"))
+
+	dt := New(db)
+	dt.Model(&User{})
+	dt.Req(Request{
+		Draw:   1,
+		Length: 10,
+		Search: Search{Value: "John"},
+		Columns: []ColumnRequest{
+			{Name: "id", Data: "id", Searchable: true},
+			{Name: "name", Data: "name", Searchable: true},
+		},
+	})
+
+	plan, err := dt.Explain(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []map[string]any{{"id": 1, "select_type": "SIMPLE", "table": "users"}}
+	if !reflect.DeepEqual(normalizeResponse(plan), normalizeResponse(expected)) {
+		t.Errorf("expected plan = %v, got %v", expected, plan)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package datatables
+
+// ExportValue returns the value of the column written to the exports for
+// the given row, as fetched from the database. It is the output of
+// ExportFunc, or else of the RenderExport function of Renders, or else of
+// RenderFunc, or else the value of the row for the column.
+func (c Column) ExportValue(row map[string]any) any {
+	switch {
+	case c.ExportFunc != nil:
+		return c.ExportFunc(row)
+	case c.Renders[RenderExport] != nil:
+		return c.Renders[RenderExport](row)
+	case c.RenderFunc != nil:
+		return c.RenderFunc(row)
+	default:
+		return row[c.Data]
+	}
+}
+
+// ExportRows validates the DataTable, fetches the rows of the request like
+// Raw, and returns them with the export value of each column, see
+// Column.ExportValue, for exporters writing CSV or spreadsheet files. The
+// grid keeps its rich rendering while the exports get clean values.
+//
+// The rows hold the columns of the request, or else the selected or defined
+// columns, except the hidden ones. No render hook or callback is run.
+func (dt *DataTable) ExportRows() ([]map[string]any, error) {
+	if err := dt.Validate(); err != nil {
+		return nil, err
+	}
+	data, _, _, err := dt.processQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []Column
+	for _, data := range dt.arrayColumns() {
+		if col, ok := dt.columnsMap[data]; ok && !dt.isColumnHidden(data) {
+			columns = append(columns, col)
+		}
+	}
+
+	rows := data.([]map[string]any)
+	exported := make([]map[string]any, len(rows))
+	for i, row := range rows {
+		values := make(map[string]any, len(columns))
+		for _, col := range columns {
+			values[col.Data] = col.ExportValue(row)
+		}
+		exported[i] = values
+	}
+	return exported, nil
+}
@@ -0,0 +1,107 @@
+package datatables
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestColumnExportValue(t *testing.T) {
+	row := map[string]any{"name": "John Doe"}
+	tests := []struct {
+		name     string
+		column   Column
+		expected any
+	}{
+		{
+			name:     "value",
+			column:   Column{Data: "name"},
+			expected: "John Doe",
+		},
+		{
+			name: "render_func",
+			column: Column{Data: "name", RenderFunc: func(row map[string]any) any {
+				return "<b>" + row["name"].(string) + "</b>"
+			}},
+			expected: "<b>John Doe</b>",
+		},
+		{
+			name: "export_render",
+			column: Column{
+				Data:       "name",
+				RenderFunc: func(map[string]any) any { return "<b>display</b>" },
+				Renders: map[string]func(map[string]any) any{
+					RenderExport: func(map[string]any) any { return "render" },
+				},
+			},
+			expected: "render",
+		},
+		{
+			name: "export_func",
+			column: Column{
+				Data:       "name",
+				RenderFunc: func(map[string]any) any { return "<b>display</b>" },
+				Renders: map[string]func(map[string]any) any{
+					RenderExport: func(map[string]any) any { return "render" },
+				},
+				ExportFunc: func(map[string]any) any { return "export" },
+			},
+			expected: "export",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if value := tt.column.ExportValue(row); value != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, value)
+			}
+		})
+	}
+}
+
+func TestExportRows(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+	mock.ExpectQuery(qm("SELECT * FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}).AddRow(1, "John Doe", 1234))
+
+	dt := New(db).Model(&User{})
+	dt.Req(Request{
+		Draw:   1,
+		Length: -1,
+		Columns: []ColumnRequest{
+			{Name: "id", Data: "id"},
+			{Name: "name", Data: "name"},
+			{Name: "age", Data: "age"},
+		},
+	})
+	dt.AddColumns(Column{
+		Name: "age",
+		Data: "age",
+		RenderFunc: func(row map[string]any) any {
+			return fmt.Sprintf(`<span class="badge">%v</span>`, row["age"])
+		},
+		ExportFunc: func(row map[string]any) any { return fmt.Sprintf("%v years", row["age"]) },
+	}).BlacklistColumn("id")
+
+	rows, err := dt.ExportRows()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []map[string]any{{"name": "John Doe", "age": "1234 years"}}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected rows %v, got %v", expected, rows)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+
+	if _, err := New(db).ExportRows(); err == nil {
+		t.Errorf("expected validation error, got nil")
+	}
+}
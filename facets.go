@@ -0,0 +1,90 @@
+package datatables
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Facets returns, for each of the given columns, the number of rows per
+// distinct value of the column, to power filter sidebars alongside the
+// table.
+//
+// The counts are computed over the rows matching the current filters of the
+// DataTable: its filters, row policies, filter presets, the global search,
+// and the per-column search values of the request, which hold the values
+// selected in the facets. Like e-commerce facets, the column search of a
+// facet is not applied to its own counts, so that the other values of the
+// facet remain selectable. One GROUP BY query is executed per facet.
+//
+// The values are keyed by their string representation, with NULL keyed by
+// an empty string. Columns that are not defined, not allowed, or declared
+// on a relation are ignored.
+// Facets are not supported when Config.GroupBy is set.
+func (dt *DataTable) Facets(columns ...string) (map[string]map[string]int64, error) {
+	if err := dt.Validate(); err != nil {
+		return nil, err
+	}
+	if len(dt.config.GroupBy) > 0 {
+		return nil, errors.New(dt.translate(MsgGroupedFacets, "facets are not supported on grouped tables"))
+	}
+
+	dt.prepare()
+	baseQuery := dt.buildBaseQuery()
+	facets := make(map[string]map[string]int64, len(columns))
+	for _, data := range columns {
+		col, exists := dt.columnsMap[data]
+		if !exists || col.Relation != "" || !dt.isColumnAllowed(data) {
+			continue
+		}
+		counts, err := dt.facetCounts(baseQuery, col)
+		if err != nil {
+			return nil, err
+		}
+		facets[data] = counts
+	}
+	return facets, nil
+}
+
+// facetCounts counts the rows of the base query per value of the given
+// column, with the search and the column searches other than its own
+// applied.
+func (dt *DataTable) facetCounts(baseQuery *gorm.DB, col Column) (map[string]int64, error) {
+	query := dt.applySearch(baseQuery.Session(&gorm.Session{}))
+	query = dt.applyColumnSearches(query, col.Data)
+
+	var rows []map[string]any
+	err := query.
+		Select("? AS value, "+queryCount, col.sqlColumn()).
+		Clauses(clause.GroupBy{Columns: []clause.Column{col.sqlColumn()}}).
+		WithContext(dt.context()).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		count, err := toInt64(row["count"])
+		if err != nil {
+			return nil, err
+		}
+		counts[facetValue(row["value"])] += count
+	}
+	return counts, nil
+}
+
+// facetValue returns the key of a facet value: its string representation,
+// or an empty string for NULL.
+func facetValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,56 @@
+package datatables
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestFacets(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT `status` AS value, COUNT(*) AS count FROM `orders` WHERE `country` LIKE ? GROUP BY `status`")).
+		WithArgs("%FR%").
+		WillReturnRows(sqlmock.NewRows([]string{"value", "count"}).
+			AddRow("paid", 3).
+			AddRow(nil, 1))
+	mock.ExpectQuery(qm("SELECT `country` AS value, COUNT(*) AS count FROM `orders` WHERE `status` LIKE ? GROUP BY `country`")).
+		WithArgs("%paid%").
+		WillReturnRows(sqlmock.NewRows([]string{"value", "count"}).
+			AddRow([]byte("FR"), 2).
+			AddRow("DE", 1))
+
+	dt := New(db.Table("orders"))
+	dt.Req(Request{
+		Draw: 1,
+		Columns: []ColumnRequest{
+			{Name: "status", Data: "status", Searchable: true, Search: Search{Value: "paid"}},
+			{Name: "country", Data: "country", Searchable: true, Search: Search{Value: "FR"}},
+		},
+	})
+
+	facets, err := dt.Facets("status", "country", "unknown")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := map[string]map[string]int64{
+		"status":  {"paid": 3, "": 1},
+		"country": {"FR": 2, "DE": 1},
+	}
+	if !reflect.DeepEqual(facets, expected) {
+		t.Errorf("expected %v, got %v", expected, facets)
+	}
+
+	t.Run("grouped", func(t *testing.T) {
+		dt := New(db.Table("orders")).SetConfig(Config{GroupBy: []string{"status"}})
+		dt.Req(Request{Draw: 1})
+		if _, err := dt.Facets("status"); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package datatables
+
+// Response formats of the rows, selected with Config.ResponseFormat.
+const (
+	ResponseFormatObject = "object" // Each row is an object keyed by column Data (default).
+	ResponseFormatArray  = "array"  // Each row is an array ordered like the columns.
+)
+
+// arrayColumns returns the Data fields of the columns in the order of the
+// values of an array row: the order of the request columns, or else the
+// columns passed to Only, or else the defined columns.
+func (dt *DataTable) arrayColumns() []string {
+	var columns []string
+	switch {
+	case len(dt.req.Columns) > 0:
+		for _, col := range dt.req.Columns {
+			columns = append(columns, col.Data)
+		}
+	case len(dt.selectedColumns) > 0:
+		columns = append(columns, dt.selectedColumns...)
+	default:
+		for _, col := range dt.columns {
+			columns = append(columns, col.Data)
+		}
+	}
+	return columns
+}
+
+// toArrays converts the rows to positional arrays ordered like arrayColumns.
+// The value of a column that is hidden or missing from a row is nil, so the
+// positions always match the columns.
+func (dt *DataTable) toArrays(data []map[string]any) [][]any {
+	columns := dt.arrayColumns()
+	rows := make([][]any, len(data))
+	for i, row := range data {
+		values := make([]any, len(columns))
+		for j, data := range columns {
+			if dt.isColumnHidden(data) {
+				continue
+			}
+			values[j] = row[data]
+		}
+		rows[i] = values
+	}
+	return rows
+}
+
+// shapeData returns the rows in the form sent in the response: converted to
+// arrays when the response format is ResponseFormatArray and no transformer
+// is set, or else with the dot-notation columns nested and transformed by the
+// transformer, if any, or converted to OrderedRow values when
+// Config.PreserveColumnOrder is set.
+func (dt *DataTable) shapeData(data []map[string]any) any {
+	if dt.transformer == nil && dt.config.ResponseFormat == ResponseFormatArray {
+		return dt.toArrays(data)
+	}
+	dt.applyNestedColumns(data)
+	if dt.transformer == nil && dt.config.PreserveColumnOrder {
+		return dt.toOrderedRows(data)
+	}
+	return dt.applyTransformer(data)
+}
@@ -0,0 +1,79 @@
+package datatables
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestToArrays(t *testing.T) {
+	data := []map[string]any{{"id": 1, "name": "John", "token": "x"}}
+
+	tests := []struct {
+		name     string
+		setup    func(*DataTable)
+		expected [][]any
+	}{
+		{
+			name:     "defined_columns",
+			setup:    func(dt *DataTable) { dt.AddColumns(Column{Data: "name"}, Column{Data: "id"}) },
+			expected: [][]any{{"John", 1}},
+		},
+		{
+			name:     "selected_columns",
+			setup:    func(dt *DataTable) { dt.AddColumns(Column{Data: "name"}, Column{Data: "id"}).Only("id") },
+			expected: [][]any{{1}},
+		},
+		{
+			name: "request_columns_keep_hidden_positions",
+			setup: func(dt *DataTable) {
+				dt.Req(Request{Columns: []ColumnRequest{{Data: "token"}, {Data: "id"}, {Data: "missing"}}})
+				dt.Except("token")
+			},
+			expected: [][]any{{nil, 1, nil}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dt := New(nil)
+			tt.setup(dt)
+			if actual := dt.toArrays(data); !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMakeArrayFormat(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(2)))
+	mock.ExpectQuery(qm("SELECT * FROM `users` LIMIT ?")).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John").AddRow(2, "Jane"))
+
+	cfg := defaultConfig()
+	cfg.ResponseFormat = ResponseFormatArray
+	dt := New(db, WithConfig(cfg)).Model(&User{})
+	dt.WithNumber()
+	dt.AddColumn(Column{Name: "name", Data: "name", RenderFunc: func(row map[string]any) any {
+		return "Mr. " + row["name"].(string)
+	}})
+	dt.Req(Request{Draw: 1, Length: 10})
+
+	res, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := [][]any{{1, "Mr. John"}, {2, "Mr. Jane"}}
+	if !reflect.DeepEqual(res["data"], expected) {
+		t.Errorf("expected %v, got %v", expected, res["data"])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package datatables
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+)
+
+// Handler returns an http.Handler serving a table: the DataTables request
+// is parsed with ParseRequest, the DataTable answering it is returned by
+// build, which must pass the request to Req, and the response of Make is
+// written as JSON.
+//
+// When the DataTable has a data version (see WithVersion), the response
+// carries an ETag computed from the request and the data version, and a
+// request whose If-None-Match header matches it is answered with 304 Not
+// Modified without running the draw, so periodic redraws of unchanged data
+// don't resend the payload. The draw counter is not part of the ETag.
+//
+// Parsing errors are answered with 400 Bad Request and other errors with
+// 500 Internal Server Error, both with the error message under the "error"
+// key.
+func Handler(build func(r *http.Request, req Request) (*DataTable, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, err := ParseRequest(r)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]any{"error": err.Error()})
+			return
+		}
+
+		dt, err := build(r, *req)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]any{"draw": req.Draw, "error": err.Error()})
+			return
+		}
+
+		if dt.version != nil {
+			_, version, err := dt.HasChanges("")
+			if err != nil {
+				writeJSON(w, http.StatusInternalServerError, map[string]any{"draw": req.Draw, "error": err.Error()})
+				return
+			}
+			etag := requestETag(*req, version)
+			w.Header().Set("ETag", etag)
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			dt.version.current = version
+		}
+
+		response, err := dt.Make()
+		if dt.version != nil {
+			dt.version.current = ""
+		}
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]any{"draw": req.Draw, "error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, response)
+	})
+}
+
+// requestETag returns the ETag of the response to the given request for
+// the given data version. It is computed from the canonical form of the
+// request, see Canonicalize, which leaves out the draw counter, since it
+// changes on every redraw.
+func requestETag(req Request, version string) string {
+	sum := sha256.Sum256(append(canonicalBytes(req), version...))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// writeJSON writes the given value as a JSON response with the given status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
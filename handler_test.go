@@ -0,0 +1,104 @@
+package datatables
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestHandler(t *testing.T) {
+	query := url.Values{
+		"draw": {"1"}, "start": {"0"}, "length": {"10"}, "search[regex]": {"false"},
+		"columns[0][data]": {"name"}, "columns[0][name]": {"name"},
+	}
+
+	db, mock := newMockDB(t)
+	handler := Handler(func(r *http.Request, req Request) (*DataTable, error) {
+		return New(db).Model(&User{}).WithVersion("updated_at").Req(req), nil
+	})
+
+	// First draw: the version query runs once and the data is sent.
+	mock.ExpectQuery(qm("SELECT COUNT(*), MAX(`updated_at`) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "max"}).AddRow(1, "2026-01-02 10:00:00"))
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(qm("SELECT * FROM `users` LIMIT ?")).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users?"+query.Encode(), nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body)
+	}
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected an ETag")
+	}
+	var response map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response["recordsTotal"] != float64(1) || response[versionKey] == nil {
+		t.Errorf("unexpected response %v", response)
+	}
+
+	// Redraw of unchanged data with a new draw counter: only the version
+	// query runs.
+	mock.ExpectQuery(qm("SELECT COUNT(*), MAX(`updated_at`) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "max"}).AddRow(1, "2026-01-02 10:00:00"))
+
+	query.Set("draw", "2")
+	req := httptest.NewRequest(http.MethodGet, "/users?"+query.Encode(), nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("expected status 304, got %d", rec.Code)
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("expected an empty body, got %s", rec.Body)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestHandlerErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		build  func(*http.Request, Request) (*DataTable, error)
+		status int
+	}{
+		{name: "invalid request", query: "draw=x", status: http.StatusBadRequest},
+		{
+			name:  "build error",
+			query: "draw=1&start=0&search[regex]=false",
+			build: func(*http.Request, Request) (*DataTable, error) {
+				return nil, errors.New("boom")
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			Handler(tt.build).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users?"+tt.query, nil))
+			if rec.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, rec.Code)
+			}
+			var response map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil || response["error"] == nil {
+				t.Errorf("expected an error message, got %s", rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package datatables
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// HeavyColumns marks the columns holding large values, such as documents or
+// images, so that they are left out of the data query while hidden on the
+// client when Config.SkipHiddenHeavy is set. The TEXT and BLOB fields of the
+// model, and its []byte fields, are detected as heavy without being marked.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) HeavyColumns(data ...string) *DataTable {
+	dt.plans = nil
+	if dt.heavyColumns == nil {
+		dt.heavyColumns = make(map[string]bool)
+	}
+	for _, d := range data {
+		dt.heavyColumns[d] = true
+	}
+	return dt
+}
+
+// isHeavyField reports whether the model field holds TEXT or BLOB values.
+func isHeavyField(field *schema.Field) bool {
+	if field.DataType == schema.Bytes {
+		return true
+	}
+	dataType := strings.ToLower(string(field.DataType))
+	return strings.Contains(dataType, "text") || strings.Contains(dataType, "blob")
+}
+
+// skippedHeavyColumns returns the heavy columns hidden on the client, which
+// are left out of the data query when Config.SkipHiddenHeavy is set, or nil.
+func (dt *DataTable) skippedHeavyColumns() map[string]bool {
+	if !dt.config.SkipHiddenHeavy {
+		return nil
+	}
+
+	var heavyFields map[string]bool
+	if s := dt.modelSchema(); s != nil {
+		heavyFields = make(map[string]bool)
+		for _, field := range s.Fields {
+			if field.DBName != "" && isHeavyField(field) {
+				heavyFields[field.DBName] = true
+			}
+		}
+	}
+
+	var skipped map[string]bool
+	for _, col := range dt.req.Columns {
+		if !col.Hidden || !(dt.heavyColumns[col.Data] || heavyFields[col.Data]) {
+			continue
+		}
+		if skipped == nil {
+			skipped = make(map[string]bool)
+		}
+		skipped[col.Data] = true
+	}
+	return skipped
+}
+
+// applyModelProjection replaces the SELECT * of the data query with the
+// fields of the model, leaving out the skipped columns. The query is
+// returned unchanged if no column is skipped or the model is not a struct.
+func (dt *DataTable) applyModelProjection(query *gorm.DB, skipped map[string]bool) *gorm.DB {
+	if len(skipped) == 0 {
+		return query
+	}
+	s := dt.modelSchema()
+	if s == nil {
+		return query
+	}
+
+	var exprs []clause.Expression
+	for _, field := range s.Fields {
+		if field.DBName == "" || skipped[field.DBName] {
+			continue
+		}
+		exprs = append(exprs, clause.Expr{
+			SQL:  "?",
+			Vars: []any{clause.Column{Table: clause.CurrentTable, Name: field.DBName}},
+		})
+	}
+	return query.Clauses(clause.Select{
+		Expression: clause.CommaExpression{Exprs: exprs},
+	})
+}
@@ -0,0 +1,64 @@
+package datatables
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+type Article struct {
+	ID      int
+	Title   string
+	Body    string `gorm:"type:text"`
+	Cover   []byte
+	Summary string
+}
+
+func TestSkipHiddenHeavy(t *testing.T) {
+	tests := []struct {
+		name    string
+		project bool
+		hidden  bool
+		selects string
+	}{
+		{"visible", false, false, "*"},
+		{"hidden", false, true, "`articles`.`id`, `articles`.`title`, `articles`.`summary`"},
+		{"hidden_projection", true, true, "`id`, `title`, `summary`"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+
+			mock.ExpectQuery(qm("SELECT count(*) FROM `articles`")).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+			mock.ExpectQuery(qm("SELECT " + tt.selects + " FROM `articles` LIMIT ?")).
+				WithArgs(10).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "Hello"))
+
+			cfg := defaultConfig()
+			cfg.SkipHiddenHeavy = true
+			cfg.ProjectColumns = tt.project
+			dt := New(db, WithConfig(cfg)).Model(&Article{}).
+				ColumnsFromModel().
+				HeavyColumns("summary").
+				Req(Request{
+					Draw:   1,
+					Length: 10,
+					Columns: []ColumnRequest{
+						{Data: "id", Name: "id"},
+						{Data: "title", Name: "title"},
+						{Data: "body", Name: "body", Hidden: tt.hidden},
+						{Data: "cover", Name: "cover", Hidden: tt.hidden},
+						{Data: "summary", Name: "summary"},
+					},
+				})
+
+			if _, err := dt.Make(); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package datatables
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// validateHints returns an error if the query comment or an optimizer hint
+// of the config contains a comment delimiter, which would let it escape the
+// comment it is written into.
+func validateHints(cfg Config) error {
+	for _, text := range append([]string{cfg.QueryComment}, cfg.QueryHints...) {
+		if strings.Contains(text, "/*") || strings.Contains(text, "*/") {
+			return fmt.Errorf("invalid query comment or hint %q", text)
+		}
+	}
+	return nil
+}
+
+// queryHints attaches the query comment and the optimizer hints of the
+// config to the SELECT statement of a query.
+type queryHints struct {
+	comment string
+	hints   []string
+}
+
+// Build implements clause.Expression. The hints are written by
+// ModifyStatement, so nothing is built in place.
+func (queryHints) Build(clause.Builder) {}
+
+// ModifyStatement adds the comment before the SELECT statement and the hints
+// in a /*+ ... */ block: right after the SELECT keyword on MySQL, and before
+// the statement elsewhere, as expected by pg_hint_plan on Postgres.
+func (h queryHints) ModifyStatement(stmt *gorm.Statement) {
+	var before []string
+	c := stmt.Clauses[querySelect]
+	if len(h.hints) > 0 {
+		block := "/*+ " + strings.Join(h.hints, " ") + " */"
+		if stmt.Dialector.Name() == "mysql" {
+			c.AfterNameExpression = clause.Expr{SQL: block}
+		} else {
+			before = append(before, block)
+		}
+	}
+	if h.comment != "" {
+		before = append(before, "/* "+h.comment+" */")
+	}
+	if len(before) > 0 {
+		c.BeforeExpression = clause.Expr{SQL: strings.Join(before, " ")}
+	}
+	stmt.Clauses[querySelect] = c
+}
+
+// applyQueryHints attaches Config.QueryComment and Config.QueryHints to the
+// query. Returns the updated query.
+func (dt *DataTable) applyQueryHints(query *gorm.DB) *gorm.DB {
+	if dt.config.QueryComment == "" && len(dt.config.QueryHints) == 0 {
+		return query
+	}
+	return query.Clauses(queryHints{comment: dt.config.QueryComment, hints: dt.config.QueryHints})
+}
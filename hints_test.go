@@ -0,0 +1,68 @@
+package datatables
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestQueryHints(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect string
+		comment string
+		hints   []string
+		sql     string
+	}{
+		{name: "mysql hints", dialect: "mysql", hints: []string{"MAX_EXECUTION_TIME(1000)"}, sql: "SELECT /*+ MAX_EXECUTION_TIME(1000) */ count(*) FROM `users`"},
+		{name: "postgres hints", dialect: "postgres", hints: []string{"SeqScan(users)"}, sql: "/*+ SeqScan(users) */ SELECT count(*) FROM `users`"},
+		{name: "comment", dialect: "mysql", comment: "users table", sql: "/* users table */ SELECT count(*) FROM `users`"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDBWithDialect(t, tt.dialect)
+
+			mock.ExpectQuery(qm(tt.sql)).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(10)))
+
+			cfg := defaultConfig()
+			cfg.QueryComment = tt.comment
+			cfg.QueryHints = tt.hints
+			dt := New(db, WithConfig(cfg)).Model(&User{})
+			dt.Req(Request{Draw: 1})
+
+			if _, _, err := dt.Counts(); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateHints(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		hints   []string
+		wantErr bool
+	}{
+		{name: "empty"},
+		{name: "valid", comment: "users table", hints: []string{"MAX_EXECUTION_TIME(1000)"}},
+		{name: "comment escape", comment: "x */ DROP TABLE users; /*", wantErr: true},
+		{name: "hint escape", hints: []string{"*/ DROP TABLE users"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := defaultConfig()
+			cfg.QueryComment = tt.comment
+			cfg.QueryHints = tt.hints
+			if err := validateHints(cfg); (err != nil) != tt.wantErr {
+				t.Errorf("validateHints() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package datatables
+
+import (
+	"errors"
+	"maps"
+
+	"gorm.io/gorm"
+)
+
+// BeforeQuery registers a hook that is applied to the data query right
+// before it is executed, after the search, ordering, and pagination have been
+// applied. The hook receives the query and returns the query to execute.
+//
+// Unlike Filter, the hook does not affect the total and filtered counts.
+// Hooks are applied in the order they were registered.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) BeforeQuery(hook func(*gorm.DB) *gorm.DB) *DataTable {
+	dt.beforeQuery = append(dt.beforeQuery, hook)
+	return dt
+}
+
+// AfterQuery registers a hook that is called once the counts and the data
+// rows have been retrieved. The hook can inspect or modify the rows in place.
+// If the hook returns an error, processing stops and the error is returned.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) AfterQuery(hook func(total, filtered int64, data []map[string]any) error) *DataTable {
+	dt.afterQuery = append(dt.afterQuery, hook)
+	return dt
+}
+
+// BeforeRender registers a hook that is called with the fetched rows before
+// the render functions, custom columns, and row attributes are applied. If
+// the hook returns an error, processing stops and the error is returned.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) BeforeRender(hook func(data []map[string]any) error) *DataTable {
+	dt.beforeRender = append(dt.beforeRender, hook)
+	return dt
+}
+
+// AfterRender registers a hook that is called with the rendered rows right
+// before the response is assembled. The hook can post-process the rows in
+// place. If the hook returns an error, processing stops and the error is
+// returned.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) AfterRender(hook func(data []map[string]any) error) *DataTable {
+	dt.afterRender = append(dt.afterRender, hook)
+	return dt
+}
+
+// SetFilteredQuery sets a function that rewrites the filtered query once the
+// search and the grouping have been applied, for instance to add joins or
+// force an index. The filtered query is the base of both the filtered count
+// and the data query, so the function affects both, but not the total
+// count. Setting it again replaces the previous function.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) SetFilteredQuery(fn func(*gorm.DB) *gorm.DB) *DataTable {
+	dt.filteredQuery = fn
+	return dt
+}
+
+// applyBeforeQuery applies the BeforeQuery hooks to the query. Returns the
+// updated query.
+func (dt *DataTable) applyBeforeQuery(query *gorm.DB) *gorm.DB {
+	for _, hook := range dt.beforeQuery {
+		query = hook(query)
+	}
+	return query
+}
+
+// runAfterQuery calls the AfterQuery hooks and returns the first error.
+func (dt *DataTable) runAfterQuery(total, filtered int64, data []map[string]any) error {
+	for _, hook := range dt.afterQuery {
+		if err := hook(total, filtered, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runRenderHooks calls the given render hooks and returns the first error.
+func runRenderHooks(hooks []func([]map[string]any) error, data []map[string]any) error {
+	for _, hook := range hooks {
+		if err := hook(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OnRow registers a callback that is called once for every fetched row,
+// after the BeforeRender hooks and before the render functions are applied.
+// The callback receives the index of the row in the current page and the row
+// itself, which it can enrich in place. It is a simpler alternative to custom
+// columns for per-row enrichment. If the callback returns an error,
+// processing stops and the error is returned.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) OnRow(callback func(i int, row map[string]any) error) *DataTable {
+	dt.onRow = append(dt.onRow, callback)
+	return dt
+}
+
+// runOnRow calls the OnRow callbacks for every row of the data and returns
+// the first error.
+func (dt *DataTable) runOnRow(data []map[string]any) error {
+	for _, callback := range dt.onRow {
+		for i, row := range data {
+			if err := callback(i, row); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// BatchRender registers a hook that is called once with the whole page of
+// rows, after the OnRow callbacks and before the render functions are
+// applied, so that values that would otherwise be looked up row by row, in
+// render functions, can be fetched with a single query, such as an IN query
+// on the ids of the page.
+//
+// The hook returns one map of values per row, in the order of the rows,
+// which are merged into the rows; a nil map leaves its row unchanged. The
+// render functions see the merged values. If the hook returns an error, or
+// not one map per row, processing stops and an error is returned.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) BatchRender(hook func(data []map[string]any) ([]map[string]any, error)) *DataTable {
+	dt.batchRender = append(dt.batchRender, hook)
+	return dt
+}
+
+// runBatchRender calls the BatchRender hooks and merges the returned values
+// into the rows. It returns the first error.
+func (dt *DataTable) runBatchRender(data []map[string]any) error {
+	for _, hook := range dt.batchRender {
+		values, err := hook(data)
+		if err != nil {
+			return err
+		}
+		if len(values) != len(data) {
+			return errors.New(dt.translatef(MsgBatchRender, "batch render returned %d values for %d rows", len(values), len(data)))
+		}
+		for i, row := range data {
+			maps.Copy(row, values[i])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,312 @@
+package datatables
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+func TestHookRegistration(t *testing.T) {
+	dt := New(nil)
+	dt.BeforeQuery(func(db *gorm.DB) *gorm.DB { return db })
+	dt.AfterQuery(func(total, filtered int64, data []map[string]any) error { return nil })
+	dt.BeforeRender(func(data []map[string]any) error { return nil })
+	dt.AfterRender(func(data []map[string]any) error { return nil })
+
+	if len(dt.beforeQuery) != 1 || len(dt.afterQuery) != 1 || len(dt.beforeRender) != 1 || len(dt.afterRender) != 1 {
+		t.Errorf("expected one hook of each kind, got %d, %d, %d, %d",
+			len(dt.beforeQuery), len(dt.afterQuery), len(dt.beforeRender), len(dt.afterRender))
+	}
+}
+
+func TestMakeWithHooks(t *testing.T) {
+	newDataTable := func(t *testing.T) (*DataTable, sqlmock.Sqlmock) {
+		db, mock := newMockDB(t)
+
+		mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+		dt := New(db)
+		dt.Model(&User{})
+		dt.Req(Request{
+			Draw:   1,
+			Length: 10,
+			Columns: []ColumnRequest{
+				{Name: "id", Data: "id"},
+				{Name: "name", Data: "name"},
+			},
+		})
+		return dt, mock
+	}
+
+	t.Run("all_hooks", func(t *testing.T) {
+		dt, mock := newDataTable(t)
+		mock.ExpectQuery(qm("SELECT * FROM `users` WHERE deleted_at IS NULL LIMIT ?")).
+			WithArgs(10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+
+		var calls []string
+		dt.BeforeQuery(func(db *gorm.DB) *gorm.DB {
+			calls = append(calls, "before_query")
+			return db.Where("deleted_at IS NULL")
+		})
+		dt.AfterQuery(func(total, filtered int64, data []map[string]any) error {
+			calls = append(calls, "after_query")
+			if total != 2 || filtered != 2 || len(data) != 1 {
+				t.Errorf("unexpected counts in AfterQuery: %d, %d, %d", total, filtered, len(data))
+			}
+			return nil
+		})
+		dt.EditColumn("name", func(v any) any {
+			return "Rendered_" + v.(string)
+		})
+		dt.BeforeRender(func(data []map[string]any) error {
+			calls = append(calls, "before_render")
+			if data[0]["name"] != "John Doe" {
+				t.Errorf("expected raw value before render, got %v", data[0]["name"])
+			}
+			return nil
+		})
+		dt.AfterRender(func(data []map[string]any) error {
+			calls = append(calls, "after_render")
+			data[0]["extra"] = true
+			return nil
+		})
+
+		response, err := dt.Make()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		expectedCalls := []string{"before_query", "after_query", "before_render", "after_render"}
+		if len(calls) != len(expectedCalls) {
+			t.Fatalf("expected calls %v, got %v", expectedCalls, calls)
+		}
+		for i := range expectedCalls {
+			if calls[i] != expectedCalls[i] {
+				t.Errorf("expected call %q, got %q", expectedCalls[i], calls[i])
+			}
+		}
+
+		row := response["data"].([]map[string]any)[0]
+		if row["name"] != "Rendered_John Doe" || row["extra"] != true {
+			t.Errorf("unexpected row %v", row)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet expectations: %v", err)
+		}
+	})
+
+	t.Run("after_query_error", func(t *testing.T) {
+		dt, mock := newDataTable(t)
+		mock.ExpectQuery(qm("SELECT * FROM `users` LIMIT ?")).
+			WithArgs(10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+
+		hookErr := errors.New("after query failed")
+		dt.AfterQuery(func(total, filtered int64, data []map[string]any) error { return hookErr })
+
+		if _, err := dt.Make(); !errors.Is(err, hookErr) {
+			t.Errorf("expected error %v, got %v", hookErr, err)
+		}
+	})
+
+	t.Run("before_render_error", func(t *testing.T) {
+		dt, mock := newDataTable(t)
+		mock.ExpectQuery(qm("SELECT * FROM `users` LIMIT ?")).
+			WithArgs(10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+
+		hookErr := errors.New("before render failed")
+		dt.BeforeRender(func(data []map[string]any) error { return hookErr })
+
+		if _, err := dt.Make(); !errors.Is(err, hookErr) {
+			t.Errorf("expected error %v, got %v", hookErr, err)
+		}
+	})
+
+	t.Run("after_render_error", func(t *testing.T) {
+		dt, mock := newDataTable(t)
+		mock.ExpectQuery(qm("SELECT * FROM `users` LIMIT ?")).
+			WithArgs(10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+
+		hookErr := errors.New("after render failed")
+		dt.AfterRender(func(data []map[string]any) error { return hookErr })
+
+		if _, err := dt.Make(); !errors.Is(err, hookErr) {
+			t.Errorf("expected error %v, got %v", hookErr, err)
+		}
+	})
+}
+
+func TestRunOnRow(t *testing.T) {
+	t.Run("enrich_rows", func(t *testing.T) {
+		dt := New(nil)
+		dt.OnRow(func(i int, row map[string]any) error {
+			row["index"] = i
+			return nil
+		})
+
+		data := []map[string]any{{"id": 1}, {"id": 2}}
+		if err := dt.runOnRow(data); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		for i, row := range data {
+			if row["index"] != i {
+				t.Errorf("expected index %d, got %v", i, row["index"])
+			}
+		}
+	})
+
+	t.Run("abort_on_error", func(t *testing.T) {
+		dt := New(nil)
+		rowErr := errors.New("row failed")
+		calls := 0
+		dt.OnRow(func(i int, row map[string]any) error {
+			calls++
+			if i == 0 {
+				return rowErr
+			}
+			return nil
+		})
+
+		data := []map[string]any{{"id": 1}, {"id": 2}}
+		if err := dt.runOnRow(data); !errors.Is(err, rowErr) {
+			t.Errorf("expected error %v, got %v", rowErr, err)
+		}
+		if calls != 1 {
+			t.Errorf("expected processing to stop after the first row, got %d calls", calls)
+		}
+	})
+}
+
+func TestRunBatchRender(t *testing.T) {
+	t.Run("merge_values", func(t *testing.T) {
+		dt := New(nil)
+		calls := 0
+		dt.BatchRender(func(data []map[string]any) ([]map[string]any, error) {
+			calls++
+			values := make([]map[string]any, len(data))
+			for i, row := range data {
+				if row["owner_id"] != nil {
+					values[i] = map[string]any{"owner": fmt.Sprintf("owner %v", row["owner_id"])}
+				}
+			}
+			return values, nil
+		})
+		dt.AddColumn(Column{Data: "owner", RenderFunc: func(row map[string]any) any {
+			return "<b>" + row["owner"].(string) + "</b>"
+		}})
+
+		data := []map[string]any{{"id": 1, "owner_id": 7}, {"id": 2}}
+		if err := dt.runBatchRender(data); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("expected one call for the page, got %d", calls)
+		}
+		if data[0]["owner"] != "owner 7" {
+			t.Errorf("expected the batch value to be merged, got %v", data[0])
+		}
+		if _, ok := data[1]["owner"]; ok {
+			t.Errorf("expected the row without value to be unchanged, got %v", data[1])
+		}
+	})
+
+	t.Run("value_count_mismatch", func(t *testing.T) {
+		dt := New(nil)
+		dt.BatchRender(func(data []map[string]any) ([]map[string]any, error) {
+			return nil, nil
+		})
+		if err := dt.runBatchRender([]map[string]any{{"id": 1}}); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+
+	t.Run("abort_on_error", func(t *testing.T) {
+		dt := New(nil)
+		batchErr := errors.New("lookup failed")
+		dt.BatchRender(func(data []map[string]any) ([]map[string]any, error) {
+			return nil, batchErr
+		})
+		if err := dt.runBatchRender([]map[string]any{{"id": 1}}); !errors.Is(err, batchErr) {
+			t.Errorf("expected error %v, got %v", batchErr, err)
+		}
+	})
+}
+
+func TestMakeWithBatchRender(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(qm("SELECT * FROM `users` LIMIT ?")).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John").AddRow(2, "Jane"))
+
+	dt := New(db).Model(&User{})
+	dt.Req(Request{
+		Draw:    1,
+		Length:  10,
+		Columns: []ColumnRequest{{Name: "id", Data: "id"}, {Name: "name", Data: "name"}},
+	})
+	dt.AddColumn(Column{Data: "posts", RenderFunc: func(row map[string]any) any {
+		return fmt.Sprintf("%v posts", row["posts"])
+	}})
+	dt.BatchRender(func(data []map[string]any) ([]map[string]any, error) {
+		posts := map[int]int{1: 3, 2: 5}
+		values := make([]map[string]any, len(data))
+		for i, row := range data {
+			values[i] = map[string]any{"posts": posts[row["id"].(int)]}
+		}
+		return values, nil
+	})
+
+	res, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	data := res["data"].([]map[string]any)
+	if data[0]["posts"] != "3 posts" || data[1]["posts"] != "5 posts" {
+		t.Errorf("expected the batch values to be rendered, got %v", data)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestSetFilteredQuery(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(10)))
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` JOIN profiles ON profiles.user_id = users.id WHERE `name` LIKE ?")).
+		WithArgs("%John%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+	mock.ExpectQuery(qm("SELECT `users`.`id`,`users`.`name` FROM `users` JOIN profiles ON profiles.user_id = users.id WHERE `name` LIKE ? LIMIT ?")).
+		WithArgs("%John%", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John"))
+
+	dt := New(db).Model(&User{})
+	dt.SetFilteredQuery(func(query *gorm.DB) *gorm.DB {
+		return query.Joins("JOIN profiles ON profiles.user_id = users.id")
+	})
+	dt.Req(Request{
+		Draw:    1,
+		Length:  10,
+		Search:  Search{Value: "John"},
+		Columns: []ColumnRequest{{Name: "name", Data: "name", Searchable: true}},
+	})
+
+	if _, err := dt.Make(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
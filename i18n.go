@@ -0,0 +1,155 @@
+package datatables
+
+import "fmt"
+
+// Message keys looked up in the Translator. Applications provide
+// translations for these keys in their message catalog.
+const (
+	MsgNoTxOrModel        = "datatables.error.no_tx_or_model"
+	MsgStatementRequired  = "datatables.error.statement_required"
+	MsgModelRequired      = "datatables.error.model_required"
+	MsgInvalidRequest     = "datatables.error.invalid_request"
+	MsgInvalidRegex       = "datatables.error.invalid_regex"
+	MsgUnsupportedDialect = "datatables.error.unsupported_dialect"
+	MsgUnsupportedSummary = "datatables.error.unsupported_summary"
+	MsgComputedSummary    = "datatables.error.computed_summary"
+	MsgSignedFilterKey    = "datatables.error.signed_filter_key"
+	MsgInvalidCursor      = "datatables.error.invalid_cursor"
+	MsgUnknownTimeColumn  = "datatables.error.unknown_time_column"
+	MsgNoRecordingStore   = "datatables.error.no_recording_store"
+	MsgDetailModel        = "datatables.error.detail_model"
+	MsgNoShards           = "datatables.error.no_shards"
+	MsgShardedCursor      = "datatables.error.sharded_cursor"
+	MsgApplyToSearch      = "datatables.error.apply_to_search"
+	MsgApplyToMasks       = "datatables.error.apply_to_masks"
+	MsgUnknownPlugin      = "datatables.error.unknown_plugin"
+	MsgUnknownProfile     = "datatables.error.unknown_profile"
+	MsgModelNotStruct     = "datatables.error.model_not_struct"
+	MsgBatchRender        = "datatables.error.batch_render"
+	MsgUnknownColumns     = "datatables.error.unknown_columns"
+	MsgUnknownGroupBy     = "datatables.error.unknown_group_by"
+	MsgUnknownFilter      = "datatables.error.unknown_filter"
+	MsgInvalidLength      = "datatables.error.invalid_length"
+	MsgGroupedFacets      = "datatables.error.grouped_facets"
+	MsgMemorySearchLimit  = "datatables.error.memory_search_limit"
+	MsgRelationModel      = "datatables.error.relation_model"
+	MsgUnknownRelation    = "datatables.error.unknown_relation"
+	MsgManyToMany         = "datatables.error.many_to_many"
+	MsgUnionRequired      = "datatables.error.union_required"
+	MsgUnionQuery         = "datatables.error.union_query"
+	MsgUnionColumn        = "datatables.error.union_column"
+	MsgNumberColumnLabel  = "datatables.column.no"
+	MsgColumnLabelPrefix  = "datatables.column."
+)
+
+// Translator is a message catalog used to localize the labels and messages
+// generated by the DataTable, such as column labels, error messages, and
+// boolean or enum value mappings.
+//
+// Translate returns the translation of the given key, formatted with args
+// when the translation contains verbs. It returns an empty string when the
+// key is unknown, in which case the DataTable falls back to its English
+// default.
+type Translator interface {
+	Translate(key string, args ...any) string
+}
+
+// TranslatorFunc is an adapter that allows the use of an ordinary function
+// as a Translator.
+type TranslatorFunc func(key string, args ...any) string
+
+// Translate calls f(key, args...).
+func (f TranslatorFunc) Translate(key string, args ...any) string {
+	return f(key, args...)
+}
+
+// SetTranslator sets the message catalog used by the DataTable.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) SetTranslator(translator Translator) *DataTable {
+	dt.translator = translator
+	return dt
+}
+
+// translate returns the translation of key, or fallback if no translator is
+// set or the key is unknown.
+func (dt *DataTable) translate(key, fallback string) string {
+	if dt.translator != nil {
+		if msg := dt.translator.Translate(key); msg != "" {
+			return msg
+		}
+	}
+	return fallback
+}
+
+// translatef is like translate but passes args to the translator and
+// formats the fallback with them.
+func (dt *DataTable) translatef(key, format string, args ...any) string {
+	return translatef(dt.translator, key, format, args...)
+}
+
+// translatef returns the translation of key with args by the translator, or
+// the fallback format formatted with args if the translator is nil or the
+// key is unknown.
+func translatef(translator Translator, key, format string, args ...any) string {
+	if translator != nil {
+		if msg := translator.Translate(key, args...); msg != "" {
+			return msg
+		}
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Label returns the localized label of the column with the given Data field.
+//
+// The label is looked up under "datatables.column.<data>" and falls back to
+// the column Label, then to the column Name, or to the Data field when the
+// column has neither. It is used for generated column labels, the header of
+// the Builder, and export headers.
+func (dt *DataTable) Label(data string) string {
+	fallback := data
+	if col, exists := dt.columnsMap[data]; exists {
+		switch {
+		case col.Label != "":
+			fallback = col.Label
+		case col.Name != "":
+			fallback = col.Name
+		}
+	}
+	return dt.translate(MsgColumnLabelPrefix+data, fallback)
+}
+
+// TranslateValues localizes the values of the column with the given Data
+// field, which is useful for boolean and enum columns.
+//
+// Each value is rendered as the translation of "<keyPrefix>.<value>" (for
+// instance "status.active" or "verified.true"). Values without translation
+// are left unchanged. The mapping is applied on top of any existing render
+// function of the column. If the column does not exist, the function does
+// nothing.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) TranslateValues(data, keyPrefix string) *DataTable {
+	dt.plans = nil
+	col, exists := dt.columnsMap[data]
+	if !exists {
+		return dt
+	}
+
+	render := col.RenderFunc
+	col.RenderFunc = func(row map[string]any) any {
+		value := row[col.Data]
+		if render != nil {
+			value = render(row)
+		}
+		if value == nil || dt.translator == nil {
+			return value
+		}
+		if msg := dt.translator.Translate(fmt.Sprintf("%s.%v", keyPrefix, value)); msg != "" {
+			return msg
+		}
+		return value
+	}
+	dt.columnsMap[data] = col
+	return dt
+}
@@ -0,0 +1,250 @@
+package datatables
+
+import (
+	"fmt"
+	"testing"
+)
+
+var catalog = TranslatorFunc(func(key string, args ...any) string {
+	messages := map[string]string{
+		MsgModelRequired:         "modelo requerido",
+		MsgNumberColumnLabel:     "Nº",
+		MsgUnsupportedDialect:    "EXPLAIN no soportado para %s",
+		MsgUnknownFilter:         "filtro desconocido %q",
+		MsgInvalidLength:         "longitud %d no válida, permitidas: %v",
+		MsgGroupedFacets:         "facetas no soportadas en tablas agrupadas",
+		MsgInvalidCursor:         "cursor no válido",
+		MsgUnknownTimeColumn:     "columna de tiempo desconocida %q",
+		MsgNoRecordingStore:      "sin almacén de grabaciones",
+		MsgDetailModel:           "el detalle requiere un modelo con clave primaria",
+		MsgNoShards:              "sin fragmentos",
+		MsgApplyToMasks:          "columnas enmascaradas no soportadas por ApplyTo",
+		MsgUnknownPlugin:         "plugin desconocido %q",
+		MsgUnknownProfile:        "perfil de columnas desconocido %q",
+		MsgModelNotStruct:        "el modelo no es una estructura",
+		MsgBatchRender:           "%d valores para %d filas",
+		"datatables.column.name": "Nombre",
+		"status.active":          "Activo",
+		"verified.true":          "Sí",
+	}
+	if msg, ok := messages[key]; ok {
+		if len(args) > 0 {
+			return fmt.Sprintf(msg, args...)
+		}
+		return msg
+	}
+	return ""
+})
+
+func TestTranslate(t *testing.T) {
+	dt := New(nil)
+	if got := dt.translate(MsgModelRequired, "model is required"); got != "model is required" {
+		t.Errorf("expected fallback without translator, got %q", got)
+	}
+	if got := dt.translatef(MsgUnsupportedDialect, "unsupported %s", "mssql"); got != "unsupported mssql" {
+		t.Errorf("expected formatted fallback without translator, got %q", got)
+	}
+
+	dt.SetTranslator(catalog)
+	if got := dt.translate(MsgModelRequired, "model is required"); got != "modelo requerido" {
+		t.Errorf("expected translation, got %q", got)
+	}
+	if got := dt.translatef(MsgUnsupportedDialect, "unsupported %s", "mssql"); got != "EXPLAIN no soportado para mssql" {
+		t.Errorf("expected formatted translation, got %q", got)
+	}
+	if got := dt.translate(MsgInvalidRequest, "invalid request"); got != "invalid request" {
+		t.Errorf("expected fallback for unknown key, got %q", got)
+	}
+}
+
+func TestTranslatedValidationError(t *testing.T) {
+	db, _ := newMockDB(t)
+	dt := New(db).SetTranslator(catalog)
+
+	if err := dt.Validate(); err == nil || err.Error() != "modelo requerido" {
+		t.Errorf("expected translated error, got %v", err)
+	}
+}
+
+func TestTranslatedErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		run      func(dt *DataTable) error
+		expected string
+	}{
+		{
+			name: "unknown_filter",
+			run: func(dt *DataTable) error {
+				return dt.Req(Request{Draw: 1, Filters: []string{"drop_everything"}}).Validate()
+			},
+			expected: `filtro desconocido "drop_everything"`,
+		},
+		{
+			name: "invalid_length",
+			run: func(dt *DataTable) error {
+				dt.config.AllowedLengths = []int{10, 25}
+				dt.config.RejectInvalidLength = true
+				_, err := dt.allowedLength(1000)
+				return err
+			},
+			expected: "longitud 1000 no válida, permitidas: [10 25]",
+		},
+		{
+			name: "grouped_facets",
+			run: func(dt *DataTable) error {
+				dt.config.GroupBy = []string{"age"}
+				_, err := dt.Req(Request{Draw: 1}).Facets("age")
+				return err
+			},
+			expected: "facetas no soportadas en tablas agrupadas",
+		},
+		{
+			name: "invalid_cursor",
+			run: func(dt *DataTable) error {
+				dt.config.CursorPagination = true
+				_, err := dt.Req(Request{Draw: 1, Cursor: "not a cursor"}).Raw()
+				return err
+			},
+			expected: "cursor no válido",
+		},
+		{
+			name: "unknown_time_column",
+			run: func(dt *DataTable) error {
+				_, err := dt.Req(Request{Draw: 1}).TimeSeries("created_at", BucketDay, nil)
+				return err
+			},
+			expected: `columna de tiempo desconocida "created_at"`,
+		},
+		{
+			name: "no_recording_store",
+			run: func(dt *DataTable) error {
+				_, err := dt.Replay("1")
+				return err
+			},
+			expected: "sin almacén de grabaciones",
+		},
+		{
+			name: "detail_model",
+			run: func(dt *DataTable) error {
+				_, err := dt.Model("users").Detail(1)
+				return err
+			},
+			expected: "el detalle requiere un modelo con clave primaria",
+		},
+		{
+			name: "apply_to_masks",
+			run: func(dt *DataTable) error {
+				_, _, err := dt.MaskColumn("name", MaskAll).Req(Request{Draw: 1}).ApplyTo(nil)
+				return err
+			},
+			expected: "columnas enmascaradas no soportadas por ApplyTo",
+		},
+		{
+			name: "unknown_plugin",
+			run: func(dt *DataTable) error {
+				return dt.Plugins("missing").Req(Request{Draw: 1}).Validate()
+			},
+			expected: `plugin desconocido "missing"`,
+		},
+		{
+			name: "unknown_profile",
+			run: func(dt *DataTable) error {
+				return dt.UseColumns("missing").Req(Request{Draw: 1}).Validate()
+			},
+			expected: `perfil de columnas desconocido "missing"`,
+		},
+		{
+			name: "model_not_struct",
+			run: func(dt *DataTable) error {
+				return dt.Model("users").ColumnsFromModel().Req(Request{Draw: 1}).Validate()
+			},
+			expected: "el modelo no es una estructura",
+		},
+		{
+			name: "batch_render",
+			run: func(dt *DataTable) error {
+				dt.BatchRender(func(data []map[string]any) ([]map[string]any, error) { return nil, nil })
+				return dt.runBatchRender([]map[string]any{{"id": 1}})
+			},
+			expected: "0 valores para 1 filas",
+		},
+		{
+			name: "no_shards",
+			run: func(dt *DataTable) error {
+				_, err := NewShardedDataSource(nil).SetTranslator(catalog).Make(Request{Draw: 1})
+				return err
+			},
+			expected: "sin fragmentos",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := newMockDB(t)
+			dt := New(db).Model(&User{}).SetTranslator(catalog)
+			if err := tt.run(dt); err == nil || err.Error() != tt.expected {
+				t.Errorf("expected %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestLabel(t *testing.T) {
+	dt := New(nil)
+	dt.AddColumns(
+		Column{Name: "users.name", Data: "name"},
+		Column{Name: "", Data: "email"},
+		Column{Name: "created_at", Data: "created_at", Label: "Created"},
+	)
+
+	if got := dt.Label("name"); got != "users.name" {
+		t.Errorf("expected column name fallback, got %q", got)
+	}
+	if got := dt.Label("email"); got != "email" {
+		t.Errorf("expected data fallback, got %q", got)
+	}
+	if got := dt.Label("created_at"); got != "Created" {
+		t.Errorf("expected column label, got %q", got)
+	}
+
+	dt.SetTranslator(catalog)
+	if got := dt.Label("name"); got != "Nombre" {
+		t.Errorf("expected translated label, got %q", got)
+	}
+
+	dt.WithNumber()
+	if got := dt.columnsMap["no"].Name; got != "Nº" {
+		t.Errorf("expected translated number column label, got %q", got)
+	}
+}
+
+func TestTranslateValues(t *testing.T) {
+	dt := New(nil).SetTranslator(catalog)
+	dt.AddColumns(
+		Column{Name: "status", Data: "status"},
+		Column{Name: "verified", Data: "verified"},
+	)
+	dt.TranslateValues("status", "status")
+	dt.TranslateValues("verified", "verified")
+	dt.TranslateValues("unknown", "unknown")
+
+	tests := []struct {
+		name     string
+		column   string
+		row      map[string]any
+		expected any
+	}{
+		{name: "enum", column: "status", row: map[string]any{"status": "active"}, expected: "Activo"},
+		{name: "untranslated", column: "status", row: map[string]any{"status": "banned"}, expected: "banned"},
+		{name: "boolean", column: "verified", row: map[string]any{"verified": true}, expected: "Sí"},
+		{name: "nil", column: "verified", row: map[string]any{"verified": nil}, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dt.columnsMap[tt.column].RenderFunc(tt.row); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
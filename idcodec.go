@@ -0,0 +1,163 @@
+package datatables
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalidID is returned when an opaque ID cannot be decoded.
+var ErrInvalidID = errors.New("invalid id")
+
+// IDCodec converts primary keys to opaque strings and back, so sequential
+// IDs are not leaked by list endpoints. Implementations can wrap hashids or
+// any other reversible encoding.
+type IDCodec interface {
+	Encode(id int64) (string, error)
+	Decode(encoded string) (int64, error)
+}
+
+// aesIDCodec is an IDCodec encrypting IDs with AES.
+type aesIDCodec struct {
+	block cipher.Block
+}
+
+// NewAESIDCodec returns an IDCodec that encrypts IDs with AES using the given
+// key, which must be 16, 24, or 32 bytes long. Encoded IDs are 22 characters
+// of URL-safe base64, and tampered values are rejected with ErrInvalidID.
+func NewAESIDCodec(key []byte) (IDCodec, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return &aesIDCodec{block: block}, nil
+}
+
+// Encode encrypts the ID into an opaque string.
+func (c *aesIDCodec) Encode(id int64) (string, error) {
+	var buf [aes.BlockSize]byte
+	binary.BigEndian.PutUint64(buf[aes.BlockSize-8:], uint64(id))
+	c.block.Encrypt(buf[:], buf[:])
+	return base64.RawURLEncoding.EncodeToString(buf[:]), nil
+}
+
+// Decode decrypts an opaque string back into the ID.
+func (c *aesIDCodec) Decode(encoded string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(raw) != aes.BlockSize {
+		return 0, ErrInvalidID
+	}
+	c.block.Decrypt(raw, raw)
+	for _, b := range raw[:aes.BlockSize-8] {
+		if b != 0 {
+			return 0, ErrInvalidID
+		}
+	}
+	return int64(binary.BigEndian.Uint64(raw[aes.BlockSize-8:])), nil
+}
+
+// toInt64 converts an integer-like value read from the database to int64.
+func toInt64(value any) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint:
+		return int64(v), nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case []byte:
+		return strconv.ParseInt(string(v), 10, 64)
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("unsupported id type %T", value)
+	}
+}
+
+// ObfuscateIDs encodes the values of the given columns with the codec in the
+// response. If no column is given, the "id" column is used.
+//
+// The encoding is applied when the rows are rendered, on top of any render
+// function of the columns, whether the columns are defined before or after
+// ObfuscateIDs is called, or only fetched. Values that cannot be encoded are
+// rendered as nil so the raw IDs are never leaked. The obfuscated columns
+// are neither searchable nor orderable, which would reveal the raw IDs. When
+// the primary key column is obfuscated, Detail decodes the opaque IDs it is
+// given.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) ObfuscateIDs(codec IDCodec, columns ...string) *DataTable {
+	dt.plans = nil
+	if len(columns) == 0 {
+		columns = []string{"id"}
+	}
+	if dt.obfuscated == nil {
+		dt.obfuscated = make(map[string]IDCodec)
+	}
+	for _, data := range columns {
+		dt.obfuscated[data] = codec
+	}
+	return dt
+}
+
+// isObfuscated reports whether the column with the given Data field is
+// obfuscated with ObfuscateIDs.
+func (dt *DataTable) isObfuscated(data string) bool {
+	_, ok := dt.obfuscated[data]
+	return ok
+}
+
+// encodeID encodes the value with the codec. Values that cannot be encoded
+// are returned as nil so the raw IDs are never leaked.
+func encodeID(codec IDCodec, value any) any {
+	if value == nil {
+		return nil
+	}
+	id, err := toInt64(value)
+	if err != nil {
+		return nil
+	}
+	encoded, err := codec.Encode(id)
+	if err != nil {
+		return nil
+	}
+	return encoded
+}
+
+// WhereEncodedID returns a scope filtering the given column by the ID decoded
+// from an opaque string, for use in Editor or detail requests:
+//
+//	db.Scopes(datatables.WhereEncodedID(codec, "id", r.FormValue("id"))).First(&user)
+//
+// If the string cannot be decoded, ErrInvalidID is added to the query.
+func WhereEncodedID(codec IDCodec, column, encoded string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		id, err := codec.Decode(encoded)
+		if err != nil {
+			_ = db.AddError(ErrInvalidID)
+			return db
+		}
+		return db.Where(clause.Eq{Column: clause.Column{Name: column}, Value: id})
+	}
+}
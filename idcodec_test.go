@@ -0,0 +1,173 @@
+package datatables
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestAESIDCodec(t *testing.T) {
+	if _, err := NewAESIDCodec([]byte("short")); err == nil {
+		t.Error("expected error for invalid key size, got nil")
+	}
+
+	codec, err := NewAESIDCodec([]byte("0123456789abcdef"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, id := range []int64{0, 1, 42, 1 << 40} {
+		encoded, err := codec.Encode(id)
+		if err != nil {
+			t.Fatalf("failed to encode %d: %v", id, err)
+		}
+		if len(encoded) != 22 {
+			t.Errorf("expected 22 characters, got %q", encoded)
+		}
+		decoded, err := codec.Decode(encoded)
+		if err != nil || decoded != id {
+			t.Errorf("expected %d, got %d (%v)", id, decoded, err)
+		}
+	}
+
+	for _, encoded := range []string{"", "not-base64!", "AAAAAAAAAAAAAAAAAAAAAA"} {
+		if _, err := codec.Decode(encoded); !errors.Is(err, ErrInvalidID) {
+			t.Errorf("expected ErrInvalidID for %q, got %v", encoded, err)
+		}
+	}
+}
+
+func TestObfuscateIDs(t *testing.T) {
+	codec, _ := NewAESIDCodec([]byte("0123456789abcdef"))
+	decode := func(t *testing.T, value any) int64 {
+		t.Helper()
+		encoded, ok := value.(string)
+		if !ok {
+			t.Fatalf("expected an encoded id, got %v", value)
+		}
+		id, err := codec.Decode(encoded)
+		if err != nil {
+			t.Fatalf("expected a valid encoded id, got %v", err)
+		}
+		return id
+	}
+
+	tests := []struct {
+		name  string
+		setup func(dt *DataTable)
+	}{
+		{
+			name: "after_columns",
+			setup: func(dt *DataTable) {
+				dt.AddColumns(Column{Name: "id", Data: "id"}, Column{Name: "parent_id", Data: "parent_id"})
+				dt.ObfuscateIDs(codec)
+				dt.ObfuscateIDs(codec, "parent_id", "owner_id")
+			},
+		},
+		{
+			name: "before_columns",
+			setup: func(dt *DataTable) {
+				dt.ObfuscateIDs(codec)
+				dt.ObfuscateIDs(codec, "parent_id", "owner_id")
+				dt.AddColumns(
+					Column{Name: "id", Data: "id", RenderFunc: func(row map[string]any) any { return row["id"].(int64) + 1 }},
+					Column{Name: "parent_id", Data: "parent_id"},
+				)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dt := New(nil)
+			tt.setup(dt)
+
+			rows := []map[string]any{
+				{"id": int64(7), "parent_id": nil, "owner_id": int64(9)},
+				{"id": int64(8), "parent_id": 3.5, "owner_id": "bogus"},
+			}
+			dt.renderRows(rows, 2)
+
+			want := int64(7)
+			if dt.columnsMap["id"].RenderFunc != nil {
+				want = 8
+			}
+			if got := decode(t, rows[0]["id"]); got != want {
+				t.Errorf("expected encoded id %d, got %d", want, got)
+			}
+			if got := decode(t, rows[0]["owner_id"]); got != 9 {
+				t.Errorf("expected the undefined column to be encoded, got %d", got)
+			}
+			if rows[0]["parent_id"] != nil || rows[1]["parent_id"] != nil || rows[1]["owner_id"] != nil {
+				t.Errorf("expected nil for null and invalid ids, got %v", rows[1])
+			}
+		})
+	}
+}
+
+func TestObfuscatedIDsNotSearchable(t *testing.T) {
+	codec, _ := NewAESIDCodec([]byte("0123456789abcdef"))
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE `name` LIKE ?")).
+		WithArgs("%7%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(qm("SELECT * FROM `users` WHERE `name` LIKE ? LIMIT ?")+"$").
+		WithArgs("%7%", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Agent 7"))
+
+	dt := New(db).Model(&User{}).
+		ObfuscateIDs(codec).
+		Req(Request{
+			Draw:   1,
+			Length: 10,
+			Search: Search{Value: "7"},
+			Order:  []Order{{Column: 0, Dir: "desc"}},
+			Columns: []ColumnRequest{
+				{Data: "id", Name: "id", Searchable: true, Orderable: true, Search: Search{Value: "1"}},
+				{Data: "name", Name: "name", Searchable: true},
+			},
+		})
+
+	if _, err := dt.Make(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestWhereEncodedID(t *testing.T) {
+	codec, _ := NewAESIDCodec([]byte("0123456789abcdef"))
+	db, mock := newMockDB(t)
+
+	t.Run("valid_id", func(t *testing.T) {
+		encoded, _ := codec.Encode(5)
+		mock.ExpectQuery(qm("SELECT * FROM `users` WHERE `id` = ? LIMIT ?")).
+			WithArgs(5, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(5, "John Doe"))
+
+		var user User
+		if err := db.Scopes(WhereEncodedID(codec, "id", encoded)).Take(&user).Error; err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if user.ID != 5 {
+			t.Errorf("expected user 5, got %d", user.ID)
+		}
+	})
+
+	t.Run("invalid_id", func(t *testing.T) {
+		var user User
+		err := db.Scopes(WhereEncodedID(codec, "id", "bogus")).Take(&user).Error
+		if !errors.Is(err, ErrInvalidID) {
+			t.Errorf("expected ErrInvalidID, got %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package datatables
+
+import (
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// quotedPattern matches the string literals and quoted identifiers of an
+	// SQL statement, whose content must not be taken for keywords.
+	quotedPattern = regexp.MustCompile("`[^`]*`|\"[^\"]*\"|'(?:[^']|'')*'")
+	// unionPattern and distinctPattern match the UNION and DISTINCT keywords,
+	// but not column names containing them, such as is_distinct.
+	unionPattern    = regexp.MustCompile(`(?i)\b` + queryUnion + `\b`)
+	distinctPattern = regexp.MustCompile(`(?i)\b` + queryDistinct + `\b`)
+	// groupByPattern matches the GROUP BY list and the HAVING condition of
+	// a raw SQL statement.
+	groupByPattern = regexp.MustCompile(`(?is)\bGROUP\s+BY\b(.*?)(?:\bHAVING\b(.*?))?(?:\bORDER\s+BY\b|\bLIMIT\b|\bOFFSET\b|$)`)
+)
+
+// maskQuoted returns the SQL with the string literals and quoted identifiers
+// replaced by placeholders of the same length, so that keywords can be
+// looked up in it and the positions found used on the SQL itself.
+func maskQuoted(sql string) string {
+	return quotedPattern.ReplaceAllStringFunc(sql, func(s string) string {
+		return strings.Repeat("x", len(s))
+	})
+}
+
+// inspectRawSQL sets the complex query flags of the config from the SQL of
+// a raw statement and its vars. The GROUP BY fields and the HAVING
+// condition found, together with the vars of its placeholders, replace
+// those of the config.
+func (dt *DataTable) inspectRawSQL(sql string, vars []any) {
+	masked := maskQuoted(sql)
+	if unionPattern.MatchString(masked) {
+		dt.config.Union = true
+	}
+	if distinctPattern.MatchString(masked) {
+		dt.config.Distinct = true
+	}
+	match := groupByPattern.FindStringSubmatchIndex(masked)
+	if match == nil {
+		return
+	}
+	var groupBy []string
+	for _, field := range strings.Split(sql[match[2]:match[3]], ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			groupBy = append(groupBy, field)
+		}
+	}
+	if len(groupBy) > 0 {
+		dt.config.GroupBy = groupBy
+	}
+	if match[4] != -1 {
+		if cond := strings.TrimSpace(sql[match[4]:match[5]]); cond != "" {
+			having := clause.Expr{SQL: cond}
+			before := strings.Count(masked[:match[4]], "?")
+			if count := strings.Count(masked[match[4]:match[5]], "?"); count > 0 && before+count <= len(vars) {
+				having.Vars = vars[before : before+count : before+count]
+			}
+			dt.having = []clause.Expr{having}
+		}
+	}
+}
+
+// hasUnion returns true if the SQL of the expression, or of a raw subquery
+// among its arguments, contains a UNION.
+func hasUnion(expr clause.Expr) bool {
+	if unionPattern.MatchString(maskQuoted(expr.SQL)) {
+		return true
+	}
+	for _, v := range expr.Vars {
+		if sub, ok := v.(*gorm.DB); ok && sub.Statement != nil &&
+			unionPattern.MatchString(maskQuoted(sub.Statement.SQL.String())) {
+			return true
+		}
+	}
+	return false
+}
+
+// inspectClauses sets the complex query flags of the config from the
+// clauses of the statement of the DataTable's transaction: its DISTINCT
+// flag or selects, a UNION in its table expression, and its GROUP BY
+// columns and HAVING conditions, kept with their vars, which replace those
+// of the config.
+func (dt *DataTable) inspectClauses() {
+	stmt := dt.tx.Statement
+	if stmt.Distinct {
+		dt.config.Distinct = true
+	}
+	for _, sel := range stmt.Selects {
+		if distinctPattern.MatchString(maskQuoted(sel)) {
+			dt.config.Distinct = true
+		}
+	}
+	if c, ok := stmt.Clauses[querySelect]; ok {
+		if expr, ok := c.Expression.(clause.Expr); ok && distinctPattern.MatchString(maskQuoted(expr.SQL)) {
+			dt.config.Distinct = true
+		}
+	}
+	if stmt.TableExpr != nil && hasUnion(*stmt.TableExpr) {
+		dt.config.Union = true
+	}
+
+	c, ok := stmt.Clauses[queryGroupBy]
+	if !ok {
+		return
+	}
+	groupBy, ok := c.Expression.(clause.GroupBy)
+	if !ok {
+		return
+	}
+	if len(groupBy.Columns) > 0 {
+		fields := make([]string, 0, len(groupBy.Columns))
+		for _, col := range groupBy.Columns {
+			fields = append(fields, col.Name)
+		}
+		dt.config.GroupBy = fields
+	}
+	for _, cond := range groupBy.Having {
+		if expr, ok := cond.(clause.Expr); ok {
+			dt.having = append(dt.having, expr)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package datatables
+
+import "gorm.io/gorm"
+
+// Names of the query phases passed to the interceptors.
+const (
+	// PhaseCountTotal is the phase of the total count query.
+	PhaseCountTotal = phaseCountTotal
+	// PhaseCountFiltered is the phase of the filtered count query.
+	PhaseCountFiltered = phaseCountFiltered
+	// PhaseFetch is the phase of the data query.
+	PhaseFetch = phaseFetch
+)
+
+// Interceptor wraps the query of a phase, one of PhaseCountTotal,
+// PhaseCountFiltered, or PhaseFetch, right before it is executed, and
+// returns the query to execute. Interceptors compose cross-cutting concerns,
+// such as optimizer hints, SQL comments, tenant scoping, or caching, as
+// middleware around the queries of the DataTable.
+type Interceptor func(phase string, query *gorm.DB) *gorm.DB
+
+// Use adds interceptors wrapping the queries of the DataTable. The
+// interceptors are applied in the order they are added, after the
+// BeforeQuery hooks, so the last one added sees the query the others
+// returned. They also apply to the statements returned by Statements and
+// Explain, and to the row fetched by Detail.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) Use(interceptors ...Interceptor) *DataTable {
+	dt.interceptors = append(dt.interceptors, interceptors...)
+	return dt
+}
+
+// intercept applies the interceptors to the query of the given phase. The
+// interceptors get a new session of the query, so that the conditions they
+// add don't leak into the queries of the other phases sharing its statement.
+// Returns the updated query.
+func (dt *DataTable) intercept(phase string, query *gorm.DB) *gorm.DB {
+	if len(dt.interceptors) == 0 {
+		return query
+	}
+	query = query.Session(&gorm.Session{})
+	for _, interceptor := range dt.interceptors {
+		query = interceptor(phase, query)
+	}
+	return query
+}
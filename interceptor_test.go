@@ -0,0 +1,83 @@
+package datatables
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+func TestUse(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE tenant_id = ?")).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE `name` LIKE ? AND tenant_id = ?")).
+		WithArgs("%John%", 7).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(qm("SELECT * FROM `users` WHERE `name` LIKE ? AND tenant_id = ? LIMIT ?")).
+		WithArgs("%John%", 7, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+
+	var phases []string
+	dt := New(db).Model(&User{}).Use(
+		func(phase string, query *gorm.DB) *gorm.DB {
+			phases = append(phases, phase)
+			return query
+		},
+		func(phase string, query *gorm.DB) *gorm.DB {
+			return query.Where("tenant_id = ?", 7)
+		},
+	)
+	dt.Req(Request{
+		Draw:    1,
+		Length:  10,
+		Search:  Search{Value: "John"},
+		Columns: []ColumnRequest{{Name: "name", Data: "name", Searchable: true}},
+	})
+
+	if _, err := dt.Make(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if want := []string{PhaseCountTotal, PhaseCountFiltered, PhaseFetch}; !slices.Equal(phases, want) {
+		t.Errorf("expected phases %v, got %v", want, phases)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestUseWithSearches(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE tenant_id = ?") + "$").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE `name` LIKE ? AND `name` LIKE ? AND tenant_id = ?")+"$").
+		WithArgs("%John%", "%Doe%", 7).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(qm("SELECT * FROM `users` WHERE `name` LIKE ? AND `name` LIKE ? AND tenant_id = ? LIMIT ?")+"$").
+		WithArgs("%John%", "%Doe%", 7, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+
+	dt := New(db).Model(&User{}).Use(func(phase string, query *gorm.DB) *gorm.DB {
+		return query.Where("tenant_id = ?", 7)
+	})
+	dt.Req(Request{
+		Draw:   1,
+		Length: 10,
+		Search: Search{Value: "John"},
+		Columns: []ColumnRequest{
+			{Name: "name", Data: "name", Searchable: true, Search: Search{Value: "Doe"}},
+		},
+	})
+
+	if _, err := dt.Make(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
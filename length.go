@@ -0,0 +1,61 @@
+package datatables
+
+import (
+	"errors"
+	"slices"
+)
+
+// enforceLength checks the requested page length against the allowed
+// lengths of the configuration, see allowedLength. It does nothing for the
+// shard requests of a ShardedDataSource, whose length is the end of the
+// requested page, checked beforehand.
+func (dt *DataTable) enforceLength() error {
+	if dt.skipLengthCheck {
+		return nil
+	}
+	length, err := dt.allowedLength(dt.req.Length)
+	if err != nil {
+		return err
+	}
+	dt.req.Length = length
+	return nil
+}
+
+// allowedLength checks the given page length against the allowed lengths of
+// the configuration and returns the length to use. A disallowed length is
+// clamped to the nearest allowed value (the smaller one on ties), or
+// rejected with an error when RejectInvalidLength is set. A length of -1
+// (all rows) is only accepted if it is explicitly allowed. The length is
+// returned as is if no allowed lengths are configured.
+func (dt *DataTable) allowedLength(requested int) (int, error) {
+	allowed := dt.config.AllowedLengths
+	if len(allowed) == 0 || slices.Contains(allowed, requested) {
+		return requested, nil
+	}
+
+	if dt.config.RejectInvalidLength {
+		return 0, errors.New(dt.translatef(MsgInvalidLength, "invalid length %d: allowed lengths are %v", requested, allowed))
+	}
+
+	nearest, best := 0, -1
+	for _, length := range allowed {
+		if length <= 0 {
+			continue
+		}
+		diff := length - requested
+		if requested < 0 {
+			diff = length
+		}
+		if diff < 0 {
+			diff = -diff
+		}
+		if best == -1 || diff < best || (diff == best && length < nearest) {
+			nearest, best = length, diff
+		}
+	}
+	if best == -1 {
+		return 0, errors.New(dt.translatef(MsgInvalidLength, "invalid length %d: allowed lengths are %v", requested, allowed))
+	}
+
+	return nearest, nil
+}
@@ -0,0 +1,47 @@
+package datatables
+
+import "testing"
+
+func TestEnforceLength(t *testing.T) {
+	tests := []struct {
+		name           string
+		allowed        []int
+		reject         bool
+		length         int
+		expectedLength int
+		expectedError  bool
+	}{
+		{name: "no_allowed_lengths", allowed: nil, length: 100000, expectedLength: 100000},
+		{name: "allowed_length", allowed: []int{10, 25, 50}, length: 25, expectedLength: 25},
+		{name: "clamp_to_largest", allowed: []int{10, 25, 50}, length: 100000, expectedLength: 50},
+		{name: "clamp_to_nearest", allowed: []int{10, 25, 50}, length: 30, expectedLength: 25},
+		{name: "clamp_tie_to_smaller", allowed: []int{10, 20}, length: 15, expectedLength: 10},
+		{name: "clamp_all_rows", allowed: []int{10, 25}, length: -1, expectedLength: 10},
+		{name: "all_rows_allowed", allowed: []int{10, -1}, length: -1, expectedLength: -1},
+		{name: "reject", allowed: []int{10, 25}, reject: true, length: 100000, expectedError: true},
+		{name: "nothing_to_clamp_to", allowed: []int{-1}, length: 5, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dt := New(nil)
+			dt.config.AllowedLengths = tt.allowed
+			dt.config.RejectInvalidLength = tt.reject
+			dt.req.Length = tt.length
+
+			err := dt.enforceLength()
+			if tt.expectedError {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if dt.req.Length != tt.expectedLength {
+				t.Errorf("expected length %d, got %d", tt.expectedLength, dt.req.Length)
+			}
+		})
+	}
+}
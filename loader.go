@@ -0,0 +1,128 @@
+package datatables
+
+import (
+	"context"
+	"sync"
+)
+
+// RowLoader is a loader primed with the rows of a page before they are
+// rendered. See Loader.
+type RowLoader interface {
+	// Prime fetches the values needed by the given rows.
+	Prime(ctx context.Context, rows []map[string]any) error
+}
+
+// Loader deduplicates, batches, and memoizes key lookups for the render
+// functions of a DataTable, so that enriching every row of a page costs a
+// single query instead of one per row:
+//
+//	owners := datatables.NewLoader(
+//		func(row map[string]any) (int64, bool) { id, ok := row["owner_id"].(int64); return id, ok },
+//		func(ctx context.Context, ids []int64) (map[int64]string, error) { return fetchOwnerNames(ctx, ids) },
+//	)
+//	dt.UseLoader(owners).AddColumn(datatables.Column{Data: "owner", RenderFunc: func(row map[string]any) any {
+//		name, _ := owners.Load(row["owner_id"].(int64))
+//		return name
+//	}})
+//
+// Once registered with UseLoader, the loader is primed right before the
+// rows are rendered: the keys of the rows are collected, deduplicated, and
+// fetched with one call to the fetch function, and Load then returns the
+// memoized values. The memoized values are scoped to a single draw, as
+// priming the loader again discards them, so a Loader should be created per
+// DataTable rather than shared between requests. It is safe for concurrent
+// use.
+type Loader[K comparable, V any] struct {
+	key    func(row map[string]any) (K, bool)
+	fetch  func(ctx context.Context, keys []K) (map[K]V, error)
+	mu     sync.Mutex
+	ctx    context.Context
+	values map[K]V
+}
+
+// NewLoader returns a Loader reading the key of a row with key, which
+// reports false for rows without a key, and fetching the values of a batch
+// of keys with fetch. Keys missing from the map returned by fetch load the
+// zero value.
+func NewLoader[K comparable, V any](key func(row map[string]any) (K, bool), fetch func(ctx context.Context, keys []K) (map[K]V, error)) *Loader[K, V] {
+	return &Loader[K, V]{key: key, fetch: fetch}
+}
+
+// Prime discards the memoized values and fetches the values of the keys of
+// the given rows in a single batch.
+func (l *Loader[K, V]) Prime(ctx context.Context, rows []map[string]any) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.ctx = ctx
+	l.values = make(map[K]V)
+	seen := make(map[K]bool, len(rows))
+	var keys []K
+	for _, row := range rows {
+		if k, ok := l.key(row); ok && !seen[k] {
+			seen[k] = true
+			keys = append(keys, k)
+		}
+	}
+	return l.load(keys)
+}
+
+// Load returns the value of the given key. A key that was not primed is
+// fetched on its own and memoized.
+func (l *Loader[K, V]) Load(key K) (V, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if v, ok := l.values[key]; ok {
+		return v, nil
+	}
+	if l.values == nil {
+		l.values = make(map[K]V)
+	}
+	if err := l.load([]K{key}); err != nil {
+		var zero V
+		return zero, err
+	}
+	return l.values[key], nil
+}
+
+// load fetches the values of the given keys and memoizes them, including
+// the zero value of the keys that were not found. The lock must be held.
+func (l *Loader[K, V]) load(keys []K) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	ctx := l.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	values, err := l.fetch(ctx, keys)
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		l.values[k] = values[k]
+	}
+	return nil
+}
+
+// UseLoader registers loaders that are primed with the rows of the page
+// right before they are rendered, after the BatchRender hooks, so that the
+// render functions can load values through them. If priming a loader fails,
+// processing stops and the error is returned.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) UseLoader(loaders ...RowLoader) *DataTable {
+	dt.loaders = append(dt.loaders, loaders...)
+	return dt
+}
+
+// primeLoaders primes the loaders with the rows and returns the first error.
+func (dt *DataTable) primeLoaders(data []map[string]any) error {
+	for _, loader := range dt.loaders {
+		if err := loader.Prime(dt.context(), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
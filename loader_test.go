@@ -0,0 +1,112 @@
+package datatables
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestLoader(t *testing.T) {
+	var batches [][]int
+	loader := NewLoader(
+		func(row map[string]any) (int, bool) {
+			id, ok := row["owner_id"].(int)
+			return id, ok
+		},
+		func(ctx context.Context, ids []int) (map[int]string, error) {
+			batches = append(batches, ids)
+			names := map[int]string{1: "Alice", 2: "Bob", 3: "Carol"}
+			found := make(map[int]string)
+			for _, id := range ids {
+				if name, ok := names[id]; ok {
+					found[id] = name
+				}
+			}
+			return found, nil
+		},
+	)
+
+	rows := []map[string]any{{"owner_id": 1}, {"owner_id": 2}, {"owner_id": 1}, {"owner_id": 9}, {}}
+	if err := loader.Prime(context.Background(), rows); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for id, expected := range map[int]string{1: "Alice", 2: "Bob", 9: ""} {
+		if name, err := loader.Load(id); err != nil || name != expected {
+			t.Errorf("Load(%d) = %q, %v, expected %q", id, name, err, expected)
+		}
+	}
+	if name, err := loader.Load(3); err != nil || name != "Carol" {
+		t.Errorf("expected a key that was not primed to be fetched, got %q, %v", name, err)
+	}
+	loader.Load(3)
+
+	expected := [][]int{{1, 2, 9}, {3}}
+	if !reflect.DeepEqual(batches, expected) {
+		t.Errorf("expected batches %v, got %v", expected, batches)
+	}
+
+	t.Run("fetch_error", func(t *testing.T) {
+		fetchErr := errors.New("fetch failed")
+		loader := NewLoader(
+			func(row map[string]any) (int, bool) { return 1, true },
+			func(ctx context.Context, ids []int) (map[int]string, error) { return nil, fetchErr },
+		)
+		if err := loader.Prime(context.Background(), []map[string]any{{}}); !errors.Is(err, fetchErr) {
+			t.Errorf("expected error %v, got %v", fetchErr, err)
+		}
+		if _, err := loader.Load(2); !errors.Is(err, fetchErr) {
+			t.Errorf("expected error %v, got %v", fetchErr, err)
+		}
+	})
+}
+
+func TestMakeWithLoader(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(qm("SELECT * FROM `users` LIMIT ?")).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John").AddRow(2, "Jane"))
+
+	calls := 0
+	posts := NewLoader(
+		func(row map[string]any) (int, bool) {
+			id, ok := row["id"].(int)
+			return id, ok
+		},
+		func(ctx context.Context, ids []int) (map[int]int, error) {
+			calls++
+			return map[int]int{1: 3, 2: 5}, nil
+		},
+	)
+
+	dt := New(db).Model(&User{}).UseLoader(posts)
+	dt.Req(Request{
+		Draw:    1,
+		Length:  10,
+		Columns: []ColumnRequest{{Name: "id", Data: "id"}, {Name: "name", Data: "name"}},
+	})
+	dt.AddColumn(Column{Data: "posts", RenderFunc: func(row map[string]any) any {
+		count, _ := posts.Load(row["id"].(int))
+		return count
+	}})
+
+	res, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	data := res["data"].([]map[string]any)
+	if data[0]["posts"] != 3 || data[1]["posts"] != 5 {
+		t.Errorf("expected the loaded values, got %v", data)
+	}
+	if calls != 1 {
+		t.Errorf("expected one fetch for the page, got %d", calls)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package datatables
+
+import (
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SetLogger sets the structured logger used by the DataTable.
+//
+// When a logger is set, the DataTable emits a debug record for each phase of
+// the request (parse, validate, count_total, count_filtered, fetch, render)
+// with its duration and row counts, and an error record when a phase fails.
+// The parse phase covers Req, whose record is emitted by Make so that the
+// logger can be set after Req. The records are logged with the context of
+// the DataTable. Passing nil disables logging, which is the default.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) SetLogger(logger *slog.Logger) *DataTable {
+	dt.logger = logger
+	return dt
+}
+
+// logPhase emits a structured log record for the given phase of the DataTable
+// processing. The record includes the phase name, the table, the duration
+// since start, and any additional attributes. If err is not nil, the record
+// is logged at error level. The function does nothing if no logger is set.
+func (dt *DataTable) logPhase(phase string, start time.Time, err error, attrs ...slog.Attr) {
+	dt.logDuration(phase, time.Since(start), err, attrs...)
+}
+
+// logDuration is like logPhase for a phase that took the given duration.
+func (dt *DataTable) logDuration(phase string, duration time.Duration, err error, attrs ...slog.Attr) {
+	if dt.logger == nil {
+		return
+	}
+
+	attrs = append([]slog.Attr{
+		slog.String("phase", phase),
+		slog.String("table", dt.tableName()),
+		slog.Duration("duration", duration),
+	}, attrs...)
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		dt.logger.LogAttrs(dt.context(), slog.LevelError, "datatables: phase failed", attrs...)
+		return
+	}
+
+	dt.logger.LogAttrs(dt.context(), slog.LevelDebug, "datatables: phase completed", attrs...)
+}
+
+// tableName returns the name of the table the DataTable is querying. It uses
+// the parsed gorm statement when available and falls back to the model when
+// it is a string. An empty string is returned if the name cannot be resolved.
+func (dt *DataTable) tableName() string {
+	if dt.tx != nil && dt.tx.Statement != nil {
+		if dt.tx.Statement.Table != "" {
+			return dt.tx.Statement.Table
+		}
+		if dt.tx.Statement.Schema != nil {
+			return dt.tx.Statement.Schema.Table
+		}
+	}
+	if name, ok := dt.model.(string); ok {
+		return name
+	}
+	if dt.tx != nil && dt.model != nil {
+		stmt := &gorm.Statement{DB: dt.tx}
+		if err := stmt.Parse(dt.model); err == nil && stmt.Schema != nil {
+			return stmt.Schema.Table
+		}
+	}
+	return ""
+}
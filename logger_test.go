@@ -0,0 +1,146 @@
+package datatables
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func TestSetLogger(t *testing.T) {
+	dt := New(nil)
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+	result := dt.SetLogger(logger)
+	if result.logger != logger {
+		t.Errorf("expected logger to be set, got %v", result.logger)
+	}
+}
+
+func TestLogPhase(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected []string
+	}{
+		{
+			name:     "success",
+			err:      nil,
+			expected: []string{"level=DEBUG", "phase=fetch", "table=users", "rows=2"},
+		},
+		{
+			name:     "failure",
+			err:      errors.New("boom"),
+			expected: []string{"level=ERROR", "phase=fetch", "error=boom"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			dt := New(nil)
+			dt.Model("users")
+			dt.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+			dt.logPhase("fetch", time.Now(), tt.err, slog.Int("rows", 2))
+
+			for _, want := range tt.expected {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("expected log to contain %q, got %q", want, buf.String())
+				}
+			}
+		})
+	}
+
+	t.Run("nil_logger", func(t *testing.T) {
+		dt := New(nil)
+		dt.logPhase("fetch", time.Now(), nil)
+	})
+}
+
+type contextKey struct{}
+
+type contextHandler struct {
+	slog.Handler
+	values []any
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	h.values = append(h.values, ctx.Value(contextKey{}))
+	return nil
+}
+
+func TestLogPhaseContext(t *testing.T) {
+	handler := &contextHandler{Handler: slog.NewTextHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelDebug})}
+	ctx := context.WithValue(context.Background(), contextKey{}, "request")
+	dt := New(nil, WithContext(ctx)).SetLogger(slog.New(handler))
+
+	dt.logPhase("fetch", time.Now(), nil)
+	dt.logPhase("fetch", time.Now(), errors.New("boom"))
+
+	if len(handler.values) != 2 || handler.values[0] != "request" || handler.values[1] != "request" {
+		t.Errorf("expected the records to be logged with the context of the DataTable, got %v", handler.values)
+	}
+}
+
+func TestMakeWithLogger(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer dbMock.Close()
+
+	dialector := mysql.New(mysql.Config{
+		Conn:                      dbMock,
+		SkipInitializeWithVersion: true,
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm DB: %v", err)
+	}
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(qm("SELECT * FROM `users` LIMIT ?")).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(1, "John Doe").
+			AddRow(2, "Jane Smith"))
+
+	var buf bytes.Buffer
+	dt := New(db)
+	dt.Model(&User{})
+	dt.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	dt.Req(Request{
+		Draw:   1,
+		Length: 10,
+		Columns: []ColumnRequest{
+			{Name: "id", Data: "id"},
+			{Name: "name", Data: "name"},
+		},
+	})
+
+	if _, err := dt.Make(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, phase := range []string{"parse", "validate", "count_total", "fetch", "render"} {
+		if !strings.Contains(buf.String(), "phase="+phase) {
+			t.Errorf("expected log to contain phase %q, got %q", phase, buf.String())
+		}
+	}
+	if !strings.Contains(buf.String(), "table=users") {
+		t.Errorf("expected log to contain table name, got %q", buf.String())
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package datatables
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// maskChar is the character used to hide masked characters.
+const maskChar = "*"
+
+// MaskFunc masks a value, hiding part or all of it.
+type MaskFunc func(value string) string
+
+// MaskEmail masks the local part of an email address, keeping its first
+// character and the domain (john@example.com becomes j***@example.com).
+// Values that are not email addresses are fully masked.
+func MaskEmail(value string) string {
+	at := strings.LastIndex(value, "@")
+	if at <= 0 {
+		return MaskAll(value)
+	}
+	return MaskPartial(1, 0)(value[:at]) + value[at:]
+}
+
+// MaskAll masks every character of the value.
+func MaskAll(value string) string {
+	return strings.Repeat(maskChar, utf8.RuneCountInString(value))
+}
+
+// MaskPartial returns a MaskFunc that keeps the first keepStart and the last
+// keepEnd characters of the value and masks the rest with at least three
+// mask characters. Values too short to hide anything are fully masked.
+func MaskPartial(keepStart, keepEnd int) MaskFunc {
+	return func(value string) string {
+		runes := []rune(value)
+		if len(runes) <= keepStart+keepEnd {
+			return MaskAll(value)
+		}
+		hidden := max(len(runes)-keepStart-keepEnd, 3)
+		return string(runes[:keepStart]) + strings.Repeat(maskChar, hidden) + string(runes[len(runes)-keepEnd:])
+	}
+}
+
+// MaskColumn masks the values of the column with the given Data field.
+//
+// The mask is applied right after the rows are fetched, before any hook or
+// render function sees them, so PII can be partially hidden in listings
+// without changing the query. The rows returned by Raw, RawFull, and
+// ExportRows are masked as well. Null values are left as is. The masked
+// columns are not searchable, globally or per column, which would reveal the
+// hidden values, and ApplyTo, which leaves the fetch to the caller, returns
+// an error when a column is masked.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) MaskColumn(column string, mask MaskFunc) *DataTable {
+	dt.plans = nil
+	dt.masks[column] = mask
+	return dt
+}
+
+// isMasked reports whether the column with the given Data field is masked
+// with MaskColumn.
+func (dt *DataTable) isMasked(data string) bool {
+	_, ok := dt.masks[data]
+	return ok
+}
+
+// applyMasks applies the column masks to the given data in place. The
+// binary values of the UUID columns are masked in their textual form.
+func (dt *DataTable) applyMasks(data []map[string]any) {
+	if len(dt.masks) == 0 {
+		return
+	}
+	for _, row := range data {
+		for column, mask := range dt.masks {
+			switch v := row[column].(type) {
+			case nil:
+			case string:
+				row[column] = mask(v)
+			case []byte:
+				if len(v) == 16 && dt.columnsMap[column].Type == ColumnTypeUUID {
+					row[column] = mask(formatUUID(v))
+				} else {
+					row[column] = mask(string(v))
+				}
+			default:
+				row[column] = mask(fmt.Sprint(v))
+			}
+		}
+	}
+}
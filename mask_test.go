@@ -0,0 +1,109 @@
+package datatables
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestMaskFuncs(t *testing.T) {
+	tests := []struct {
+		name     string
+		mask     MaskFunc
+		value    string
+		expected string
+	}{
+		{name: "email", mask: MaskEmail, value: "john@example.com", expected: "j***@example.com"},
+		{name: "short_email", mask: MaskEmail, value: "j@example.com", expected: "*@example.com"},
+		{name: "not_an_email", mask: MaskEmail, value: "john", expected: "****"},
+		{name: "all", mask: MaskAll, value: "secret", expected: "******"},
+		{name: "partial", mask: MaskPartial(0, 4), value: "4111111111111111", expected: "************1111"},
+		{name: "partial_unicode", mask: MaskPartial(1, 1), value: "Jürgen", expected: "J****n"},
+		{name: "partial_too_short", mask: MaskPartial(2, 2), value: "abc", expected: "***"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.mask(tt.value); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestApplyMasks(t *testing.T) {
+	dt := New(nil)
+	dt.MaskColumn("email", MaskEmail)
+	dt.MaskColumn("phone", MaskPartial(0, 2))
+
+	data := []map[string]any{
+		{"id": 1, "email": "john@example.com", "phone": []byte("5551234")},
+		{"id": 2, "email": nil, "phone": int64(5559876)},
+	}
+	dt.applyMasks(data)
+
+	expected := []map[string]any{
+		{"id": 1, "email": "j***@example.com", "phone": "*****34"},
+		{"id": 2, "email": nil, "phone": "*****76"},
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("expected %v, got %v", expected, data)
+	}
+}
+
+func TestMaskedColumnsFetched(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE `id` LIKE ?")).
+		WithArgs("%John%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(qm("SELECT * FROM `users` WHERE `id` LIKE ? LIMIT ?")+"$").
+		WithArgs("%John%", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+
+	var hooked any
+	dt := New(db).Model(&User{}).
+		MaskColumn("name", MaskPartial(1, 0)).
+		AfterQuery(func(_, _ int64, data []map[string]any) error {
+			hooked = data[0]["name"]
+			return nil
+		}).
+		Req(Request{
+			Draw:   1,
+			Length: 10,
+			Search: Search{Value: "John"},
+			Columns: []ColumnRequest{
+				{Data: "id", Name: "id", Searchable: true},
+				{Data: "name", Name: "name", Searchable: true, Search: Search{Value: "Doe"}},
+			},
+		})
+
+	data, err := dt.Raw()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := data.([]map[string]any)[0]["name"]; got != "J*******" {
+		t.Errorf("expected the raw rows to be masked, got %v", got)
+	}
+	if hooked != "J*******" {
+		t.Errorf("expected the AfterQuery hooks to see the masked value, got %v", hooked)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestMaskedColumnsApplyTo(t *testing.T) {
+	db, _ := newMockDB(t)
+	dt := New(db).Model(&User{}).
+		MaskColumn("name", MaskAll).
+		Req(Request{Draw: 1, Length: 10, Columns: []ColumnRequest{{Data: "name", Name: "name"}}})
+
+	if _, _, err := dt.ApplyTo(nil); err == nil || !strings.Contains(err.Error(), "masked") {
+		t.Errorf("expected ApplyTo to reject the masked columns, got %v", err)
+	}
+}
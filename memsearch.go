@@ -0,0 +1,143 @@
+package datatables
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// defaultInMemoryLimit is the maximum number of rows fetched to search the
+// computed columns in memory when Config.InMemoryLimit is not set.
+const defaultInMemoryLimit = 10000
+
+// SearchComputed makes the computed column with the given data searchable
+// by the global search, with the match function evaluated in memory on the
+// raw rows, since there is no SQL column to search.
+//
+// When the global search is used and the column is requested as
+// searchable, the rows matching the filters are fetched, up to
+// Config.InMemoryLimit, together with the rows matching the search on the
+// SQL columns. A row matches if it matches the SQL search or the match
+// function of a computed column. The filtered count and the page are then
+// computed in memory, so pagination stays consistent. The summary
+// aggregates, if any, are computed on the SQL search only.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) SearchComputed(data string, match func(row map[string]any, search string) bool) *DataTable {
+	if dt.computedSearch == nil {
+		dt.computedSearch = make(map[string]func(map[string]any, string) bool)
+	}
+	dt.computedSearch[data] = match
+	return dt
+}
+
+// inMemoryMatchers returns the match functions of the computed columns
+// requested as searchable.
+func (dt *DataTable) inMemoryMatchers() []func(map[string]any, string) bool {
+	var matchers []func(map[string]any, string) bool
+	for _, clientCol := range dt.req.Columns {
+		match, ok := dt.computedSearch[clientCol.Data]
+		if ok && clientCol.Searchable && dt.isColumnAllowed(clientCol.Data) && !dt.isMasked(clientCol.Data) {
+			matchers = append(matchers, match)
+		}
+	}
+	return matchers
+}
+
+// searchesInMemory reports whether the request searches computed columns in
+// memory.
+func (dt *DataTable) searchesInMemory() bool {
+	return dt.config.Searchable && dt.req.Search.Value != "" && len(dt.inMemoryMatchers()) > 0
+}
+
+// processInMemorySearch processes the request like processQuery, but applies
+// the global search in memory, on the rows matching the filters, so that the
+// computed columns can be searched.
+func (dt *DataTable) processInMemorySearch(baseQuery *gorm.DB) (any, int64, int64, error) {
+	limit := dt.config.InMemoryLimit
+	if limit <= 0 {
+		limit = defaultInMemoryLimit
+	}
+
+	ctx, p := dt.beginPhase(phaseCountTotal)
+	total, err := dt.getTotalCount(dt.intercept(phaseCountTotal, dt.buildCountQuery(baseQuery)).WithContext(ctx))
+	p.end(err, "count", total)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	if len(dt.summaryAggs) > 0 {
+		if dt.summary, err = dt.getSummary(dt.buildFilteredQuery(baseQuery)); err != nil {
+			return nil, 0, 0, err
+		}
+	}
+
+	search := dt.req.Search
+	dt.req.Search.Value = ""
+	unsearched := dt.buildFilteredQuery(baseQuery)
+	dt.req.Search = search
+
+	fetch := func(query *gorm.DB) ([]map[string]any, error) {
+		query = dt.intercept(phaseFetch, dt.applyBeforeQuery(dt.applyProjection(query.Limit(limit+1))))
+		ctx, p := dt.beginPhase(phaseFetch)
+		rows, err := dt.executeQuery(query.WithContext(ctx), 0)
+		p.end(err, "rows", int64(len(rows)))
+		if err == nil && len(rows) > limit {
+			err = errors.New(dt.translatef(MsgMemorySearchLimit, "too many rows to search in memory, the limit is %d", limit))
+		}
+		return rows, err
+	}
+
+	key := dt.rowKey()
+	matchedSQL := make(map[string]bool)
+	if dt.searcher != nil || dt.searchExpression() != nil {
+		rows, err := fetch(dt.buildFilteredQuery(baseQuery))
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		for _, row := range rows {
+			matchedSQL[key(row)] = true
+		}
+	}
+
+	rows, err := fetch(dt.applyOrder(unsearched))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	matchers := dt.inMemoryMatchers()
+	matches := rows[:0]
+	for _, row := range rows {
+		if matchedSQL[key(row)] || matchAny(matchers, row, dt.req.Search.Value) {
+			matches = append(matches, row)
+		}
+	}
+
+	filtered := int64(len(matches))
+	data := matches
+	if dt.config.Paginate {
+		start := min(dt.req.Start, len(matches))
+		end := len(matches)
+		if dt.req.Length >= 0 {
+			end = min(start+dt.req.Length, end)
+		}
+		data = matches[start:end]
+	}
+	dt.removeDeniedColumns(data)
+	dt.applyMasks(data)
+
+	if err := dt.runAfterQuery(total, filtered, data); err != nil {
+		return nil, 0, 0, err
+	}
+	return data, total, filtered, nil
+}
+
+// matchAny reports whether any of the match functions matches the row.
+func matchAny(matchers []func(map[string]any, string) bool, row map[string]any, search string) bool {
+	for _, match := range matchers {
+		if match(row, search) {
+			return true
+		}
+	}
+	return false
+}
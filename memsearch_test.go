@@ -0,0 +1,85 @@
+package datatables
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestSearchComputed(t *testing.T) {
+	newTable := func(t *testing.T, limit int) (*DataTable, sqlmock.Sqlmock) {
+		db, mock := newMockDB(t)
+		config := defaultConfig()
+		config.InMemoryLimit = limit
+		dt := New(db).Model(&User{}).SetConfig(config).
+			AddColumnFunc("group", func(row map[string]any) any {
+				if row["age"].(int64) >= 18 {
+					return "adult"
+				}
+				return "minor"
+			}).
+			SearchComputed("group", func(row map[string]any, search string) bool {
+				return search == "adult" && row["age"].(int64) >= 18
+			}).
+			Req(Request{
+				Draw:   1,
+				Start:  1,
+				Length: 1,
+				Search: Search{Value: "adult"},
+				Order:  []Order{{Column: 0, Dir: "asc"}},
+				Columns: []ColumnRequest{
+					{Data: "name", Name: "name", Searchable: true, Orderable: true},
+					{Data: "group", Name: "group", Searchable: true},
+				},
+			})
+		return dt, mock
+	}
+	rows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "name", "age"}).
+			AddRow(1, "Adultia", 10).
+			AddRow(2, "Bob", 30).
+			AddRow(3, "Kid", 5)
+	}
+
+	dt, mock := newTable(t, 0)
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(qm("SELECT * FROM `users` WHERE `name` LIKE ? LIMIT ?")).
+		WithArgs("%adult%", defaultInMemoryLimit+1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}).AddRow(1, "Adultia", 10))
+	mock.ExpectQuery(qm("SELECT * FROM `users` ORDER BY `name` LIMIT ?")).
+		WithArgs(defaultInMemoryLimit + 1).
+		WillReturnRows(rows())
+
+	response, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if response["recordsTotal"] != int64(3) || response["recordsFiltered"] != int64(2) {
+		t.Errorf("expected 3 total and 2 filtered records, got %v and %v", response["recordsTotal"], response["recordsFiltered"])
+	}
+	expected := []map[string]any{{"id": 2, "name": "Bob", "age": int64(30), "group": "adult"}}
+	if !reflect.DeepEqual(response["data"], expected) {
+		t.Errorf("expected data %v, got %v", expected, response["data"])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+
+	t.Run("limit_exceeded", func(t *testing.T) {
+		dt, mock := newTable(t, 2)
+		mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectQuery(qm("SELECT * FROM `users` WHERE `name` LIKE ? LIMIT ?")).
+			WithArgs("%adult%", 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}).AddRow(1, "Adultia", 10))
+		mock.ExpectQuery(qm("SELECT * FROM `users` ORDER BY `name` LIMIT ?")).
+			WithArgs(3).
+			WillReturnRows(rows())
+
+		if _, err := dt.Make(); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package datatables
+
+import "time"
+
+// MetricsRecorder receives the measurements taken while a DataTable
+// processes a request. Implementations must be safe for concurrent use, as a
+// single recorder is typically shared by every DataTable of an application.
+//
+// The metrics sub-package provides a Prometheus implementation.
+type MetricsRecorder interface {
+	// ObservePhase records the duration of a processing phase (count_total,
+	// count_filtered, fetch, or render) for the given table.
+	ObservePhase(table, phase string, duration time.Duration)
+
+	// ObserveRequest records a processed request for the given table along
+	// with the number of rows returned and the error, if any.
+	ObserveRequest(table string, rows int, err error)
+}
+
+// SetMetrics sets the metrics recorder used by the DataTable.
+//
+// When a recorder is set, the DataTable reports the duration of each phase
+// and the outcome of each Make call to it. Passing nil disables metrics,
+// which is the default.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) SetMetrics(recorder MetricsRecorder) *DataTable {
+	dt.metrics = recorder
+	return dt
+}
+
+// observeRequest reports a processed request to the metrics recorder, if
+// one is set.
+func (dt *DataTable) observeRequest(rows int, err error) {
+	if dt.metrics != nil {
+		dt.metrics.ObserveRequest(dt.tableName(), rows, err)
+	}
+}
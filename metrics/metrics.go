@@ -0,0 +1,117 @@
+// Package metrics provides a Prometheus implementation of the
+// datatables.MetricsRecorder interface.
+//
+// The Collector exposes histograms for query and render durations, counters
+// for requests and errors, and a gauge for the rows returned by the last
+// request, all labeled by table. It can be registered on any
+// prometheus.Registerer:
+//
+//	collector := metrics.NewCollector("myapp")
+//	prometheus.MustRegister(collector)
+//
+//	response, err := datatables.New(tx).SetMetrics(collector).Req(*req).Make()
+package metrics
+
+import (
+	"time"
+
+	datatables "github.com/ZihxS/golang-gorm-datatables"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// phaseRender is the name of the render phase reported by the DataTable.
+const phaseRender = "render"
+
+// Collector records DataTable metrics and exposes them to Prometheus.
+type Collector struct {
+	queryDuration  *prometheus.HistogramVec
+	renderDuration *prometheus.HistogramVec
+	requests       *prometheus.CounterVec
+	errors         *prometheus.CounterVec
+	rows           *prometheus.GaugeVec
+}
+
+var (
+	_ datatables.MetricsRecorder = (*Collector)(nil)
+	_ prometheus.Collector       = (*Collector)(nil)
+)
+
+// NewCollector returns a new Collector whose metric names are prefixed with
+// the given namespace. An empty namespace produces unprefixed names such as
+// datatables_requests_total.
+func NewCollector(namespace string) *Collector {
+	return &Collector{
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "datatables",
+			Name:      "query_duration_seconds",
+			Help:      "Duration of the DataTable queries, by table and phase.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"table", "phase"}),
+		renderDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "datatables",
+			Name:      "render_duration_seconds",
+			Help:      "Duration of the DataTable rendering, by table.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"table"}),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "datatables",
+			Name:      "requests_total",
+			Help:      "Number of DataTable requests processed, by table.",
+		}, []string{"table"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "datatables",
+			Name:      "errors_total",
+			Help:      "Number of DataTable requests that failed, by table.",
+		}, []string{"table"}),
+		rows: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "datatables",
+			Name:      "rows_returned",
+			Help:      "Number of rows returned by the last DataTable request, by table.",
+		}, []string{"table"}),
+	}
+}
+
+// ObservePhase records the duration of a processing phase. The render phase
+// is recorded in the render histogram, every other phase in the query
+// histogram.
+func (c *Collector) ObservePhase(table, phase string, duration time.Duration) {
+	if phase == phaseRender {
+		c.renderDuration.WithLabelValues(table).Observe(duration.Seconds())
+		return
+	}
+	c.queryDuration.WithLabelValues(table, phase).Observe(duration.Seconds())
+}
+
+// ObserveRequest records a processed request. Failed requests increment the
+// error counter and leave the rows gauge untouched.
+func (c *Collector) ObserveRequest(table string, rows int, err error) {
+	c.requests.WithLabelValues(table).Inc()
+	if err != nil {
+		c.errors.WithLabelValues(table).Inc()
+		return
+	}
+	c.rows.WithLabelValues(table).Set(float64(rows))
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.queryDuration.Describe(ch)
+	c.renderDuration.Describe(ch)
+	c.requests.Describe(ch)
+	c.errors.Describe(ch)
+	c.rows.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.queryDuration.Collect(ch)
+	c.renderDuration.Collect(ch)
+	c.requests.Collect(ch)
+	c.errors.Collect(ch)
+	c.rows.Collect(ch)
+}
@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	collector := NewCollector("app")
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(collector); err != nil {
+		t.Fatalf("failed to register collector: %v", err)
+	}
+
+	collector.ObservePhase("users", "fetch", 20*time.Millisecond)
+	collector.ObservePhase("users", "render", 5*time.Millisecond)
+	collector.ObserveRequest("users", 10, nil)
+	collector.ObserveRequest("users", 0, errors.New("boom"))
+
+	if got := testutil.CollectAndCount(collector, "app_datatables_query_duration_seconds"); got != 1 {
+		t.Errorf("expected 1 query duration series, got %d", got)
+	}
+	if got := testutil.CollectAndCount(collector, "app_datatables_render_duration_seconds"); got != 1 {
+		t.Errorf("expected 1 render duration series, got %d", got)
+	}
+
+	expected := `
+# HELP app_datatables_requests_total Number of DataTable requests processed, by table.
+# TYPE app_datatables_requests_total counter
+app_datatables_requests_total{table="users"} 2
+# HELP app_datatables_errors_total Number of DataTable requests that failed, by table.
+# TYPE app_datatables_errors_total counter
+app_datatables_errors_total{table="users"} 1
+# HELP app_datatables_rows_returned Number of rows returned by the last DataTable request, by table.
+# TYPE app_datatables_rows_returned gauge
+app_datatables_rows_returned{table="users"} 10
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"app_datatables_requests_total", "app_datatables_errors_total", "app_datatables_rows_returned"); err != nil {
+		t.Errorf("unexpected metrics: %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package datatables
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type fakeRecorder struct {
+	mu       sync.Mutex
+	phases   []string
+	requests int
+	rows     int
+	errs     int
+}
+
+func (f *fakeRecorder) ObservePhase(table, phase string, duration time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.phases = append(f.phases, table+":"+phase)
+}
+
+func (f *fakeRecorder) ObserveRequest(table string, rows int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests++
+	f.rows = rows
+	if err != nil {
+		f.errs++
+	}
+}
+
+func TestSetMetrics(t *testing.T) {
+	dt := New(nil)
+	recorder := &fakeRecorder{}
+
+	result := dt.SetMetrics(recorder)
+	if result.metrics != recorder {
+		t.Errorf("expected metrics to be set, got %v", result.metrics)
+	}
+}
+
+func TestMakeWithMetrics(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer dbMock.Close()
+
+	dialector := mysql.New(mysql.Config{
+		Conn:                      dbMock,
+		SkipInitializeWithVersion: true,
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm DB: %v", err)
+	}
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(qm("SELECT * FROM `users` LIMIT ?")).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(1, "John Doe").
+			AddRow(2, "Jane Smith"))
+
+	recorder := &fakeRecorder{}
+	dt := New(db)
+	dt.Model(&User{})
+	dt.SetMetrics(recorder)
+	dt.Req(Request{
+		Draw:   1,
+		Length: 10,
+		Columns: []ColumnRequest{
+			{Name: "id", Data: "id"},
+			{Name: "name", Data: "name"},
+		},
+	})
+
+	t.Run("success", func(t *testing.T) {
+		if _, err := dt.Make(); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		expected := []string{"users:count_total", "users:fetch", "users:render"}
+		if len(recorder.phases) != len(expected) {
+			t.Fatalf("expected phases %v, got %v", expected, recorder.phases)
+		}
+		for i := range expected {
+			if recorder.phases[i] != expected[i] {
+				t.Errorf("expected phase %q, got %q", expected[i], recorder.phases[i])
+			}
+		}
+		if recorder.requests != 1 || recorder.rows != 2 || recorder.errs != 0 {
+			t.Errorf("unexpected request metrics: %+v", recorder)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+			WillReturnError(errors.New("boom"))
+
+		if _, err := dt.Make(); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if recorder.requests != 2 || recorder.errs != 1 {
+			t.Errorf("unexpected request metrics: %+v", recorder)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -1,10 +1,17 @@
 package datatables
 
 import (
+	"context"
 	"errors"
+	"log/slog"
 	"regexp"
+	"slices"
+	"strconv"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
 )
 
 // DataTable represents the configuration and data for a datatables request.
@@ -22,8 +29,25 @@ type DataTable struct {
 	totalRecords     *int64
 	filteredRecords  *int64
 	rowClass         string
+	rowClassFunc     func(map[string]any) string
 	model            any
 	tx               *gorm.DB
+	err              error
+	ctx              context.Context
+	logger           *slog.Logger
+	tracer           Tracer
+	metrics          MetricsRecorder
+	transformer      Transformer
+	translator       Translator
+	auditSink        AuditSink
+	auditActor       func(context.Context) string
+	costWarning      *costWarning
+	recordings       RecordingStore
+	recordingID      string
+	replaying        bool
+	pageVerifier     func(context.Context, PageOverlap)
+	renderIsolation  *renderIsolation
+	computedSearch   map[string]func(map[string]any, string) bool
 	req              Request
 	config           Config
 	relations        []string
@@ -31,12 +55,52 @@ type DataTable struct {
 	columns          []Column
 	whitelistColumns map[string]bool
 	blacklistColumns map[string]bool
+	excludedColumns  map[string]bool
+	computedColumns  map[string]bool
+	obfuscated       map[string]IDCodec
+	skipLengthCheck  bool
+	parseDuration    time.Duration
+	indexColumn      *indexColumn
 	additionalData   map[string]any
 	columnsMap       map[string]Column
+	declaredColumns  map[string]bool
+	heavyColumns     map[string]bool
+	unknownHook      func(context.Context, []string)
+	masks            map[string]MaskFunc
+	presets          map[string]func(*gorm.DB) *gorm.DB
+	deniedColumns    map[string]bool
+	summaryAggs      map[string]string
+	summary          map[string]any
 	rowIdFunc        func(map[string]any) string
 	rowDataFunc      func(map[string]any) map[string]any
 	filters          []func(*gorm.DB) *gorm.DB
+	beforeQuery      []func(*gorm.DB) *gorm.DB
+	filteredQuery    func(*gorm.DB) *gorm.DB
+	rawBase          bool
+	having           []clause.Expr
+	counter          Counter
+	search           *compiledSearch
+	version          *dataVersion
+	afterQuery       []func(int64, int64, []map[string]any) error
+	beforeRender     []func([]map[string]any) error
+	afterRender      []func([]map[string]any) error
+	onRow            []func(int, map[string]any) error
+	batchRender      []func([]map[string]any) ([]map[string]any, error)
+	loaders          []RowLoader
+	values           map[string]any
+	signedFilters    *signedFilters
+	paginator        Paginator
+	searcher         Searcher
+	orderer          Orderer
+	interceptors     []Interceptor
+	pageCursor       string
 	customCols       []func(map[string]any) map[string]any
+	metaFuncs        []func(int64, int64, []map[string]any) map[string]any
+	columnPolicy     func(context.Context, Column) bool
+	rowPolicies      []func(context.Context) func(*gorm.DB) *gorm.DB
+	info             *modelInfo
+	plans            *planCache
+	plan             *plan
 }
 
 // Model sets the model to be used for the datatables request.
@@ -50,27 +114,65 @@ type DataTable struct {
 // The model can also be set when creating a new DataTable with the
 // New function.
 func (dt *DataTable) Model(model any) *DataTable {
+	dt.plans = nil
 	dt.model = model
 	return dt
 }
 
+// modelSchema returns the parsed schema of the DataTable's model, or nil if
+// the model is not a struct that can be parsed.
+func (dt *DataTable) modelSchema() *schema.Schema {
+	if info := dt.modelInfo(); info != nil {
+		return info.schema
+	}
+	return nil
+}
+
 // Req sets the request parameters for the DataTable and adds the specified columns.
 //
 // The request contains various configurations such as draw counter, pagination info,
 // search terms, and column specifications. Each column specified in the request is
 // added to the DataTable. The columns are set with their respective properties,
-// including name, data, searchable, and orderable attributes.
+// including name, data, searchable, and orderable attributes. The SQL
+// expression, type, and render function of an already defined column are kept,
+// since clients cannot send them.
+//
+// Some clients send the column data as numeric indices rather than field
+// names. A numeric data that does not match a defined column is resolved to
+// the column defined at that index, so that searching and ordering target the
+// right column. The defined name of a declared column is always kept, since
+// it may be an SQL expression, and is used when the client sends none.
 //
 // Returns the updated DataTable instance.
 func (dt *DataTable) Req(req Request) *DataTable {
+	start := time.Now()
+	defer func() { dt.parseDuration = time.Since(start) }()
 	dt.req = req
+	dt.req.Columns = slices.Clone(req.Columns)
+	for i := range dt.req.Columns {
+		dt.req.Columns[i].Data = dt.resolveColumnData(dt.req.Columns[i].Data, dt.columns)
+	}
 	for _, v := range dt.req.Columns {
-		dt = dt.AddColumn(Column{
-			Name:       v.Name,
+		existing := dt.columnsMap[v.Data]
+		name := v.Name
+		if name == "" || (dt.declaredColumns[v.Data] && existing.Name != "") {
+			name = existing.Name
+		}
+		if dt.config.Strict && !dt.declaredColumns[v.Data] {
+			continue
+		}
+		dt = dt.addColumn(Column{
+			Name:       name,
 			Data:       v.Data,
 			Searchable: v.Searchable,
 			Orderable:  v.Orderable,
-			RenderFunc: nil,
+			Type:       existing.Type,
+			SQL:        existing.SQL,
+			RenderFunc: existing.RenderFunc,
+			Label:      existing.Label,
+			Relation:   existing.Relation,
+			Renders:    existing.Renders,
+			ExportFunc: existing.ExportFunc,
 		})
 	}
 	return dt
@@ -84,16 +186,33 @@ func (dt *DataTable) Req(req Request) *DataTable {
 // columns will be used in subsequent operations, such as filtering and
 // rendering the table. The function returns the updated DataTable instance.
 func (dt *DataTable) Only(columns ...string) *DataTable {
+	dt.plans = nil
 	dt.selectedColumns = columns
 	return dt
 }
 
+// Except excludes the specified columns from the DataTable's response.
+//
+// This function is the inverse of Only: it takes one or more string
+// arguments, representing the Data fields of the columns to be left out,
+// such as password hashes or tokens. Excluded columns are removed from every
+// row of the response and are never searched or ordered on. The function
+// returns the updated DataTable instance.
+func (dt *DataTable) Except(columns ...string) *DataTable {
+	dt.plans = nil
+	for _, col := range columns {
+		dt.excludedColumns[col] = true
+	}
+	return dt
+}
+
 // With appends the specified relations to the DataTable's relations slice.
 //
 // This function allows the user to specify related models that should be
 // included in the query. The relations are typically defined as strings
 // representing the names of the related models. These relations will be
-// processed during query execution to preload associated data.
+// processed during query execution to preload associated data. Set
+// Config.RelationsFormat to include the preloaded data in the response.
 //
 // Returns the updated DataTable instance.
 func (dt *DataTable) With(relations ...string) *DataTable {
@@ -112,13 +231,90 @@ func (dt *DataTable) WithData(key string, value any) *DataTable {
 	return dt
 }
 
+// WithMeta adds a function computing extra response fields from the results.
+//
+// Unlike WithData, which only sets static keys, the function is called after
+// the query runs with the total and filtered counts and the rendered rows, so
+// it can compute summary stats or flags from the actual results. The returned
+// map is merged into the response after the additional data. The function
+// returns the updated DataTable instance.
+func (dt *DataTable) WithMeta(metaFunc func(total, filtered int64, data []map[string]any) map[string]any) *DataTable {
+	dt.metaFuncs = append(dt.metaFuncs, metaFunc)
+	return dt
+}
+
+// resolveColumnData returns the Data of the column defined at the given
+// numeric index when data is such an index and does not match a defined
+// column. Otherwise data is returned unchanged.
+func (dt *DataTable) resolveColumnData(data string, declared []Column) string {
+	if _, exists := dt.columnsMap[data]; exists {
+		return data
+	}
+	idx, err := strconv.Atoi(data)
+	if err != nil || idx < 0 || idx >= len(declared) {
+		return data
+	}
+	return declared[idx].Data
+}
+
+// indexColumn describes the row number column added with WithIndexColumn.
+type indexColumn struct {
+	data       string
+	startFrom  int
+	descending bool
+}
+
+// number returns the number of the i-th row of the current page.
+func (c *indexColumn) number(start, i int, filtered int64) int {
+	if c.descending {
+		return int(filtered) - start - i - 1 + c.startFrom
+	}
+	return start + i + c.startFrom
+}
+
 // WithNumber adds a column named "No" to the DataTable, which is non-searchable
 // and non-orderable. The column is then blacklisted, meaning it will not be
 // included in the final response. This function returns the updated DataTable
 // instance.
 func (dt *DataTable) WithNumber() *DataTable {
-	dt.AddColumn(Column{Name: "No", Data: "no", Searchable: false, Orderable: false, RenderFunc: nil})
-	dt.BlacklistColumn("no")
+	return dt.WithIndexColumn(dt.translate(MsgNumberColumnLabel, "No"), "no", 1)
+}
+
+// WithIndexColumn adds a row number column with the given name and data key.
+//
+// Rows are numbered across pages starting from startFrom, typically 0 or 1.
+// The column is computed, so it is never searched, ordered on, or selected,
+// and it is blacklisted. Only one index column is kept; calling the function
+// again replaces it. This function returns the updated DataTable instance.
+func (dt *DataTable) WithIndexColumn(name, data string, startFrom int) *DataTable {
+	return dt.withIndexColumn(name, data, startFrom, false)
+}
+
+// WithIndexColumnDesc works like WithIndexColumn but numbers the rows in
+// descending order, so that the last filtered row gets startFrom. This
+// function returns the updated DataTable instance.
+func (dt *DataTable) WithIndexColumnDesc(name, data string, startFrom int) *DataTable {
+	return dt.withIndexColumn(name, data, startFrom, true)
+}
+
+// withIndexColumn registers the row number column of the DataTable.
+func (dt *DataTable) withIndexColumn(name, data string, startFrom int, descending bool) *DataTable {
+	dt.plans = nil
+	if dt.indexColumn != nil && dt.indexColumn.data != data {
+		dt.columns = slices.DeleteFunc(dt.columns, func(col Column) bool {
+			return col.Data == dt.indexColumn.data
+		})
+		delete(dt.columnsMap, dt.indexColumn.data)
+		delete(dt.computedColumns, dt.indexColumn.data)
+		delete(dt.blacklistColumns, dt.indexColumn.data)
+	}
+	dt.indexColumn = &indexColumn{data: data, startFrom: startFrom, descending: descending}
+	if dt.computedColumns == nil {
+		dt.computedColumns = make(map[string]bool)
+	}
+	dt.computedColumns[data] = true
+	dt.AddColumn(Column{Name: name, Data: data, Searchable: false, Orderable: false, RenderFunc: nil})
+	dt.BlacklistColumn(data)
 	return dt
 }
 
@@ -138,23 +334,28 @@ func (dt *DataTable) Filter(filterFunc func(*gorm.DB) *gorm.DB) *DataTable {
 
 // Validate checks the integrity of the DataTable configuration and request.
 //
-// It ensures that either a model or a transaction (tx) with a valid gorm statement
+// It first returns any error recorded while the DataTable was built. It ensures that either a model or a transaction (tx) with a valid gorm statement
 // is provided. If a model is not explicitly set, it attempts to derive it from the
 // gorm statement. The function also validates the request by checking the draw and
-// columns parameters. If a regex search pattern is provided, it verifies that the
-// pattern is valid. Returns an error if any of these validations fail, otherwise
+// columns parameters, the selected filter presets, and the page length, which is
+// clamped to the allowed lengths if needed. If a regex search pattern is provided,
+// globally or for a column, it verifies that the pattern is valid. Returns an error if any of these validations fail, otherwise
 // returns nil.
 func (dt *DataTable) Validate() error {
+	if dt.err != nil {
+		return dt.err
+	}
+
 	if dt.model == nil {
 		if dt.tx == nil {
-			return errors.New("no tx or model provided")
+			return errors.New(dt.translate(MsgNoTxOrModel, "no tx or model provided"))
 		}
 		if dt.tx.Statement == nil {
-			return errors.New("gorm statement is required")
+			return errors.New(dt.translate(MsgStatementRequired, "gorm statement is required"))
 		}
 		if dt.tx.Statement.Model == nil {
 			if dt.tx.Statement.TableExpr == nil || dt.tx.Statement.TableExpr.SQL == "" {
-				return errors.New("model is required")
+				return errors.New(dt.translate(MsgModelRequired, "model is required"))
 			}
 			dt.model = dt.tx.Statement.TableExpr.SQL
 			goto afterModel
@@ -164,18 +365,56 @@ func (dt *DataTable) Validate() error {
 
 afterModel:
 	if dt.req.Draw == 0 && len(dt.req.Columns) == 0 {
-		return errors.New("invalid request")
+		return errors.New(dt.translate(MsgInvalidRequest, "invalid request"))
+	}
+
+	dt.warnUndeclaredColumns()
+	if err := dt.checkUnknownColumns(); err != nil {
+		return err
+	}
+
+	if err := dt.validatePresets(); err != nil {
+		return err
+	}
+
+	if err := dt.validateRelations(); err != nil {
+		return err
+	}
+
+	if _, err := dt.signedPredicates(); err != nil {
+		return err
+	}
+
+	if err := dt.enforceLength(); err != nil {
+		return err
+	}
+
+	if err := dt.config.validate(); err != nil {
+		return err
 	}
 
 	if dt.req.Search.Regex {
 		if _, err := regexp.Compile(dt.req.Search.Value); err != nil {
-			return errors.New("invalid regex search pattern")
+			return errors.New(dt.translate(MsgInvalidRegex, "invalid regex search pattern"))
+		}
+	}
+	for _, col := range dt.req.Columns {
+		if col.Search.Regex {
+			if _, err := regexp.Compile(col.Search.Value); err != nil {
+				return errors.New(dt.translate(MsgInvalidRegex, "invalid regex search pattern"))
+			}
 		}
 	}
 
 	return nil
 }
 
+// addError records an error detected while the DataTable is being built. The
+// errors are joined and returned by Validate, and thus by Make.
+func (dt *DataTable) addError(err error) {
+	dt.err = errors.Join(dt.err, err)
+}
+
 // SetTotalRecords sets the total number of records in the table.
 //
 // This is a convenience method, and is used internally by the DataTable
@@ -214,6 +453,39 @@ func (dt *DataTable) SetFilteredRecords(count int64) *DataTable {
 func (dt *DataTable) SetRowAttributes(idFunc func(map[string]any) string, class string, dataFunc func(map[string]any) map[string]any) *DataTable {
 	dt.rowIdFunc = idFunc
 	dt.rowClass = class
+	dt.rowClassFunc = nil
+	dt.rowDataFunc = dataFunc
+	return dt
+}
+
+// SetRowID sets the function that returns the ID of each table row, sent as
+// DT_RowId. Returns the updated DataTable instance.
+func (dt *DataTable) SetRowID(idFunc func(map[string]any) string) *DataTable {
+	dt.rowIdFunc = idFunc
+	return dt
+}
+
+// SetRowClass sets the function that returns the class of each table row,
+// sent as DT_RowClass, so that classes can vary per row:
+//
+//	dt.SetRowClass(func(row map[string]any) string {
+//		if row["overdue"] == true {
+//			return "row-danger"
+//		}
+//		return ""
+//	})
+//
+// Rows for which the function returns an empty string get no class. Returns
+// the updated DataTable instance.
+func (dt *DataTable) SetRowClass(classFunc func(map[string]any) string) *DataTable {
+	dt.rowClass = ""
+	dt.rowClassFunc = classFunc
+	return dt
+}
+
+// SetRowData sets the function that returns the data-* attributes of each
+// table row. Returns the updated DataTable instance.
+func (dt *DataTable) SetRowData(dataFunc func(map[string]any) map[string]any) *DataTable {
 	dt.rowDataFunc = dataFunc
 	return dt
 }
@@ -230,6 +502,7 @@ func (dt *DataTable) SetRowAttributes(idFunc func(map[string]any) string, class
 //
 // Returns the updated DataTable instance.
 func (dt *DataTable) SetConfig(config Config) *DataTable {
+	dt.plans = nil
 	dt.config = config
 	return dt
 }
@@ -293,3 +566,16 @@ func (dt *DataTable) CaseInsensitive() *DataTable {
 	dt.config.CaseInsensitive = true
 	return dt
 }
+
+// SetResponseSchema sets the schema used to shape the envelope of the
+// response returned by Make.
+//
+// This method is a convenience method that can be used to rename or remove
+// the top-level keys of the response, or to wrap the whole response under a
+// single key, without replacing the rest of the configuration.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) SetResponseSchema(schema ResponseSchema) *DataTable {
+	dt.config.ResponseSchema = schema
+	return dt
+}
@@ -38,6 +38,37 @@ func TestReq(t *testing.T) {
 	}
 }
 
+func TestReqNumericColumnData(t *testing.T) {
+	dt := New(nil)
+	dt.AddColumns(
+		Column{Name: "id", Data: "id"},
+		Column{Name: "customers.name", Data: "customer"},
+	)
+	req := Request{
+		Order: []Order{{Column: 1, Dir: "desc"}},
+		Columns: []ColumnRequest{
+			{Data: "0", Searchable: true, Orderable: true},
+			{Data: "1", Searchable: true, Orderable: true},
+			{Data: "7", Searchable: true},
+		},
+	}
+
+	dt.Req(req)
+	var data []string
+	for _, col := range dt.req.Columns {
+		data = append(data, col.Data)
+	}
+	if expected := []string{"id", "customer", "7"}; !reflect.DeepEqual(data, expected) {
+		t.Errorf("expected request columns %v, got %v", expected, data)
+	}
+	if col := dt.columnsMap["customer"]; col.Name != "customers.name" || !col.Orderable {
+		t.Errorf("expected resolved column to keep its name and be orderable, got %+v", col)
+	}
+	if req.Columns[0].Data != "0" {
+		t.Errorf("expected the caller's request not to be modified")
+	}
+}
+
 func TestOnly(t *testing.T) {
 	dt := New(nil)
 	columns := []string{"id", "name"}
@@ -77,6 +108,42 @@ func TestWithNumber(t *testing.T) {
 	}
 }
 
+func TestWithIndexColumn(t *testing.T) {
+	dt := New(nil)
+	dt.WithNumber()
+	dt.WithIndexColumn("#", "row_index", 0)
+
+	if len(dt.columns) != 1 || dt.columns[0].Data != "row_index" {
+		t.Fatalf("expected index column to replace the number column, got %v", dt.columns)
+	}
+	if _, ok := dt.columnsMap["no"]; ok {
+		t.Errorf("expected previous index column to be removed")
+	}
+	if !dt.computedColumns["row_index"] || dt.isColumnAllowed("row_index") {
+		t.Errorf("expected index column to be computed and blacklisted")
+	}
+
+	tests := []struct {
+		name     string
+		idx      indexColumn
+		start    int
+		i        int
+		expected int
+	}{
+		{name: "one_based", idx: indexColumn{startFrom: 1}, start: 10, i: 0, expected: 11},
+		{name: "zero_based", idx: indexColumn{startFrom: 0}, start: 10, i: 2, expected: 12},
+		{name: "descending", idx: indexColumn{startFrom: 1, descending: true}, start: 10, i: 0, expected: 40},
+		{name: "descending_last_row", idx: indexColumn{startFrom: 1, descending: true}, start: 40, i: 9, expected: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if n := tt.idx.number(tt.start, tt.i, 50); n != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, n)
+			}
+		})
+	}
+}
+
 func TestFilter(t *testing.T) {
 	dt := New(nil)
 	filterFunc := func(db *gorm.DB) *gorm.DB { return db.Where("active = ?", true) }
@@ -131,6 +198,19 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid_case_column_regex_pattern",
+			setup: func(dt *DataTable) {
+				dt.tx = &gorm.DB{Statement: &gorm.Statement{Model: struct{}{}}}
+				dt.Req(Request{
+					Draw: 1,
+					Columns: []ColumnRequest{
+						{Name: "ID", Data: "id", Searchable: true, Search: Search{Value: "(a", Regex: true}},
+					},
+				})
+			},
+			wantErr: true,
+		},
 		{
 			name: "valid_case_with_a_proper_request",
 			setup: func(dt *DataTable) {
@@ -194,6 +274,30 @@ func TestSetRowAttributes(t *testing.T) {
 	}
 }
 
+func TestSetRowSetters(t *testing.T) {
+	dt := New(nil)
+	dt.SetRowAttributes(nil, "row-class", nil)
+	dt.SetRowID(func(row map[string]any) string { return "row_1" }).
+		SetRowClass(func(row map[string]any) string {
+			if row["overdue"] == true {
+				return "row-danger"
+			}
+			return ""
+		}).
+		SetRowData(func(row map[string]any) map[string]any { return map[string]any{"id": 1} })
+
+	data := []map[string]any{{"overdue": true}, {"overdue": false}}
+	dt.applyRowAttributes(data)
+
+	expected := []map[string]any{
+		{"overdue": true, "DT_RowId": "row_1", "DT_RowClass": "row-danger", "DT_RowData_id": 1},
+		{"overdue": false, "DT_RowId": "row_1", "DT_RowData_id": 1},
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("expected %v, got %v", expected, data)
+	}
+}
+
 func TestDisableMethods(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -278,3 +382,13 @@ func TestSetConfig(t *testing.T) {
 		t.Errorf("expected returned instance to be the same as the original DataTable instance")
 	}
 }
+
+func TestExcept(t *testing.T) {
+	dt := New(nil)
+
+	result := dt.Except("password_hash", "token")
+	expected := map[string]bool{"password_hash": true, "token": true}
+	if !reflect.DeepEqual(result.excludedColumns, expected) {
+		t.Errorf("expected excludedColumns to be %v, got %v", expected, result.excludedColumns)
+	}
+}
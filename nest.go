@@ -0,0 +1,59 @@
+package datatables
+
+import "strings"
+
+// nestedColumns returns the Data fields of the defined columns that use
+// dot-notation, such as "profile.name".
+func (dt *DataTable) nestedColumns() []string {
+	var columns []string
+	for _, col := range dt.columns {
+		if strings.Contains(col.Data, ".") {
+			columns = append(columns, col.Data)
+		}
+	}
+	return columns
+}
+
+// applyNestedColumns moves the values of the dot-notation columns into
+// nested objects, so that "profile.name" is sent as {"profile":{"name":...}},
+// matching how DataTables resolves dotted data sources on the client.
+//
+// A value is left under its flat key when a part of its path already holds a
+// value that is not an object. The data is modified in place.
+func (dt *DataTable) applyNestedColumns(data []map[string]any) {
+	columns := dt.nestedColumns()
+	if len(columns) == 0 {
+		return
+	}
+	for _, row := range data {
+		for _, key := range columns {
+			value, ok := row[key]
+			if !ok {
+				continue
+			}
+			if setNested(row, strings.Split(key, "."), value) {
+				delete(row, key)
+			}
+		}
+	}
+}
+
+// setNested stores value in the object at the given path of m, creating the
+// intermediate objects as needed. It returns false if a part of the path
+// already holds a value that is not an object.
+func setNested(m map[string]any, path []string, value any) bool {
+	for _, part := range path[:len(path)-1] {
+		switch next := m[part].(type) {
+		case map[string]any:
+			m = next
+		case nil:
+			child := make(map[string]any)
+			m[part] = child
+			m = child
+		default:
+			return false
+		}
+	}
+	m[path[len(path)-1]] = value
+	return true
+}
@@ -0,0 +1,36 @@
+package datatables
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyNestedColumns(t *testing.T) {
+	dt := New(nil)
+	dt.AddColumns(
+		Column{Data: "id"},
+		Column{Data: "profile.name"},
+		Column{Data: "profile.address.city"},
+		Column{Data: "status.code"},
+	)
+
+	data := []map[string]any{
+		{"id": 1, "profile.name": "John", "profile.address.city": "Jakarta", "status": "active", "status.code": 2},
+	}
+	dt.applyNestedColumns(data)
+
+	expected := []map[string]any{
+		{
+			"id": 1,
+			"profile": map[string]any{
+				"name":    "John",
+				"address": map[string]any{"city": "Jakarta"},
+			},
+			"status":      "active",
+			"status.code": 2,
+		},
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("expected %v, got %v", expected, data)
+	}
+}
@@ -0,0 +1,48 @@
+package datatables
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Names of the processing phases. The parse, validate, record, and verify
+// phases are only logged, the others are also reported to the tracer and
+// the metrics recorder.
+const (
+	phaseParse         = "parse"
+	phaseValidate      = "validate"
+	phaseRecord        = "record"
+	phaseVerify        = "verify"
+	phaseCountTotal    = "count_total"
+	phaseCountFiltered = "count_filtered"
+	phaseFetch         = "fetch"
+	phaseRender        = "render"
+)
+
+// phase tracks a single processing phase of the DataTable so it can be
+// reported to the logger, the tracer, and the metrics recorder at once.
+type phase struct {
+	dt    *DataTable
+	name  string
+	start time.Time
+	span  Span
+}
+
+// beginPhase starts tracking the phase with the given name. It returns the
+// context that queries of the phase should run with, so database spans nest
+// under the phase span.
+func (dt *DataTable) beginPhase(name string) (context.Context, *phase) {
+	ctx, span := dt.startSpan("datatables." + name)
+	return ctx, &phase{dt: dt, name: name, start: time.Now(), span: span}
+}
+
+// end finishes the phase, reporting its duration, the error if any, and the
+// number n under the given key (e.g. "count" or "rows").
+func (p *phase) end(err error, key string, n int64) {
+	p.span.End(err, key, n)
+	p.dt.logPhase(p.name, p.start, err, slog.Int64(key, n))
+	if p.dt.metrics != nil {
+		p.dt.metrics.ObservePhase(p.dt.tableName(), p.name, time.Since(p.start))
+	}
+}
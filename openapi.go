@@ -0,0 +1,257 @@
+package datatables
+
+import (
+	"sync"
+
+	"gorm.io/gorm/schema"
+)
+
+// OpenAPIEndpoint describes a table endpoint documented by OpenAPI.
+//
+// Fields:
+//   - Path: The path of the endpoint, such as /users.
+//   - Name: The name of the table, used to name its row and response
+//     schemas, such as User.
+//   - Table: The definition of the table served by the endpoint.
+type OpenAPIEndpoint struct {
+	Path  string
+	Name  string
+	Table *TableDefinition
+}
+
+// OpenAPI returns an OpenAPI 3.0 document describing the given table
+// endpoints, ready to be encoded as JSON or YAML, so that documented, typed
+// clients can be generated for them.
+//
+// Every endpoint accepts the DataTables request parameters with GET and
+// POST, and returns the response shape configured by its definition. The
+// rows are typed from the declared columns and the fields of the model;
+// columns with a render function or without a model field are left
+// untyped.
+func OpenAPI(title, version string, endpoints ...OpenAPIEndpoint) map[string]any {
+	schemas := map[string]any{
+		"DataTablesSearch": apiObject(map[string]any{
+			"value": apiType("string", ""),
+			"regex": apiType("boolean", ""),
+		}),
+		"DataTablesOrder": apiObject(map[string]any{
+			"column": apiType("integer", ""),
+			"dir":    map[string]any{"type": "string", "enum": []string{"asc", "desc"}},
+		}),
+		"DataTablesColumn": apiObject(map[string]any{
+			"data":       apiType("string", ""),
+			"name":       apiType("string", ""),
+			"searchable": apiType("boolean", ""),
+			"orderable":  apiType("boolean", ""),
+			"search":     apiRef("DataTablesSearch"),
+		}),
+		"DataTablesRequest": apiObject(map[string]any{
+			"draw":    apiType("integer", ""),
+			"start":   apiType("integer", ""),
+			"length":  apiType("integer", ""),
+			"search":  apiRef("DataTablesSearch"),
+			"order":   apiArray(apiRef("DataTablesOrder")),
+			"columns": apiArray(apiRef("DataTablesColumn")),
+			"filter":  apiArray(apiType("string", "")),
+			"cursor":  apiType("string", ""),
+		}),
+	}
+
+	paths := make(map[string]any, len(endpoints))
+	for _, endpoint := range endpoints {
+		schemas[endpoint.Name+"Row"] = endpoint.Table.rowSchema()
+		schemas[endpoint.Name+"Response"] = endpoint.Table.responseSchema(endpoint.Name + "Row")
+
+		responses := map[string]any{
+			"200": map[string]any{
+				"description": "The requested page of " + endpoint.Name + " rows.",
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": apiRef(endpoint.Name + "Response")},
+				},
+			},
+		}
+		paths[endpoint.Path] = map[string]any{
+			"get": map[string]any{
+				"operationId": "list" + endpoint.Name,
+				"parameters":  requestParameters(),
+				"responses":   responses,
+			},
+			"post": map[string]any{
+				"operationId": "query" + endpoint.Name,
+				"requestBody": map[string]any{
+					"content": map[string]any{
+						"application/x-www-form-urlencoded": map[string]any{
+							"schema":   apiRef("DataTablesRequest"),
+							"encoding": deepObjectEncoding(),
+						},
+					},
+				},
+				"responses": responses,
+			},
+		}
+	}
+
+	return map[string]any{
+		"openapi":    "3.0.3",
+		"info":       map[string]any{"title": title, "version": version},
+		"paths":      paths,
+		"components": map[string]any{"schemas": schemas},
+	}
+}
+
+// requestParameters returns the query parameters of a DataTables request.
+func requestParameters() []any {
+	param := func(name string, schema map[string]any, style string) map[string]any {
+		p := map[string]any{"name": name, "in": "query", "schema": schema}
+		if style != "" {
+			p["style"] = style
+			p["explode"] = true
+		}
+		return p
+	}
+	return []any{
+		param("draw", apiType("integer", ""), ""),
+		param("start", apiType("integer", ""), ""),
+		param("length", apiType("integer", ""), ""),
+		param("search", apiRef("DataTablesSearch"), "deepObject"),
+		param("order", apiArray(apiRef("DataTablesOrder")), "deepObject"),
+		param("columns", apiArray(apiRef("DataTablesColumn")), "deepObject"),
+		param("filter", apiArray(apiType("string", "")), "form"),
+		param("cursor", apiType("string", ""), ""),
+	}
+}
+
+// deepObjectEncoding returns the encoding of the nested properties of a
+// form encoded DataTables request.
+func deepObjectEncoding() map[string]any {
+	encoding := make(map[string]any)
+	for _, name := range []string{"search", "order", "columns"} {
+		encoding[name] = map[string]any{"style": "deepObject", "explode": true}
+	}
+	return encoding
+}
+
+// rowSchema returns the schema of the rows of the table.
+func (d *TableDefinition) rowSchema() map[string]any {
+	fields := make(map[string]*schema.Field)
+	if s, err := schema.Parse(d.Model, &sync.Map{}, schema.NamingStrategy{}); err == nil {
+		for _, field := range s.Fields {
+			if field.DBName != "" {
+				fields[field.DBName] = field
+				fields[field.Name] = field
+			}
+		}
+	}
+
+	columns := d.Columns
+	if len(columns) == 0 {
+		for data, field := range fields {
+			if data == field.DBName {
+				columns = append(columns, Column{Data: data})
+			}
+		}
+	}
+	only := make(map[string]bool, len(d.Only))
+	for _, data := range d.Only {
+		only[data] = true
+	}
+
+	properties := make(map[string]any, len(columns))
+	for _, col := range columns {
+		if len(only) > 0 && !only[col.Data] {
+			continue
+		}
+		properties[col.Data] = columnSchema(col, fields[col.Data])
+	}
+	return apiObject(properties)
+}
+
+// columnSchema returns the schema of the values of the given column, backed
+// by the given model field, if any.
+func columnSchema(col Column, field *schema.Field) map[string]any {
+	switch {
+	case len(col.Renders) > 0:
+		return apiType("object", "")
+	case col.RenderFunc != nil:
+		return map[string]any{}
+	case col.Type == ColumnTypeUUID:
+		return apiType("string", "uuid")
+	case field == nil:
+		return map[string]any{}
+	}
+
+	switch field.DataType {
+	case schema.Bool:
+		return apiType("boolean", "")
+	case schema.Int, schema.Uint:
+		return apiType("integer", "int64")
+	case schema.Float:
+		return apiType("number", "")
+	case schema.String:
+		return apiType("string", "")
+	case schema.Time:
+		return apiType("string", "date-time")
+	case schema.Bytes:
+		return apiType("string", "byte")
+	default:
+		return map[string]any{}
+	}
+}
+
+// responseSchema returns the schema of the responses of the table, with
+// rows of the given schema, following its response schema and format.
+func (d *TableDefinition) responseSchema(row string) map[string]any {
+	var cfg Config
+	if d.Config != nil {
+		cfg = *d.Config
+	}
+
+	rows := apiArray(apiRef(row))
+	if cfg.ResponseFormat == ResponseFormatArray {
+		rows = apiArray(apiArray(map[string]any{}))
+	}
+
+	keys := cfg.ResponseSchema
+	properties := make(map[string]any)
+	for key, value := range map[string]any{
+		schemaKey(keys.Draw, "draw"):                       apiType("integer", ""),
+		schemaKey(keys.RecordsTotal, "recordsTotal"):       apiType("integer", "int64"),
+		schemaKey(keys.RecordsFiltered, "recordsFiltered"): apiType("integer", "int64"),
+		schemaKey(keys.Data, "data"):                       rows,
+	} {
+		if key != "-" {
+			properties[key] = value
+		}
+	}
+
+	response := apiObject(properties)
+	response["additionalProperties"] = true
+	if keys.Wrap != "" {
+		return apiObject(map[string]any{keys.Wrap: response})
+	}
+	return response
+}
+
+// apiObject returns the schema of an object with the given properties.
+func apiObject(properties map[string]any) map[string]any {
+	return map[string]any{"type": "object", "properties": properties}
+}
+
+// apiArray returns the schema of an array of the given items.
+func apiArray(items map[string]any) map[string]any {
+	return map[string]any{"type": "array", "items": items}
+}
+
+// apiType returns the schema of a value of the given type and format.
+func apiType(typ, format string) map[string]any {
+	s := map[string]any{"type": typ}
+	if format != "" {
+		s["format"] = format
+	}
+	return s
+}
+
+// apiRef returns a reference to the component schema of the given name.
+func apiRef(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
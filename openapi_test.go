@@ -0,0 +1,85 @@
+package datatables
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestOpenAPI(t *testing.T) {
+	users := &TableDefinition{
+		Model: &User{},
+		Columns: []Column{
+			{Data: "id"},
+			{Data: "name"},
+			{Data: "label", RenderFunc: func(row map[string]any) any { return row["name"] }},
+		},
+	}
+	wrapped := &TableDefinition{
+		Model:  "orders",
+		Config: &Config{ResponseFormat: ResponseFormatArray, ResponseSchema: ResponseSchema{Wrap: "result", Draw: "-"}},
+	}
+
+	doc := OpenAPI("Tables", "1.0.0",
+		OpenAPIEndpoint{Path: "/users", Name: "User", Table: users},
+		OpenAPIEndpoint{Path: "/orders", Name: "Order", Table: wrapped},
+	)
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("failed to encode document: %v", err)
+	}
+	var decoded struct {
+		OpenAPI    string                    `json:"openapi"`
+		Paths      map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("failed to decode document: %v", err)
+	}
+
+	if decoded.OpenAPI != "3.0.3" {
+		t.Errorf("expected openapi 3.0.3, got %q", decoded.OpenAPI)
+	}
+	for _, path := range []string{"/users", "/orders"} {
+		if decoded.Paths[path]["get"] == nil || decoded.Paths[path]["post"] == nil {
+			t.Errorf("expected GET and POST operations for %s", path)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		schema   string
+		expected string
+	}{
+		{
+			name:     "typed rows",
+			schema:   "UserRow",
+			expected: `{"type":"object","properties":{"id":{"type":"integer","format":"int64"},"name":{"type":"string"},"label":{}}}`,
+		},
+		{
+			name:     "default response",
+			schema:   "UserResponse",
+			expected: `{"type":"object","additionalProperties":true,"properties":{"draw":{"type":"integer"},"recordsTotal":{"type":"integer","format":"int64"},"recordsFiltered":{"type":"integer","format":"int64"},"data":{"type":"array","items":{"$ref":"#/components/schemas/UserRow"}}}}`,
+		},
+		{
+			name:     "wrapped array response",
+			schema:   "OrderResponse",
+			expected: `{"type":"object","properties":{"result":{"type":"object","additionalProperties":true,"properties":{"recordsTotal":{"type":"integer","format":"int64"},"recordsFiltered":{"type":"integer","format":"int64"},"data":{"type":"array","items":{"type":"array","items":{}}}}}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var expected any
+			if err := json.Unmarshal([]byte(tt.expected), &expected); err != nil {
+				t.Fatalf("invalid expectation: %v", err)
+			}
+			if got := decoded.Components.Schemas[tt.schema]; !reflect.DeepEqual(got, expected) {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package datatables
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Option configures a DataTable when it is created with New.
+type Option func(*DataTable)
+
+// WithConfig sets the configuration of the DataTable, replacing the default
+// one. See SetConfig.
+func WithConfig(config Config) Option {
+	return func(dt *DataTable) {
+		dt.SetConfig(config)
+	}
+}
+
+// WithColumns adds the given columns to the DataTable. See AddColumns.
+func WithColumns(columns ...Column) Option {
+	return func(dt *DataTable) {
+		dt.AddColumns(columns...)
+	}
+}
+
+// WithContext sets the context used by the DataTable's queries and passed to
+// its policies, hooks, and audit sink.
+func WithContext(ctx context.Context) Option {
+	return func(dt *DataTable) {
+		dt.ctx = ctx
+		if dt.tx != nil {
+			dt.tx = dt.tx.WithContext(ctx)
+		}
+	}
+}
+
+// WithLogger sets the structured logger of the DataTable. See SetLogger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(dt *DataTable) {
+		dt.SetLogger(logger)
+	}
+}
@@ -0,0 +1,46 @@
+package datatables
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	db, _ := newMockDB(t)
+	ctx := context.WithValue(context.Background(), actorKey{}, "alice")
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+	dt := New(db,
+		WithConfig(Config{Searchable: true, Paginate: true}),
+		WithColumns(
+			Column{Name: "id", Data: "id", Orderable: true},
+			Column{Name: "name", Data: "name", Searchable: true},
+		),
+		WithContext(ctx),
+		WithLogger(logger),
+	)
+
+	if dt.config.Orderable || !dt.config.Searchable || !dt.config.Paginate {
+		t.Errorf("expected config to be replaced, got %+v", dt.config)
+	}
+	if len(dt.columns) != 2 || !dt.columnsMap["name"].Searchable {
+		t.Errorf("expected columns to be added, got %v", dt.columns)
+	}
+	if dt.context() != ctx || dt.tx.Statement.Context != ctx {
+		t.Errorf("expected context to be set on the DataTable and its tx")
+	}
+	if dt.logger != logger {
+		t.Errorf("expected logger to be set, got %v", dt.logger)
+	}
+}
+
+func TestWithContextWithoutTx(t *testing.T) {
+	ctx := context.WithValue(context.Background(), actorKey{}, "bob")
+	dt := New(nil, WithContext(ctx))
+
+	if dt.context() != ctx {
+		t.Errorf("expected context to be set without tx")
+	}
+}
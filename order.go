@@ -0,0 +1,79 @@
+package datatables
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// OrderedRow is a row of the response whose keys are encoded to JSON in a
+// fixed order, used when Config.PreserveColumnOrder is set.
+//
+// Fields:
+//   - Keys: The keys encoded first, in this order. Keys missing from
+//     Values are skipped.
+//   - Values: The values of the row. The keys not listed in Keys, such as
+//     DT_RowId, are encoded after them, sorted.
+type OrderedRow struct {
+	Keys   []string
+	Values map[string]any
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r OrderedRow) MarshalJSON() ([]byte, error) {
+	listed := make(map[string]bool, len(r.Keys))
+	keys := make([]string, 0, len(r.Values))
+	for _, key := range r.Keys {
+		if _, ok := r.Values[key]; ok && !listed[key] {
+			listed[key] = true
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(r.Values)) {
+		if !listed[key] {
+			keys = append(keys, key)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(r.Values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// toOrderedRows converts the rows to OrderedRow values keyed in the order of
+// arrayColumns. A dot-notation column, nested by applyNestedColumns, is
+// ordered by its top-level key.
+func (dt *DataTable) toOrderedRows(data []map[string]any) []OrderedRow {
+	var keys []string
+	for _, data := range dt.arrayColumns() {
+		key, _, _ := strings.Cut(data, ".")
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	rows := make([]OrderedRow, len(data))
+	for i, row := range data {
+		rows[i] = OrderedRow{Keys: keys, Values: row}
+	}
+	return rows
+}
@@ -0,0 +1,49 @@
+package datatables
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestPreserveColumnOrder(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+	mock.ExpectQuery(qm("SELECT * FROM `users` LIMIT ?")).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "profile.city", "age"}).AddRow(1, "John", "Paris", 25))
+
+	cfg := defaultConfig()
+	cfg.PreserveColumnOrder = true
+	dt := New(db, WithConfig(cfg)).Model(&User{}).
+		SetRowID(func(row map[string]any) string { return "row_1" }).
+		Req(Request{
+			Draw:   1,
+			Length: 10,
+			Columns: []ColumnRequest{
+				{Data: "name"},
+				{Data: "profile.city"},
+				{Data: "id"},
+			},
+		})
+
+	res, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	b, err := json.Marshal(res["data"])
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := `[{"name":"John","profile":{"city":"Paris"},"id":1,"DT_RowId":"row_1","age":25}]`
+	if string(b) != expected {
+		t.Errorf("expected %s, got %s", expected, b)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
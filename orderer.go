@@ -0,0 +1,61 @@
+package datatables
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ColumnOrder is an ordering of the rows by a column, resolved from the
+// order of a request or from Config.DefaultSort.
+//
+// Fields:
+//   - Column: The column definition the rows are ordered by.
+//   - SQLColumn: The SQL column the column orders by, referenced raw when
+//     the column is backed by an SQL expression.
+//   - Desc: Whether the rows are ordered in descending order.
+type ColumnOrder struct {
+	Column    Column
+	SQLColumn clause.Column
+	Desc      bool
+}
+
+// Orderer applies the ordering of a request to the data query, so that
+// advanced ordering schemes, such as a relevance score, a collation per
+// locale, or a precomputed rank table, can be swapped in per table.
+//
+// Order is called with the orders resolved from the request, restricted to
+// the allowed orderable columns, or from Config.DefaultSort when none of
+// them can be applied. The orders may be empty. The primary key tiebreaker of
+// Config.StableOrder is appended to the ordering it applies.
+type Orderer interface {
+	Order(query *gorm.DB, req Request, orders []ColumnOrder) *gorm.DB
+}
+
+// OrdererFunc is an adapter that allows the use of an ordinary function as
+// an Orderer.
+type OrdererFunc func(query *gorm.DB, req Request, orders []ColumnOrder) *gorm.DB
+
+// Order calls f(query, req, orders).
+func (f OrdererFunc) Order(query *gorm.DB, req Request, orders []ColumnOrder) *gorm.DB {
+	return f(query, req, orders)
+}
+
+// ColumnOrderer orders the rows by the columns of the orders, in turn. It is
+// the default Orderer.
+var ColumnOrderer Orderer = OrdererFunc(func(query *gorm.DB, req Request, orders []ColumnOrder) *gorm.DB {
+	for _, order := range orders {
+		query = query.Order(clause.OrderByColumn{Column: order.SQLColumn, Desc: order.Desc})
+	}
+	return query
+})
+
+// SetOrderer sets the strategy used to order the data query. When no orderer
+// is set, ColumnOrderer is used. The ordering is only applied when
+// Config.Orderable is enabled, and is replaced by the union order when
+// Config.Union is set.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) SetOrderer(orderer Orderer) *DataTable {
+	dt.orderer = orderer
+	return dt
+}
@@ -0,0 +1,56 @@
+package datatables
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestSetOrderer(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(qm("SELECT * FROM `users` ORDER BY `name` COLLATE utf8mb4_de_0900_ai_ci DESC LIMIT ?")).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Zoe").AddRow(2, "Ähre"))
+
+	var resolved []ColumnOrder
+	collated := OrdererFunc(func(query *gorm.DB, req Request, orders []ColumnOrder) *gorm.DB {
+		resolved = orders
+		for _, order := range orders {
+			dir := ""
+			if order.Desc {
+				dir = " DESC"
+			}
+			query = query.Order(clause.OrderBy{Expression: clause.Expr{
+				SQL:  "? COLLATE utf8mb4_de_0900_ai_ci" + dir,
+				Vars: []any{order.SQLColumn},
+			}})
+		}
+		return query
+	})
+
+	dt := New(db).Model(&User{}).SetOrderer(collated)
+	dt.Req(Request{
+		Draw:   1,
+		Length: 10,
+		Order:  []Order{{Column: 1, Dir: "desc"}, {Column: 0, Dir: "asc"}},
+		Columns: []ColumnRequest{
+			{Name: "id", Data: "id"},
+			{Name: "name", Data: "name", Orderable: true},
+		},
+	})
+
+	if _, err := dt.Make(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(resolved) != 1 || resolved[0].Column.Data != "name" || !resolved[0].Desc {
+		t.Errorf("expected a descending order on name, got %+v", resolved)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
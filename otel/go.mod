@@ -0,0 +1,17 @@
+module github.com/ZihxS/golang-gorm-datatables/otel
+
+go 1.24
+
+require (
+	github.com/ZihxS/golang-gorm-datatables v0.0.0-20261016200854-14a58338f5da
+	go.opentelemetry.io/otel v1.35.0
+	go.opentelemetry.io/otel/trace v1.35.0
+)
+
+require (
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+	golang.org/x/text v0.24.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+	gorm.io/gorm v1.26.0 // indirect
+)
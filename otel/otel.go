@@ -0,0 +1,57 @@
+// Package otel provides an OpenTelemetry implementation of the
+// datatables.Tracer interface.
+//
+// The spans are started with the given OpenTelemetry tracer and carry the
+// table name under the datatables.table attribute and the row counts under
+// datatables.count or datatables.rows:
+//
+//	tracer := otel.NewTracer(otelapi.Tracer("myapp"))
+//
+//	response, err := datatables.New(tx).SetTracer(tracer).Req(*req).Make()
+package otel
+
+import (
+	"context"
+
+	datatables "github.com/ZihxS/golang-gorm-datatables"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracer starts the spans of the DataTable phases with an OpenTelemetry
+// tracer.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer returns a Tracer starting its spans with the given OpenTelemetry
+// tracer.
+func NewTracer(tracer trace.Tracer) *Tracer {
+	return &Tracer{tracer: tracer}
+}
+
+// Start starts a span with the given name for the given table as a child of
+// the span found in ctx.
+func (t *Tracer) Start(ctx context.Context, name, table string) (context.Context, datatables.Span) {
+	ctx, span := t.tracer.Start(ctx, name, trace.WithAttributes(
+		attribute.String("datatables.table", table),
+	))
+	return ctx, otelSpan{span: span}
+}
+
+// otelSpan adapts an OpenTelemetry span to the datatables.Span interface.
+type otelSpan struct {
+	span trace.Span
+}
+
+// End records the error, if any, and the number n under the given key on
+// the span and ends it.
+func (s otelSpan) End(err error, key string, n int64) {
+	s.span.SetAttributes(attribute.Int64("datatables."+key, n))
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
@@ -0,0 +1,88 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+type recordedSpan struct {
+	noop.Span
+	name   string
+	attrs  map[attribute.Key]attribute.Value
+	errs   []error
+	status codes.Code
+	ended  bool
+}
+
+func (s *recordedSpan) SetAttributes(kv ...attribute.KeyValue) {
+	for _, attr := range kv {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *recordedSpan) RecordError(err error, _ ...trace.EventOption) {
+	s.errs = append(s.errs, err)
+}
+
+func (s *recordedSpan) SetStatus(code codes.Code, _ string) {
+	s.status = code
+}
+
+func (s *recordedSpan) End(...trace.SpanEndOption) {
+	s.ended = true
+}
+
+type recordingTracer struct {
+	noop.Tracer
+	spans []*recordedSpan
+}
+
+func (r *recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	span := &recordedSpan{name: name, attrs: make(map[attribute.Key]attribute.Value)}
+	cfg := trace.NewSpanStartConfig(opts...)
+	span.SetAttributes(cfg.Attributes()...)
+	r.spans = append(r.spans, span)
+	return ctx, span
+}
+
+func TestTracer(t *testing.T) {
+	recorder := &recordingTracer{}
+	tracer := NewTracer(recorder)
+
+	_, span := tracer.Start(context.Background(), "datatables.fetch", "users")
+	span.End(nil, "rows", 2)
+	_, span = tracer.Start(context.Background(), "datatables.count_total", "users")
+	span.End(errors.New("boom"), "count", 0)
+
+	if len(recorder.spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(recorder.spans))
+	}
+
+	fetch := recorder.spans[0]
+	if fetch.name != "datatables.fetch" || !fetch.ended {
+		t.Errorf("expected an ended fetch span, got %q (ended %v)", fetch.name, fetch.ended)
+	}
+	if got := fetch.attrs["datatables.table"].AsString(); got != "users" {
+		t.Errorf("expected the table name, got %q", got)
+	}
+	if got := fetch.attrs["datatables.rows"].AsInt64(); got != 2 {
+		t.Errorf("expected 2 rows, got %d", got)
+	}
+	if fetch.status != codes.Unset || len(fetch.errs) != 0 {
+		t.Errorf("expected no error on the fetch span, got %v", fetch.errs)
+	}
+
+	count := recorder.spans[1]
+	if count.status != codes.Error || len(count.errs) != 1 {
+		t.Errorf("expected the error to be recorded, got status %v and %v", count.status, count.errs)
+	}
+	if !count.ended {
+		t.Error("expected the count span to be ended")
+	}
+}
@@ -0,0 +1,103 @@
+package datatables
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Paginator applies the pagination of a request to the data query, so that
+// new pagination schemes can be plugged in per table without modifying the
+// query processing.
+type Paginator interface {
+	Paginate(query *gorm.DB, req Request) *gorm.DB
+}
+
+// CursorPaginator is a Paginator that issues the cursor of the next page
+// itself, such as KeysetPaginator. The cursor is computed from the fetched
+// rows of the page, before they are rendered, and is added to the response
+// under the "nextCursor" key; an empty cursor marks the last page.
+type CursorPaginator interface {
+	Paginator
+	NextCursor(req Request, rows []map[string]any) string
+}
+
+// PaginatorFunc is an adapter that allows the use of an ordinary function as
+// a Paginator.
+type PaginatorFunc func(query *gorm.DB, req Request) *gorm.DB
+
+// Paginate calls f(query, req).
+func (f PaginatorFunc) Paginate(query *gorm.DB, req Request) *gorm.DB {
+	return f(query, req)
+}
+
+// OffsetPaginator fetches the page with OFFSET and LIMIT, from the start and
+// length of the request. It is the default Paginator.
+var OffsetPaginator Paginator = PaginatorFunc(func(query *gorm.DB, req Request) *gorm.DB {
+	return query.Offset(req.Start).Limit(req.Length)
+})
+
+// NoPaginator fetches all the filtered rows, ignoring the start and length
+// of the request.
+var NoPaginator Paginator = PaginatorFunc(func(query *gorm.DB, req Request) *gorm.DB {
+	return query
+})
+
+// keysetPaginator is the Paginator returned by KeysetPaginator.
+type keysetPaginator struct {
+	column string
+	desc   bool
+}
+
+// KeysetPaginator returns a CursorPaginator fetching the page that follows
+// the key of the cursor of the request, with a WHERE condition on the given
+// unique column instead of an OFFSET, so that deep pages are as fast as the
+// first one and stay stable while rows are inserted.
+//
+// The rows are ordered by the column only, descending when desc is true, and
+// the ordering of the request is ignored. A request without a cursor fetches
+// the first page, and the start of the request is ignored. A request with an
+// invalid cursor fails with an "invalid cursor" error. The column must be
+// selected by the data query.
+func KeysetPaginator(column string, desc bool) CursorPaginator {
+	return keysetPaginator{column: column, desc: desc}
+}
+
+// Paginate orders the query by the key column and fetches the rows after
+// the key of the cursor of the request. An invalid cursor is added to the
+// errors of the query, so that it fails when executed.
+func (p keysetPaginator) Paginate(query *gorm.DB, req Request) *gorm.DB {
+	column := clause.Column{Name: p.column}
+	query = query.Order(clause.OrderByColumn{Column: column, Desc: p.desc, Reorder: true})
+	if req.Cursor != "" {
+		c, err := decodeCursor(req.Cursor)
+		if err != nil {
+			query.AddError(err)
+		} else if c.After != nil {
+			if p.desc {
+				query = query.Where(clause.Lt{Column: column, Value: c.After})
+			} else {
+				query = query.Where(clause.Gt{Column: column, Value: c.After})
+			}
+		}
+	}
+	return query.Limit(req.Length)
+}
+
+// NextCursor returns the cursor holding the key of the last row, or an
+// empty string if the page is the last one.
+func (p keysetPaginator) NextCursor(req Request, rows []map[string]any) string {
+	if len(rows) == 0 || req.Length < 0 || len(rows) < req.Length {
+		return ""
+	}
+	return encodeCursor(cursor{After: rows[len(rows)-1][p.column]})
+}
+
+// SetPaginator sets the strategy used to paginate the data query, such as
+// KeysetPaginator. When no paginator is set, OffsetPaginator is used. The
+// pagination is only applied when Config.Paginate is enabled.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) SetPaginator(paginator Paginator) *DataTable {
+	dt.paginator = paginator
+	return dt
+}
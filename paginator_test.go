@@ -0,0 +1,137 @@
+package datatables
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestSetPaginator(t *testing.T) {
+	tests := []struct {
+		name      string
+		paginator Paginator
+		sql       string
+		args      []driver.Value
+	}{
+		{name: "default", sql: "SELECT * FROM `users` LIMIT ? OFFSET ?", args: []driver.Value{2, 4}},
+		{name: "offset", paginator: OffsetPaginator, sql: "SELECT * FROM `users` LIMIT ? OFFSET ?", args: []driver.Value{2, 4}},
+		{name: "none", paginator: NoPaginator, sql: "SELECT * FROM `users`"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(6))
+			mock.ExpectQuery(qm(tt.sql) + "$").
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+
+			dt := New(db).Model(&User{}).SetPaginator(tt.paginator)
+			dt.Req(Request{Draw: 1, Start: 4, Length: 2, Columns: []ColumnRequest{{Name: "name", Data: "name"}}})
+
+			if _, err := dt.Make(); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestKeysetPaginator(t *testing.T) {
+	tests := []struct {
+		name       string
+		desc       bool
+		cursor     string
+		sql        string
+		args       []driver.Value
+		ids        []int
+		nextCursor any
+	}{
+		{
+			name:       "first_page",
+			sql:        "SELECT * FROM `users` ORDER BY `id` LIMIT ?",
+			args:       []driver.Value{2},
+			ids:        []int{1, 2},
+			nextCursor: encodeCursor(cursor{After: 2}),
+		},
+		{
+			name:       "next_page",
+			cursor:     encodeCursor(cursor{After: 2}),
+			sql:        "SELECT * FROM `users` WHERE `id` > ? ORDER BY `id` LIMIT ?",
+			args:       []driver.Value{int64(2), 2},
+			ids:        []int{3, 4},
+			nextCursor: encodeCursor(cursor{After: 4}),
+		},
+		{
+			name:   "descending_last_page",
+			desc:   true,
+			cursor: encodeCursor(cursor{After: 2}),
+			sql:    "SELECT * FROM `users` WHERE `id` < ? ORDER BY `id` DESC LIMIT ?",
+			args:   []driver.Value{int64(2), 2},
+			ids:    []int{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+			rows := sqlmock.NewRows([]string{"id", "name"})
+			for _, id := range tt.ids {
+				rows.AddRow(id, "John Doe")
+			}
+			mock.ExpectQuery(qm(tt.sql) + "$").WithArgs(tt.args...).WillReturnRows(rows)
+
+			dt := New(db).Model(&User{}).SetPaginator(KeysetPaginator("id", tt.desc))
+			dt.Req(Request{
+				Draw:    1,
+				Start:   10,
+				Length:  2,
+				Cursor:  tt.cursor,
+				Order:   []Order{{Column: 1, Dir: "asc"}},
+				Columns: []ColumnRequest{{Name: "id", Data: "id"}, {Name: "name", Data: "name", Orderable: true}},
+			})
+
+			response, err := dt.Make()
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if response[nextCursorKey] != tt.nextCursor {
+				t.Errorf("expected nextCursor %v, got %v", tt.nextCursor, response[nextCursorKey])
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestKeysetPaginatorInvalidCursor(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	query := KeysetPaginator("id", false).Paginate(db.Table("users"), Request{Length: 2, Cursor: "not a cursor"})
+	if !errors.Is(query.Error, errInvalidCursor) {
+		t.Errorf("expected the query to carry the invalid cursor error, got %v", query.Error)
+	}
+
+	dt := New(db).Model(&User{}).SetPaginator(KeysetPaginator("id", false))
+	dt.Req(Request{
+		Draw:    1,
+		Length:  2,
+		Cursor:  "not a cursor",
+		Columns: []ColumnRequest{{Name: "id", Data: "id"}},
+	})
+
+	if _, err := dt.Make(); err == nil || err.Error() != "invalid cursor" {
+		t.Errorf("expected an invalid cursor error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package datatables
+
+import (
+	"net/url"
+	"strings"
+)
+
+// ParamStyle is an encoding of the nested parameters of a DataTables
+// request, such as the data of a column or the search value.
+type ParamStyle int
+
+const (
+	// ParamStyleBracket is the encoding used by DataTables, such as
+	// columns[0][data] and search[value]. It is always accepted.
+	ParamStyleBracket ParamStyle = iota
+	// ParamStyleDot encodes the parameters with dots, such as columns.0.data
+	// and search.value, as done by some gateways and deepObject encoders.
+	ParamStyleDot
+	// ParamStyleUnderscore encodes the parameters with underscores, such as
+	// columns_0_data and search_value, as done by some proxies stripping
+	// brackets.
+	ParamStyleUnderscore
+)
+
+// normalizeParams returns the form with the columns, order, and search
+// parameters in the given styles rewritten to the bracket style. The form is
+// returned as is when no other style is accepted.
+func normalizeParams(form url.Values, styles []ParamStyle) url.Values {
+	var seps []string
+	for _, style := range styles {
+		switch style {
+		case ParamStyleDot:
+			seps = append(seps, ".")
+		case ParamStyleUnderscore:
+			seps = append(seps, "_")
+		}
+	}
+	if len(seps) == 0 {
+		return form
+	}
+
+	normalized := make(url.Values, len(form))
+	for key, values := range form {
+		for _, sep := range seps {
+			if k, ok := bracketParam(key, sep); ok {
+				key = k
+				break
+			}
+		}
+		normalized[key] = append(normalized[key], values...)
+	}
+	return normalized
+}
+
+// bracketParam returns the bracket style equivalent of a columns, order, or
+// search parameter whose parts are separated by sep, such as columns[0][data]
+// for columns.0.data, and whether the key is such a parameter.
+func bracketParam(key, sep string) (string, bool) {
+	parts := strings.Split(key, sep)
+	if len(parts) < 2 {
+		return "", false
+	}
+	switch parts[0] {
+	case "columns", "order", "search":
+		return parts[0] + "[" + strings.Join(parts[1:], "][") + "]", true
+	default:
+		return "", false
+	}
+}
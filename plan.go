@@ -0,0 +1,129 @@
+package datatables
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"gorm.io/gorm/clause"
+)
+
+// maxPlans is the maximum number of plans cached by a TableDefinition, so
+// that requests with arbitrary column shapes cannot grow the cache without
+// bound. Plans beyond the limit are compiled for every draw.
+const maxPlans = 256
+
+// plan is the compiled form of the static parts of a request: the columns
+// matched by the global search, the column each orderable request column
+// orders by, and the projection of the data query. Only the per-request
+// values, the search value and the order directions, are bound when it is
+// applied.
+//
+// Fields:
+//   - search: The allowed searchable columns of the request, in order.
+//   - orders: The ascending order of the allowed orderable request columns,
+//     by request column index.
+//   - projection: The SELECT expressions of the data query, when
+//     Config.ProjectColumns is enabled.
+type plan struct {
+	search     []Column
+	orders     map[int]ColumnOrder
+	projection []clause.Expression
+}
+
+// planCache caches the plans of the DataTables produced by a
+// TableDefinition, keyed by the shape of their request. It is safe for
+// concurrent use.
+type planCache struct {
+	plans sync.Map
+	size  atomic.Int64
+}
+
+// get returns the plan cached under the given key, compiling and caching it
+// with compile if there is none.
+func (c *planCache) get(key string, compile func() *plan) *plan {
+	if cached, ok := c.plans.Load(key); ok {
+		return cached.(*plan)
+	}
+	p := compile()
+	if c.size.Load() >= maxPlans {
+		return p
+	}
+	cached, loaded := c.plans.LoadOrStore(key, p)
+	if !loaded {
+		c.size.Add(1)
+	}
+	return cached.(*plan)
+}
+
+// currentPlan returns the plan of the request, compiled on first use after
+// the DataTable is prepared. The plans of the DataTables produced by a
+// TableDefinition are shared through the cache of the definition, unless
+// their columns or configuration were changed after they were produced.
+func (dt *DataTable) currentPlan() *plan {
+	if dt.plan != nil {
+		return dt.plan
+	}
+	if dt.plans == nil {
+		dt.plan = dt.compilePlan()
+	} else {
+		dt.plan = dt.plans.get(dt.planKey(), dt.compilePlan)
+	}
+	return dt.plan
+}
+
+// compilePlan resolves the static parts of the request against the columns
+// of the DataTable.
+func (dt *DataTable) compilePlan() *plan {
+	p := &plan{orders: make(map[int]ColumnOrder)}
+	for i, clientCol := range dt.req.Columns {
+		if !dt.isColumnAllowed(clientCol.Data) {
+			continue
+		}
+		col, exists := dt.columnsMap[clientCol.Data]
+		if !exists {
+			continue
+		}
+		if col.Searchable && !dt.isObfuscated(col.Data) && !dt.isMasked(col.Data) {
+			p.search = append(p.search, col)
+		}
+		if col.Orderable && !dt.isObfuscated(col.Data) && col.Relation == "" && (col.Name != "" || col.SQL != "") {
+			p.orders[i] = ColumnOrder{Column: col, SQLColumn: col.sqlColumn()}
+		}
+	}
+	if dt.config.ProjectColumns {
+		p.projection = dt.projectedColumns(dt.skippedHeavyColumns())
+	}
+	return p
+}
+
+// planKey returns the key of the plan of the request in the cache of a
+// TableDefinition: the shape of the request columns and the columns denied
+// by the column policy, which depend on the context.
+func (dt *DataTable) planKey() string {
+	var b strings.Builder
+	for _, col := range dt.req.Columns {
+		flags := byte('0')
+		if col.Searchable {
+			flags |= 1
+		}
+		if col.Orderable {
+			flags |= 2
+		}
+		if col.Hidden {
+			flags |= 4
+		}
+		b.WriteString(col.Data)
+		b.WriteByte(0)
+		b.WriteString(col.Name)
+		b.WriteByte(0)
+		b.WriteByte(flags)
+	}
+	for _, data := range slices.Sorted(maps.Keys(dt.deniedColumns)) {
+		b.WriteByte(1)
+		b.WriteString(data)
+	}
+	return b.String()
+}
@@ -0,0 +1,72 @@
+package datatables
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPlanCache(t *testing.T) {
+	db, _ := newMockDB(t)
+	def := &TableDefinition{
+		Model:   &User{},
+		Columns: []Column{{Name: "id", Data: "id", Orderable: true}, {Name: "name", Data: "name", Searchable: true, Orderable: true}},
+	}
+	req := Request{
+		Draw:    1,
+		Columns: []ColumnRequest{{Name: "id", Data: "id", Orderable: true}, {Name: "name", Data: "name", Searchable: true, Orderable: true}},
+	}
+	planOf := func(dt *DataTable) *plan {
+		dt.prepare()
+		return dt.currentPlan()
+	}
+
+	first := planOf(def.New(db, req))
+	if len(first.search) != 1 || first.search[0].Data != "name" {
+		t.Errorf("expected the name column to be searched, got %+v", first.search)
+	}
+	if len(first.orders) != 2 {
+		t.Errorf("expected both columns to be orderable, got %+v", first.orders)
+	}
+	if second := planOf(def.New(db, req)); second != first {
+		t.Error("expected the plan to be shared by the same request shape")
+	}
+
+	other := req
+	other.Columns = []ColumnRequest{{Name: "name", Data: "name", Searchable: true, Orderable: true}}
+	if p := planOf(def.New(db, other)); p == first {
+		t.Error("expected another plan for another request shape")
+	}
+
+	changed := def.New(db, req).Except("name")
+	if p := planOf(changed); p == first || len(p.search) != 0 {
+		t.Errorf("expected a plan of its own without the excluded column, got %+v", p)
+	}
+	if def.plans.size.Load() != 2 {
+		t.Errorf("expected 2 cached plans, got %d", def.plans.size.Load())
+	}
+
+	t.Run("limit", func(t *testing.T) {
+		var cache planCache
+		for i := range maxPlans + 10 {
+			cache.get(fmt.Sprint(i), func() *plan { return &plan{} })
+		}
+		if size := cache.size.Load(); size != maxPlans {
+			t.Errorf("expected %d cached plans, got %d", maxPlans, size)
+		}
+	})
+}
+
+func TestPlanKey(t *testing.T) {
+	dt := New(nil).Req(Request{Columns: []ColumnRequest{{Name: "id", Data: "id", Searchable: true}}})
+	key := dt.planKey()
+
+	dt.req.Columns[0].Hidden = true
+	if dt.planKey() == key {
+		t.Error("expected the visibility to change the key")
+	}
+	dt.req.Columns[0].Hidden = false
+	dt.deniedColumns = map[string]bool{"id": true}
+	if dt.planKey() == key {
+		t.Error("expected the denied columns to change the key")
+	}
+}
@@ -0,0 +1,116 @@
+package datatables
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// Plugin is an optional extension of the DataTable, such as SearchPanes, an
+// editor, or an export format, so that such features can live outside the
+// core DataTable. A plugin is registered once with Register and enabled per
+// table with Plugins. All its hooks are optional.
+//
+// Fields:
+//   - Parse: Called by ParseRequest, for every registered plugin, to read the
+//     parameters of the plugin from the HTTP request. A non-nil value is
+//     stored in Request.Extensions under the name of the plugin, and an
+//     error fails the parsing.
+//   - Install: Called when the plugin is enabled on a DataTable, to configure
+//     it, for example to add columns, presets, or hooks.
+//   - Query: Wraps the queries of the DataTable, like an interceptor added
+//     with Use.
+//   - Render: Called with the rendered rows, after the AfterRender hooks,
+//     for example to add data to the response with WithData.
+type Plugin struct {
+	Parse   func(r *http.Request) (any, error)
+	Install func(dt *DataTable)
+	Query   func(phase string, query *gorm.DB) *gorm.DB
+	Render  func(dt *DataTable, data []map[string]any) error
+}
+
+var (
+	pluginsMu sync.RWMutex
+	plugins   = make(map[string]Plugin)
+)
+
+// Register makes a plugin available under the given name. It is meant to be
+// called from the init function of the package providing the plugin, and
+// panics if the name is empty or already registered.
+func Register(name string, plugin Plugin) {
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+	if name == "" {
+		panic("datatables: Register with an empty plugin name")
+	}
+	if _, dup := plugins[name]; dup {
+		panic("datatables: Register called twice for plugin " + name)
+	}
+	plugins[name] = plugin
+}
+
+// Plugins enables the registered plugins with the given names on the
+// DataTable, in order. An unknown name is reported as an error by Validate,
+// and thus by Make.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) Plugins(names ...string) *DataTable {
+	for _, name := range names {
+		pluginsMu.RLock()
+		plugin, exists := plugins[name]
+		pluginsMu.RUnlock()
+		if !exists {
+			dt.addError(errors.New(dt.translatef(MsgUnknownPlugin, "unknown plugin %q", name)))
+			continue
+		}
+		if plugin.Install != nil {
+			plugin.Install(dt)
+		}
+		if plugin.Query != nil {
+			dt.Use(plugin.Query)
+		}
+		if plugin.Render != nil {
+			render := plugin.Render
+			dt.AfterRender(func(data []map[string]any) error {
+				return render(dt, data)
+			})
+		}
+	}
+	return dt
+}
+
+// Extension returns the value parsed from the request by the Parse hook of
+// the plugin with the given name, and whether there is one.
+func (dt *DataTable) Extension(name string) (any, bool) {
+	value, ok := dt.req.Extensions[name]
+	return value, ok
+}
+
+// parsePlugins runs the Parse hooks of the registered plugins, in name
+// order, and stores their values in the Extensions of the request.
+func parsePlugins(r *http.Request, req *Request) error {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+	for _, name := range slices.Sorted(maps.Keys(plugins)) {
+		parse := plugins[name].Parse
+		if parse == nil {
+			continue
+		}
+		value, err := parse(r)
+		if err != nil {
+			return fmt.Errorf("invalid %s parameters: %v", name, err)
+		}
+		if value != nil {
+			if req.Extensions == nil {
+				req.Extensions = make(map[string]any)
+			}
+			req.Extensions[name] = value
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package datatables
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+func TestPlugins(t *testing.T) {
+	Register("test_panes", Plugin{
+		Parse: func(r *http.Request) (any, error) {
+			if v := r.Form.Get("panes"); v != "" {
+				if v == "invalid" {
+					return nil, errors.New("unknown pane")
+				}
+				return v, nil
+			}
+			return nil, nil
+		},
+		Install: func(dt *DataTable) {
+			dt.WithData("installed", true)
+		},
+		Query: func(phase string, query *gorm.DB) *gorm.DB {
+			return query.Where("deleted = ?", false)
+		},
+		Render: func(dt *DataTable, data []map[string]any) error {
+			pane, _ := dt.Extension("test_panes")
+			dt.WithData("panes", map[string]any{"pane": pane, "rows": len(data)})
+			return nil
+		},
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/?draw=1&start=0&length=10&search[regex]=false&columns[0][data]=name&panes=name", nil)
+	req, err := ParseRequest(r)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if req.Extensions["test_panes"] != "name" {
+		t.Fatalf("expected the plugin extension, got %v", req.Extensions)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/?draw=1&start=0&length=10&search[regex]=false&panes=invalid", nil)
+	if _, err := ParseRequest(r); err == nil {
+		t.Error("expected the plugin parse error")
+	}
+
+	db, mock := newMockDB(t)
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE deleted = ?")).
+		WithArgs(false).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE deleted = ?")).
+		WithArgs(false).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(qm("SELECT * FROM `users` WHERE deleted = ? LIMIT ?")).
+		WithArgs(false, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+
+	dt := New(db).Model(&User{}).Req(*req).Plugins("test_panes")
+	response, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if response["installed"] != true {
+		t.Error("expected the plugin to be installed")
+	}
+	panes, _ := response["panes"].(map[string]any)
+	if panes["pane"] != "name" || panes["rows"] != 1 {
+		t.Errorf("expected the panes of the plugin, got %v", response["panes"])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+
+	if _, err := New(db).Model(&User{}).Req(*req).Plugins("missing").Make(); err == nil {
+		t.Error("expected an error for an unknown plugin")
+	}
+}
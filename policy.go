@@ -0,0 +1,139 @@
+package datatables
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ColumnPolicy registers a visibility policy deciding which columns the
+// current request may see, typically based on the user role found in the
+// context.
+//
+// The policy is evaluated once per request for every defined column, with
+// the context of the DataTable's gorm statement (set it with
+// db.WithContext). Columns for which the policy returns false are excluded
+// from the search, the ordering, the explicit SELECT list of the query, and
+// the fetched rows, so that neither the response nor Raw, RawFull, the
+// hooks, or the render functions see them, centralizing per-role column
+// ACLs. The query returned by ApplyTo omits them from its SELECT list, which
+// needs a model or a struct destination for the columns to be listed.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) ColumnPolicy(policy func(ctx context.Context, column Column) bool) *DataTable {
+	dt.columnPolicy = policy
+	return dt
+}
+
+// applyColumnPolicy evaluates the column policy for every defined column and
+// records the denied ones, which are removed from the SELECT list of a new
+// session of the query, so that a base query shared across requests keeps
+// its columns. It does nothing if no policy is set.
+func (dt *DataTable) applyColumnPolicy() {
+	if dt.columnPolicy == nil {
+		return
+	}
+
+	ctx := dt.context()
+	dt.deniedColumns = make(map[string]bool)
+	for data, col := range dt.columnsMap {
+		if !dt.columnPolicy(ctx, col) {
+			dt.deniedColumns[data] = true
+		}
+	}
+
+	if len(dt.deniedColumns) > 0 && dt.tx != nil && len(dt.tx.Statement.Selects) > 0 {
+		var selects []string
+		for _, sel := range dt.tx.Statement.Selects {
+			for _, expr := range strings.Split(sel, ",") {
+				if expr = strings.TrimSpace(expr); !dt.isDeniedSelect(expr) {
+					selects = append(selects, expr)
+				}
+			}
+		}
+		dt.tx = dt.tx.Session(&gorm.Session{}).Select(selects)
+	}
+}
+
+// removeDeniedColumns removes the columns denied by the column policy from
+// the fetched data in place, under their Data field and their column name,
+// so that no output method sees them.
+func (dt *DataTable) removeDeniedColumns(data []map[string]any) {
+	if len(dt.deniedColumns) == 0 {
+		return
+	}
+	for _, row := range data {
+		for data := range dt.deniedColumns {
+			delete(row, data)
+			delete(row, dt.columnsMap[data].Name)
+		}
+	}
+}
+
+// omitDeniedColumns leaves the columns denied by the column policy out of the
+// SELECT list of the query, for a query selecting every field of its model.
+// Returns the updated query.
+func (dt *DataTable) omitDeniedColumns(query *gorm.DB) *gorm.DB {
+	if len(dt.deniedColumns) == 0 || len(query.Statement.Selects) > 0 {
+		return query
+	}
+	var names []string
+	for _, data := range slices.Sorted(maps.Keys(dt.deniedColumns)) {
+		if name := dt.columnsMap[data].Name; name != "" {
+			names = append(names, name)
+		} else {
+			names = append(names, data)
+		}
+	}
+	return query.Omit(names...)
+}
+
+// isDeniedSelect reports whether the SELECT expression selects a column
+// denied by the column policy. The expression is matched by its alias or,
+// without alias, by its column name without table qualifier.
+func (dt *DataTable) isDeniedSelect(expr string) bool {
+	name := expr
+	if i := strings.LastIndex(strings.ToUpper(name), " AS "); i != -1 {
+		name = name[i+4:]
+	} else if i := strings.LastIndex(name, "."); i != -1 {
+		name = name[i+1:]
+	}
+	name = strings.Trim(strings.TrimSpace(name), "`\"")
+
+	for data := range dt.deniedColumns {
+		if name == data || name == dt.columnsMap[data].Name {
+			return true
+		}
+	}
+	return false
+}
+
+// RowPolicy registers a row-level security policy.
+//
+// The policy is called once per request with the context of the DataTable's
+// gorm statement and returns a scope restricting the rows the request may
+// see. The scope is applied to the base query, from which the total count,
+// filtered count, data, and summary queries are all derived, so access
+// control never distorts recordsTotal or recordsFiltered. A policy may
+// return nil to leave the query unrestricted.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) RowPolicy(policy func(ctx context.Context) func(*gorm.DB) *gorm.DB) *DataTable {
+	dt.rowPolicies = append(dt.rowPolicies, policy)
+	return dt
+}
+
+// applyRowPolicies applies the scopes returned by the row policies to the
+// query. Returns the updated query.
+func (dt *DataTable) applyRowPolicies(query *gorm.DB) *gorm.DB {
+	ctx := dt.context()
+	for _, policy := range dt.rowPolicies {
+		if scope := policy(ctx); scope != nil {
+			query = scope(query)
+		}
+	}
+	return query
+}
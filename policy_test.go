@@ -0,0 +1,241 @@
+package datatables
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+type roleKey struct{}
+
+func adminOnlyPolicy(ctx context.Context, column Column) bool {
+	if column.Data != "salary" {
+		return true
+	}
+	role, _ := ctx.Value(roleKey{}).(string)
+	return role == "admin"
+}
+
+func TestColumnPolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           string
+		countQuery     string
+		dataQuery      string
+		expectedSalary bool
+	}{
+		{
+			name:           "admin",
+			role:           "admin",
+			countQuery:     "SELECT count(*) FROM `employees` WHERE (`name` LIKE ? OR `salary` LIKE ?)",
+			dataQuery:      "SELECT id, name, salary FROM `employees` WHERE (`name` LIKE ? OR `salary` LIKE ?) ORDER BY `salary` DESC LIMIT ?",
+			expectedSalary: true,
+		},
+		{
+			name:           "viewer",
+			role:           "viewer",
+			countQuery:     "SELECT count(*) FROM `employees` WHERE `name` LIKE ?",
+			dataQuery:      "SELECT id, name FROM `employees` WHERE `name` LIKE ? LIMIT ?",
+			expectedSalary: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			ctx := context.WithValue(context.Background(), roleKey{}, tt.role)
+
+			mock.ExpectQuery(qm("SELECT count(*) FROM `employees`")).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			mock.ExpectQuery(qm(tt.countQuery)).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			mock.ExpectQuery(qm(tt.dataQuery)).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "name", "salary"}).AddRow(1, "John Doe", 1000))
+
+			dt := New(db.WithContext(ctx).Table("employees").Select("id, name, salary"))
+			dt.ColumnPolicy(adminOnlyPolicy)
+			dt.Req(Request{
+				Draw:   1,
+				Length: 10,
+				Search: Search{Value: "1"},
+				Order:  []Order{{Column: 2, Dir: "desc"}},
+				Columns: []ColumnRequest{
+					{Name: "id", Data: "id"},
+					{Name: "name", Data: "name", Searchable: true},
+					{Name: "salary", Data: "salary", Searchable: true, Orderable: true},
+				},
+			})
+
+			response, err := dt.Make()
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			row := response["data"].([]map[string]any)[0]
+			if _, ok := row["salary"]; ok != tt.expectedSalary {
+				t.Errorf("expected salary presence to be %v, got row %v", tt.expectedSalary, row)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestColumnPolicySharedQuery(t *testing.T) {
+	db, mock := newMockDB(t)
+	base := db.Table("employees").Select("id, name, salary")
+
+	for _, tt := range []struct {
+		role      string
+		dataQuery string
+	}{
+		{"viewer", "SELECT id, name FROM `employees` LIMIT ?"},
+		{"admin", "SELECT id, name, salary FROM `employees` LIMIT ?"},
+	} {
+		mock.ExpectQuery(qm("SELECT count(*) FROM `employees`")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(qm(tt.dataQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+
+		role := tt.role
+		dt := New(base).ColumnPolicy(func(_ context.Context, column Column) bool {
+			return adminOnlyPolicy(context.WithValue(context.Background(), roleKey{}, role), column)
+		})
+		dt.Req(Request{
+			Draw:    1,
+			Length:  10,
+			Columns: []ColumnRequest{{Name: "id", Data: "id"}, {Name: "name", Data: "name"}, {Name: "salary", Data: "salary"}},
+		})
+		if _, err := dt.Make(); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	if want := []string{"id, name, salary"}; !slices.Equal(base.Statement.Selects, want) {
+		t.Errorf("expected the base query selects to be kept as %v, got %v", want, base.Statement.Selects)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+type tenantKey struct{}
+
+func TestRowPolicy(t *testing.T) {
+	db, mock := newMockDB(t)
+	ctx := context.WithValue(context.Background(), tenantKey{}, 7)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE tenant_id = ?")).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE tenant_id = ? AND `name` LIKE ?")).
+		WithArgs(7, "%John%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(qm("SELECT * FROM `users` WHERE tenant_id = ? AND `name` LIKE ? LIMIT ?")).
+		WithArgs(7, "%John%", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+
+	dt := New(db.WithContext(ctx))
+	dt.Model(&User{})
+	dt.RowPolicy(func(ctx context.Context) func(*gorm.DB) *gorm.DB {
+		tenant := ctx.Value(tenantKey{})
+		return func(db *gorm.DB) *gorm.DB {
+			return db.Where("tenant_id = ?", tenant)
+		}
+	})
+	dt.RowPolicy(func(ctx context.Context) func(*gorm.DB) *gorm.DB { return nil })
+	dt.Req(Request{
+		Draw:   1,
+		Length: 10,
+		Search: Search{Value: "John"},
+		Columns: []ColumnRequest{
+			{Name: "name", Data: "name", Searchable: true},
+		},
+	})
+
+	response, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if response["recordsTotal"] != int64(3) || response["recordsFiltered"] != int64(1) {
+		t.Errorf("unexpected counts %v, %v", response["recordsTotal"], response["recordsFiltered"])
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestColumnPolicyRaw(t *testing.T) {
+	db, mock := newMockDB(t)
+	ctx := context.WithValue(context.Background(), roleKey{}, "viewer")
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `employees`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(qm("SELECT * FROM `employees` LIMIT ?") + "$").
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "salary"}).AddRow(1, "John Doe", 1000))
+
+	var hooked []map[string]any
+	dt := New(db.WithContext(ctx).Table("employees")).
+		ColumnPolicy(adminOnlyPolicy).
+		AfterQuery(func(_, _ int64, data []map[string]any) error {
+			hooked = data
+			return nil
+		}).
+		Req(Request{
+			Draw:    1,
+			Length:  10,
+			Columns: []ColumnRequest{{Name: "id", Data: "id"}, {Name: "name", Data: "name"}, {Name: "salary", Data: "salary"}},
+		})
+
+	data, total, _, err := dt.RawFull()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	row := data.([]map[string]any)[0]
+	if _, ok := row["salary"]; ok || total != 1 {
+		t.Errorf("expected the denied column to be removed from the raw rows, got %v", row)
+	}
+	if _, ok := hooked[0]["salary"]; ok {
+		t.Errorf("expected the AfterQuery hooks not to see the denied column, got %v", hooked[0])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestColumnPolicyApplyTo(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(qm("SELECT `users`.`id` FROM `users` LIMIT ?") + "$").
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	dt := New(nil).Model(&User{}).
+		ColumnPolicy(func(_ context.Context, column Column) bool { return column.Data != "name" }).
+		Req(Request{
+			Draw:    1,
+			Length:  10,
+			Columns: []ColumnRequest{{Name: "id", Data: "id"}, {Name: "name", Data: "name"}},
+		})
+
+	query, _, err := dt.ApplyTo(db)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var users []User
+	if err := query.Find(&users).Error; err != nil {
+		t.Fatalf("failed to execute query: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
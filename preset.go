@@ -0,0 +1,42 @@
+package datatables
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// RegisterFilter registers a named filter preset that clients can select
+// with the "filter" request parameter (e.g. filter=active_only).
+//
+// Only registered presets can be selected, so clients are limited to
+// server-defined filters; selecting an unknown preset makes Make return an
+// error. Selected presets are applied to the base query, like Filter.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) RegisterFilter(name string, filterFunc func(*gorm.DB) *gorm.DB) *DataTable {
+	dt.presets[name] = filterFunc
+	return dt
+}
+
+// validatePresets returns an error if the request selects a filter preset
+// that is not registered.
+func (dt *DataTable) validatePresets() error {
+	for _, name := range dt.req.Filters {
+		if _, ok := dt.presets[name]; !ok {
+			return errors.New(dt.translatef(MsgUnknownFilter, "unknown filter %q", name))
+		}
+	}
+	return nil
+}
+
+// applyPresets applies the filter presets selected by the request to the
+// query. Returns the updated query.
+func (dt *DataTable) applyPresets(query *gorm.DB) *gorm.DB {
+	for _, name := range dt.req.Filters {
+		if filter, ok := dt.presets[name]; ok {
+			query = filter(query)
+		}
+	}
+	return query
+}
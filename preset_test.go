@@ -0,0 +1,70 @@
+package datatables
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+func TestFilterPresets(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE active = ?")).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(qm("SELECT * FROM `users` WHERE active = ? LIMIT ?")).
+		WithArgs(true, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+
+	dt := New(db)
+	dt.Model(&User{})
+	dt.RegisterFilter("active_only", func(db *gorm.DB) *gorm.DB {
+		return db.Where("active = ?", true)
+	})
+	dt.RegisterFilter("adults", func(db *gorm.DB) *gorm.DB {
+		return db.Where("age >= ?", 18)
+	})
+	dt.Req(Request{
+		Draw:    1,
+		Length:  10,
+		Filters: []string{"active_only"},
+		Columns: []ColumnRequest{{Name: "name", Data: "name"}},
+	})
+
+	if _, err := dt.Make(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestUnknownFilterPreset(t *testing.T) {
+	dt := New(nil)
+	dt.Model(&User{})
+	dt.Req(Request{Draw: 1, Filters: []string{"drop_everything"}})
+
+	err := dt.Validate()
+	if err == nil || !strings.Contains(err.Error(), `unknown filter "drop_everything"`) {
+		t.Errorf("expected unknown filter error, got %v", err)
+	}
+}
+
+func TestParseRequestFilters(t *testing.T) {
+	r := httptest.NewRequest("GET", "/?draw=1&start=0&length=10&search[regex]=false&filter=active_only&filter=adults", nil)
+
+	req, err := ParseRequest(r)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []string{"active_only", "adults"}
+	if !reflect.DeepEqual(req.Filters, expected) {
+		t.Errorf("expected filters %v, got %v", expected, req.Filters)
+	}
+}
@@ -0,0 +1,58 @@
+package datatables
+
+import (
+	"errors"
+	"slices"
+	"sync"
+)
+
+// columnProfiles holds the column sets registered with RegisterColumns.
+var columnProfiles = struct {
+	sync.RWMutex
+	m map[string][]Column
+}{m: make(map[string][]Column)}
+
+// RegisterColumns registers a named column set, such as "user-admin" or
+// "user-public", that DataTables can attach with UseColumns. Registering a
+// profile again replaces it. It is typically called once at startup.
+func RegisterColumns(name string, columns ...Column) {
+	columnProfiles.Lock()
+	defer columnProfiles.Unlock()
+	columnProfiles.m[name] = slices.Clone(columns)
+}
+
+// RegisterProfiles registers every column profile of the configuration file.
+func (f *ConfigFile) RegisterProfiles() {
+	for name, columns := range f.Profiles {
+		RegisterColumns(name, columns...)
+	}
+}
+
+// UseColumns attaches the registered column profiles with the given names.
+//
+// The columns of the profiles are added to the DataTable, overriding the
+// columns sent in the request, and the response is restricted to them, so
+// different endpoints with different visibility share one source of truth
+// for columns. If a profile is not registered, Make returns an error.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) UseColumns(names ...string) *DataTable {
+	dt.plans = nil
+	columnProfiles.RLock()
+	defer columnProfiles.RUnlock()
+
+	for _, name := range names {
+		columns, ok := columnProfiles.m[name]
+		if !ok {
+			dt.addError(errors.New(dt.translatef(MsgUnknownProfile, "unknown column profile %q", name)))
+			continue
+		}
+		dt.AddColumns(columns...)
+		for _, col := range columns {
+			if !slices.Contains(dt.selectedColumns, col.Data) {
+				dt.selectedColumns = append(dt.selectedColumns, col.Data)
+			}
+		}
+	}
+	return dt
+}
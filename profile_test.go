@@ -0,0 +1,55 @@
+package datatables
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUseColumns(t *testing.T) {
+	RegisterColumns("user-public",
+		Column{Name: "id", Data: "id"},
+		Column{Name: "name", Data: "name", Searchable: true},
+	)
+	RegisterColumns("user-admin",
+		Column{Name: "email", Data: "email", Searchable: true},
+	)
+
+	dt := New(nil)
+	dt.Req(Request{Columns: []ColumnRequest{{Name: "name", Data: "name", Searchable: false}}})
+	dt.UseColumns("user-public", "user-admin")
+
+	if err := dt.err; err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !dt.columnsMap["name"].Searchable {
+		t.Errorf("expected profile column to override request column")
+	}
+	expected := []string{"id", "name", "email"}
+	if !reflect.DeepEqual(dt.selectedColumns, expected) {
+		t.Errorf("expected selected columns %v, got %v", expected, dt.selectedColumns)
+	}
+}
+
+func TestUseColumnsUnknownProfile(t *testing.T) {
+	dt := New(nil)
+	dt.UseColumns("missing")
+
+	err := dt.Validate()
+	if err == nil || !strings.Contains(err.Error(), `unknown column profile "missing"`) {
+		t.Errorf("expected unknown profile error, got %v", err)
+	}
+}
+
+func TestRegisterProfiles(t *testing.T) {
+	file, err := ParseConfig([]byte("profiles:\n  order-public:\n    - {data: total}\n"), FormatYAML)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	file.RegisterProfiles()
+
+	dt := New(nil).UseColumns("order-public")
+	if _, ok := dt.columnsMap["total"]; !ok || dt.err != nil {
+		t.Errorf("expected profile from config file to be usable, got %v", dt.err)
+	}
+}
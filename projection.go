@@ -0,0 +1,91 @@
+package datatables
+
+import (
+	"regexp"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// identifierPattern matches plain and table-qualified column names. Column
+// names that do not match it are treated as SQL expressions.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// projectedColumns returns the SELECT expressions of the data query when
+// column projection is enabled.
+//
+// Every declared column with a Name is selected, restricted to the columns
+// passed to Only when it was called, and leaving out the computed columns,
+// such as the row number column, the columns excluded with Except, denied by
+// the column policy, or blacklisted, and the given skipped columns. The
+// columns coming only from the request are never selected, since their Name
+// is set by the client. A column backed by an SQL expression, or whose Name
+// differs from its Data, is aliased to its Data, and a declared Name that is
+// not a plain column name is selected as a raw expression.
+func (dt *DataTable) projectedColumns(skipped map[string]bool) []clause.Expression {
+	var selected map[string]bool
+	if len(dt.selectedColumns) > 0 {
+		selected = make(map[string]bool, len(dt.selectedColumns))
+		for _, data := range dt.selectedColumns {
+			selected[data] = true
+		}
+	}
+
+	var exprs []clause.Expression
+	for _, col := range dt.columns {
+		if (col.Name == "" && col.SQL == "") || col.Relation != "" || !dt.declaredColumns[col.Data] {
+			continue
+		}
+		if selected != nil && !selected[col.Data] {
+			continue
+		}
+		if dt.isColumnHidden(col.Data) || dt.computedColumns[col.Data] || skipped[col.Data] {
+			continue
+		}
+
+		switch {
+		case col.SQL != "":
+			exprs = append(exprs, clause.Expr{
+				SQL:  "? AS ?",
+				Vars: []any{col.sqlColumn(), clause.Column{Name: col.Data}},
+			})
+		case !identifierPattern.MatchString(col.Name):
+			exprs = append(exprs, clause.Expr{
+				SQL:  "(" + col.Name + ") AS ?",
+				Vars: []any{clause.Column{Name: col.Data}},
+			})
+		case col.Name != col.Data:
+			exprs = append(exprs, clause.Expr{
+				SQL:  "?",
+				Vars: []any{clause.Column{Name: col.Name, Alias: col.Data}},
+			})
+		default:
+			exprs = append(exprs, clause.Expr{
+				SQL:  "?",
+				Vars: []any{clause.Column{Name: col.Name}},
+			})
+		}
+	}
+	return exprs
+}
+
+// applyProjection restricts the SELECT of the data query to the columns of
+// the DataTable when Config.ProjectColumns is enabled, or else to the fields
+// of the model when hidden heavy columns are skipped. Queries that already
+// select columns explicitly are left untouched. Returns the updated query.
+func (dt *DataTable) applyProjection(query *gorm.DB) *gorm.DB {
+	if len(query.Statement.Selects) > 0 {
+		return query
+	}
+	skipped := dt.skippedHeavyColumns()
+	if !dt.config.ProjectColumns {
+		return dt.applyModelProjection(query, skipped)
+	}
+	exprs := dt.currentPlan().projection
+	if len(exprs) == 0 {
+		return query
+	}
+	return query.Clauses(clause.Select{
+		Expression: clause.CommaExpression{Exprs: exprs},
+	})
+}
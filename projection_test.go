@@ -0,0 +1,88 @@
+package datatables
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestProjectColumns(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(2)))
+	mock.ExpectQuery(qm("SELECT `id`, `name` AS `full_name`, (CONCAT(name, '-', age)) AS `label` FROM `users` LIMIT ?")).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "full_name", "label"}).AddRow(1, "John", "John-25"))
+
+	cfg := defaultConfig()
+	cfg.ProjectColumns = true
+	dt := New(db, WithConfig(cfg))
+	dt.Model(&User{})
+	dt.AddColumns(
+		Column{Name: "id", Data: "id"},
+		Column{Name: "name", Data: "full_name"},
+		Column{Name: "CONCAT(name, '-', age)", Data: "label"},
+		Column{Name: "password_hash", Data: "password_hash"},
+		Column{Name: "token", Data: "token"},
+	)
+	dt.WithNumber()
+	dt.Except("password_hash")
+	dt.Only("no", "id", "full_name", "label", "password_hash")
+	dt.Req(Request{Draw: 1, Length: 10})
+
+	if _, err := dt.Make(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestProjectColumnsDisabled(t *testing.T) {
+	dt := New(nil)
+	dt.AddColumn(Column{Name: "id", Data: "id"})
+	if exprs := dt.projectedColumns(nil); len(exprs) != 1 {
+		t.Errorf("expected 1 projected column, got %d", len(exprs))
+	}
+
+	db, _ := newMockDB(t)
+	query := dt.applyProjection(db)
+	if _, ok := query.Statement.Clauses["SELECT"]; ok {
+		t.Errorf("expected no SELECT clause when projection is disabled")
+	}
+}
+
+func TestProjectColumnsHostileName(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+	mock.ExpectQuery(qm("SELECT `id`, `name` FROM `users` LIMIT ?") + "$").
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John"))
+
+	cfg := defaultConfig()
+	cfg.ProjectColumns = true
+	dt := New(db, WithConfig(cfg)).Model(&User{})
+	dt.AddColumns(
+		Column{Name: "id", Data: "id"},
+		Column{Name: "name", Data: "name"},
+	)
+	dt.Req(Request{
+		Draw:   1,
+		Length: 10,
+		Columns: []ColumnRequest{
+			{Data: "id", Name: "id"},
+			{Data: "name", Name: "(SELECT password FROM admins LIMIT 1)"},
+			{Data: "pw", Name: "SELECT password FROM admins LIMIT 1"},
+		},
+	})
+
+	if _, err := dt.Make(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
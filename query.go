@@ -1,6 +1,8 @@
 package datatables
 
 import (
+	"maps"
+	"slices"
 	"strings"
 
 	"gorm.io/gorm"
@@ -23,6 +25,17 @@ func hasHavingClause(db *gorm.DB) bool {
 	return false
 }
 
+// hasGroupByColumns returns true if the query has a GROUP BY clause with at
+// least one column, false otherwise.
+func hasGroupByColumns(db *gorm.DB) bool {
+	c, ok := db.Statement.Clauses[queryGroupBy]
+	if !ok {
+		return false
+	}
+	expr, ok := c.Expression.(clause.GroupBy)
+	return ok && len(expr.Columns) > 0
+}
+
 // hasJoinClause returns true if the query has a JOIN clause, false otherwise.
 func (dt *DataTable) hasJoinClause() bool {
 	return len(dt.tx.Statement.Joins) > 0
@@ -42,7 +55,9 @@ func (dt *DataTable) applyFilters(query *gorm.DB) *gorm.DB {
 // query does not already have a JOIN clause. Returns the updated query.
 func (dt *DataTable) applyRelations(query *gorm.DB) *gorm.DB {
 	if len(dt.relations) > 0 && !dt.hasJoinClause() {
-		query = query.Preload(strings.Join(dt.relations, ","))
+		for _, name := range dt.relationNames() {
+			query = query.Preload(name)
+		}
 	}
 	return query
 }
@@ -51,42 +66,88 @@ func (dt *DataTable) applyRelations(query *gorm.DB) *gorm.DB {
 //
 // The search is performed across all columns defined in the request that are allowed
 // and marked as searchable. The search value can be either a plain text or a regex pattern,
-// and case sensitivity is configurable. If the search value is empty or the search
-// functionality is disabled, the query is returned unmodified. Returns the updated query.
+// and case sensitivity and the collation are configurable. UUID columns are only matched exactly, when
+// the search value is a UUID. If the search value is empty or the search
+// functionality is disabled, the query is returned unmodified. The search
+// expression is built once per request and reused by every query it is
+// applied to. When a Searcher is set with SetSearcher, the search is
+// applied by the searcher instead. Returns the updated query.
 func (dt *DataTable) applySearch(query *gorm.DB) *gorm.DB {
 	if !dt.config.Searchable || dt.req.Search.Value == "" {
 		return query
 	}
+	if dt.searcher != nil {
+		return dt.searcher.Search(query, dt.req.Search, slices.Clone(dt.currentPlan().search))
+	}
 
-	var conditions []clause.Expression
+	if expr := dt.searchExpression(); expr != nil {
+		query = query.Where(expr)
+	}
+	return query
+}
+
+// applyColumnSearches applies the per-column search values of the request
+// to the query, except the one of the column with the given Data, if any.
+// Only the defined, allowed, and searchable columns are searched, with a
+// REGEXP for the columns whose search is a regex and a LIKE otherwise. UUID
+// columns are matched exactly, and match no rows when the search value is
+// not a UUID. Returns the updated query.
+func (dt *DataTable) applyColumnSearches(query *gorm.DB, except string) *gorm.DB {
+	if !dt.config.Searchable {
+		return query
+	}
 	for _, clientCol := range dt.req.Columns {
-		if !dt.isColumnAllowed(clientCol.Data) {
+		if clientCol.Data == except || clientCol.Search.Value == "" || !dt.isColumnAllowed(clientCol.Data) {
+			continue
+		}
+		col, exists := dt.columnsMap[clientCol.Data]
+		if !exists || !col.Searchable || dt.isObfuscated(col.Data) || dt.isMasked(col.Data) {
 			continue
 		}
-		if col, exists := dt.columnsMap[clientCol.Data]; exists && col.Searchable {
-			val := dt.req.Search.Value
-			if dt.config.CaseInsensitive {
-				val = strings.ToLower(val)
+		if col.Type == ColumnTypeUUID {
+			cond, ok := dt.uuidCondition(col, clientCol.Search.Value)
+			if !ok {
+				cond = clause.Expr{SQL: "1 = 0"}
 			}
-			if dt.req.Search.Regex {
-				conditions = append(conditions, clause.Expr{
-					SQL:  "? REGEXP ?",
-					Vars: []any{clause.Column{Name: col.Name}, val},
-				})
-			} else {
-				conditions = append(conditions, clause.Like{
-					Column: clause.Column{Name: col.Name},
-					Value:  "%" + val + "%",
-				})
+			query = query.Where(dt.relationScoped(col, cond))
+			continue
+		}
+		query = query.Where(dt.relationScoped(col, dt.searchCondition(col, clientCol.Search.Value, clientCol.Search.Regex)))
+	}
+	return query
+}
+
+// compiledSearch is the search expression built for a search value.
+type compiledSearch struct {
+	search Search
+	expr   clause.Expression
+}
+
+// searchExpression returns the condition matching the global search value
+// on the searchable columns of the request, or nil if no column can be
+// searched. The expression is cached until the search value changes or the
+// DataTable is prepared again.
+func (dt *DataTable) searchExpression() clause.Expression {
+	if dt.search != nil && dt.search.search == dt.req.Search {
+		return dt.search.expr
+	}
+
+	var conditions []clause.Expression
+	for _, col := range dt.currentPlan().search {
+		if col.Type == ColumnTypeUUID {
+			if cond, ok := dt.uuidCondition(col, dt.req.Search.Value); ok {
+				conditions = append(conditions, dt.relationScoped(col, cond))
 			}
+			continue
 		}
+		conditions = append(conditions, dt.relationScoped(col, dt.searchCondition(col, dt.req.Search.Value, dt.req.Search.Regex)))
 	}
 
+	dt.search = &compiledSearch{search: dt.req.Search}
 	if len(conditions) > 0 {
-		query = query.Where(clause.Or(conditions...))
+		dt.search.expr = clause.Or(conditions...)
 	}
-
-	return query
+	return dt.search.expr
 }
 
 // executeQuery executes the given query and returns the result as a slice of
@@ -94,20 +155,41 @@ func (dt *DataTable) applySearch(query *gorm.DB) *gorm.DB {
 //
 // The function takes a gorm.DB query instance as an argument and executes the
 // query using the Find method. The result is stored in the rawData variable,
-// which is then returned to the caller along with any error that may have
-// occurred. Returns the updated query.
-func (dt *DataTable) executeQuery(query *gorm.DB) ([]map[string]any, error) {
-	var rawData []map[string]any
+// preallocated for size rows, which is then returned to the caller along with
+// any error that may have occurred. When Config.RelationsFormat is set, the
+// query is executed into the model instead so that the preloaded relations
+// are included.
+func (dt *DataTable) executeQuery(query *gorm.DB, size int) ([]map[string]any, error) {
+	if dt.includesRelations() {
+		return dt.executeWithRelations(query)
+	}
+	rawData := make([]map[string]any, 0, size)
 	err := query.Find(&rawData).Error
 	return rawData, err
 }
 
+// pageSize returns the number of rows the data query is expected to return
+// given the filtered count: the rows after the page start, bounded by the
+// page length when paginating.
+func (dt *DataTable) pageSize(filtered int64) int {
+	size := filtered
+	if dt.config.Paginate {
+		size -= int64(dt.req.Start)
+		if dt.req.Length >= 0 {
+			size = min(size, int64(dt.req.Length))
+		}
+	}
+	return int(max(size, 0))
+}
+
 // buildBaseQuery returns a gorm.DB query instance that is the base query used
 // by the DataTable to generate the filtered, sorted, and paginated result set.
 //
 // The query is built by applying the relations specified by the DataTable's
-// relations slice to the query, and then applying the filters specified by
-// the DataTable's Filters method to the query. If the DataTable's model is a
+// relations slice to the query, then the row policies, the filters specified
+// by the DataTable's Filters method, the filter presets selected by the
+// request, the signed filters of the request, and the query comment and
+// optimizer hints. If the DataTable's model is a
 // string, the query is built by using the Select method to select the columns
 // specified by the DataTable's request configuration. Returns the updated query.
 func (dt *DataTable) buildBaseQuery() *gorm.DB {
@@ -128,38 +210,49 @@ func (dt *DataTable) buildBaseQuery() *gorm.DB {
 	} else {
 		query = dt.tx.Model(dt.model)
 	}
+	if len(dt.values) > 0 {
+		query = query.WithContext(dt.context())
+	}
 	query = dt.applyRelations(query)
+	query = dt.applyRowPolicies(query)
 	query = dt.applyFilters(query)
+	query = dt.applyPresets(query)
+	query = dt.applySignedFilters(query)
+	query = dt.applyQueryHints(query)
 	return query
 }
 
 // buildCountQuery creates a new query session for counting records based on
 // the provided baseQuery. If the DataTable configuration specifies Distinct
 // as true, it applies a distinct selection on the "id" field, ensuring that
-// only unique records are counted. Returns the modified query ready for
-// counting the records.
+// only unique records are counted, unless DistinctColumns is set, in which
+// case the distinct combinations of those columns are counted instead.
+// Returns the modified query ready for counting the records.
 func (dt *DataTable) buildCountQuery(baseQuery *gorm.DB) *gorm.DB {
 	countQuery := baseQuery.Session(&gorm.Session{})
 
-	if dt.config.Distinct {
+	if dt.config.Distinct && len(dt.config.DistinctColumns) == 0 {
 		countQuery = countQuery.Distinct("id")
 	}
 
 	return countQuery
 }
 
-// buildFilteredQuery applies the search filter specified by the DataTable's
-// request configuration to the provided base query. If the DataTable's
-// configuration specifies GroupBy, it applies the specified group by clause
-// to the query. If the query already has a group by clause, it replaces it
-// with the new one. If the configuration specifies Having, it applies the
-// specified having conditions to the query. If the query already has a having
-// clause, it replaces it with the new one. Returns the updated query.
+// buildFilteredQuery applies the global and per-column search filters
+// specified by the DataTable's request configuration to the provided base
+// query. If the DataTable's
+// configuration specifies GroupBy and the query is not already grouped by
+// columns, it applies the specified group by clause to the query, replacing
+// an empty one, together with the HAVING conditions of havingConditions. A
+// GROUP BY clause of the base query is kept as is, together with its HAVING
+// conditions and their arguments. Finally, the function set with SetFilteredQuery, if any, is
+// applied. Returns the updated query.
 func (dt *DataTable) buildFilteredQuery(baseQuery *gorm.DB) *gorm.DB {
 	query := baseQuery.Session(&gorm.Session{})
 	query = dt.applySearch(query)
+	query = dt.applyColumnSearches(query, "")
 
-	if len(dt.config.GroupBy) > 0 {
+	if len(dt.config.GroupBy) > 0 && !hasGroupByColumns(query) {
 		if !hasGroupByClause(query) {
 			query = query.Group(strings.Join(dt.config.GroupBy, ", "))
 		} else {
@@ -174,28 +267,57 @@ func (dt *DataTable) buildFilteredQuery(baseQuery *gorm.DB) *gorm.DB {
 				),
 			)
 		}
-		for _, cond := range dt.config.Having {
+		for _, cond := range dt.havingConditions() {
 			if hasHavingClause(query) {
 				delete(query.Statement.Clauses, queryHaving)
 			}
-			query = query.Having(
-				strings.TrimSpace(
-					strings.ReplaceAll(
-						cond,
-						queryHaving,
-						"",
-					),
-				),
-			)
+			query = query.Having(cond.SQL, cond.Vars...)
 		}
 	}
 
+	if dt.filteredQuery != nil {
+		query = dt.filteredQuery(query)
+	}
+
 	return query
 }
 
+// buildDataQuery builds the query used to fetch the data rows of the
+// DataTable. It applies the search, ordering, and pagination on top of the
+// base query and runs the interceptors, exactly like processQuery does
+// before executing it.
+func (dt *DataTable) buildDataQuery() *gorm.DB {
+	return dt.buildFetchQuery(dt.buildFilteredQuery(dt.buildBaseQuery()))
+}
+
+// buildFetchQuery applies the ordering, pagination, and projection on top of
+// the filtered query, then the BeforeQuery hooks and the interceptors of the
+// fetch phase.
+func (dt *DataTable) buildFetchQuery(filteredQuery *gorm.DB) *gorm.DB {
+	query := dt.applyOrder(filteredQuery)
+	query = dt.applyPagination(query)
+	query = dt.applyProjection(query)
+	return dt.intercept(phaseFetch, dt.applyBeforeQuery(query))
+}
+
+// havingConditions returns the HAVING conditions found by checkComplexQuery
+// together with their vars or, if none was found, those of Config.Having.
+func (dt *DataTable) havingConditions() []clause.Expr {
+	if len(dt.having) > 0 {
+		return dt.having
+	}
+	conds := make([]clause.Expr, len(dt.config.Having))
+	for i, cond := range dt.config.Having {
+		conds[i] = clause.Expr{SQL: strings.TrimSpace(strings.ReplaceAll(cond, queryHaving, ""))}
+	}
+	return conds
+}
+
 // getTotalCount executes the count query and returns the total number of records
 // in the table and any error that may have occurred. If the total number of records
-// is already cached, it returns the cached value.
+// is already cached, it returns the cached value. The HAVING conditions of a
+// grouped query are ignored, and the groups are counted with the counter set
+// with SetCounter, if any.
 func (dt *DataTable) getTotalCount(countQuery *gorm.DB) (int64, error) {
 	if dt.totalRecords != nil {
 		return *dt.totalRecords, nil
@@ -206,11 +328,19 @@ func (dt *DataTable) getTotalCount(countQuery *gorm.DB) (int64, error) {
 		if ok {
 			newGroupBy := expr
 			newGroupBy.Having = nil
-			groupByClause.Expression = &newGroupBy
+			groupByClause.Expression = newGroupBy
 			countQuery.Statement.Clauses[queryGroupBy] = groupByClause
 		}
 	}
 
+	if dt.counter != nil && hasGroupByColumns(countQuery) {
+		return dt.counter.Count(countQuery)
+	}
+
+	if len(dt.config.DistinctColumns) > 0 && !hasGroupByColumns(countQuery) {
+		return dt.countDistinct(countQuery)
+	}
+
 	var count int64
 	err := countQuery.Count(&count).Error
 	return count, err
@@ -219,7 +349,10 @@ func (dt *DataTable) getTotalCount(countQuery *gorm.DB) (int64, error) {
 // getFilteredCount executes the filtered query and returns the total number of records
 // in the table that are visible after filtering and any error that may have occurred.
 // If the total number of records is already cached, it returns the cached value.
-// If the query has a GROUP BY clause, it executes a subquery to get the count.
+// If the query is grouped, it counts the groups with the counter set with
+// SetCounter or, by default, by wrapping the filtered query, including its
+// HAVING conditions, in a subquery, so that only the groups matching them
+// are counted.
 func (dt *DataTable) getFilteredCount(filteredQuery *gorm.DB) (int64, error) {
 	if dt.filteredRecords != nil {
 		return *dt.filteredRecords, nil
@@ -227,15 +360,15 @@ func (dt *DataTable) getFilteredCount(filteredQuery *gorm.DB) (int64, error) {
 
 	var count int64
 
-	if len(dt.config.GroupBy) > 0 {
-		subQuery := filteredQuery.Session(&gorm.Session{})
-		subQuery = dt.tx.Select(queryCount).Table("(?) subquery", subQuery)
-		if dt.hasJoinClause() {
-			subQuery.Statement.Joins = nil
+	if len(dt.config.GroupBy) > 0 || hasGroupByColumns(filteredQuery) {
+		if dt.counter != nil {
+			return dt.counter.Count(filteredQuery)
 		}
-		delete(subQuery.Statement.Clauses, queryGroupBy)
-		err := subQuery.Scan(&count).Error
-		return count, err
+		return SubqueryCounter.Count(filteredQuery)
+	}
+
+	if len(dt.config.DistinctColumns) > 0 {
+		return dt.countDistinct(filteredQuery)
 	}
 
 	err := filteredQuery.Count(&count).Error
@@ -246,9 +379,11 @@ func (dt *DataTable) getFilteredCount(filteredQuery *gorm.DB) (int64, error) {
 // to the query. If ordering is disabled in the configuration, the query is returned
 // unmodified. If the configuration specifies a union, it applies a default ordering
 // by the "union_order" column. For each order in the request, it checks if the column
-// is allowed and orderable, and applies the specified order direction. If no order
-// is specified in the request, it applies the default sorting defined in the configuration.
-// Returns the updated query with the applied order.
+// is allowed and orderable, and resolves the specified order direction. If no order
+// of the request can be applied, it resolves the default sorting defined in the
+// configuration, by column Data in lexical order.
+// The resolved orders are applied by the DataTable's orderer, ColumnOrderer by
+// default. Returns the updated query with the applied order.
 func (dt *DataTable) applyOrder(query *gorm.DB) *gorm.DB {
 	if !dt.config.Orderable {
 		return query
@@ -261,119 +396,163 @@ func (dt *DataTable) applyOrder(query *gorm.DB) *gorm.DB {
 		})
 	}
 
+	var orders []ColumnOrder
+	planOrders := dt.currentPlan().orders
 	for _, order := range dt.req.Order {
-		if order.Column >= len(dt.req.Columns) {
-			continue
-		}
-		clientCol := dt.req.Columns[order.Column]
-		if !dt.isColumnAllowed(clientCol.Data) {
-			continue
-		}
-		if col, exists := dt.columnsMap[clientCol.Data]; exists && col.Orderable {
-			dir := strings.ToUpper(order.Dir)
-			if dir != orderAscending && dir != orderDescending {
-				dir = orderAscending
-			}
-			if col.Name != "" {
-				query = query.Order(clause.OrderByColumn{
-					Column: clause.Column{Name: col.Name},
-					Desc:   strings.ToUpper(dir) == orderDescending,
-				})
-			}
+		if o, exists := planOrders[order.Column]; exists {
+			o.Desc = strings.ToUpper(order.Dir) == orderDescending
+			orders = append(orders, o)
 		}
 	}
 
-	if len(dt.req.Order) == 0 && len(dt.config.DefaultSort) > 0 {
-		for name, dir := range dt.config.DefaultSort {
+	if len(orders) == 0 {
+		for _, name := range slices.Sorted(maps.Keys(dt.config.DefaultSort)) {
+			dir := dt.config.DefaultSort[name]
 			if col, exists := dt.columnsMap[name]; exists {
-				colName := col.Name
-				if colName == "" {
-					colName = col.Data
+				if col.Name == "" && col.SQL == "" {
+					col.Name = col.Data
 				}
-				if colName != "" {
-					query = query.Order(clause.OrderByColumn{
-						Column: clause.Column{Name: colName},
-						Desc:   strings.ToUpper(dir) == orderDescending,
+				if col.Relation == "" && (col.Name != "" || col.SQL != "") {
+					orders = append(orders, ColumnOrder{
+						Column:    col,
+						SQLColumn: col.sqlColumn(),
+						Desc:      strings.ToUpper(dir) == orderDescending,
 					})
 				}
 			}
 		}
 	}
 
-	return query
+	orderer := dt.orderer
+	if orderer == nil {
+		orderer = ColumnOrderer
+	}
+	return dt.applyTiebreaker(orderer.Order(query, dt.req, orders))
 }
 
-// applyPagination applies pagination to the query if the DataTable's config
-// has pagination enabled. Returns the updated query.
+// applyPagination applies pagination to the query with the DataTable's
+// paginator, OffsetPaginator by default, if the DataTable's config has
+// pagination enabled. Returns the updated query.
 func (dt *DataTable) applyPagination(query *gorm.DB) *gorm.DB {
-	if dt.config.Paginate {
-		query = query.Offset(dt.req.Start).Limit(dt.req.Length)
+	if !dt.config.Paginate {
+		return query
 	}
-	return query
+	if dt.paginator == nil {
+		return OffsetPaginator.Paginate(query, dt.req)
+	}
+	return dt.paginator.Paginate(query, dt.req)
 }
 
 // checkComplexQuery inspects the DataTable's query to determine if it contains
 // UNION, DISTINCT, GROUP BY, or HAVING clauses. It sets the appropriate flags
 // in the DataTable's config field to indicate the presence of these clauses.
 //
-// Note that this function does not actually execute the query. It uses
-// GORM's DryRun feature to generate the SQL without executing it.
+// The clauses of the query are inspected directly, without building its SQL.
+// Only the SQL of a raw statement is scanned, for the keywords outside of its
+// string literals and quoted identifiers. Flags set in the config are never
+// cleared. The HAVING conditions found are kept with their vars, and take
+// precedence over Config.Having. Nothing is inspected when
+// Config.SkipQueryInspection is set.
 func (dt *DataTable) checkComplexQuery() {
-	var result []map[string]any
-	tx := dt.tx.Session(&gorm.Session{DryRun: true}).Find(&result)
-
-	sql := tx.Statement.SQL.String()
-	sql = strings.ToUpper(sql)
-
-	if strings.Contains(sql, queryUnion) {
-		dt.config.Union = true
+	dt.having = nil
+	if dt.config.SkipQueryInspection || dt.tx == nil || dt.tx.Statement == nil {
+		return
+	}
+	if sql := dt.tx.Statement.SQL.String(); sql != "" {
+		dt.inspectRawSQL(sql, dt.tx.Statement.Vars)
+		return
 	}
+	dt.inspectClauses()
+}
 
-	if strings.Contains(sql, queryDistinct) {
-		dt.config.Distinct = true
+// prepare inspects the DataTable's query and model before the queries are
+// built. It detects complex query clauses, UUID, citext, and computed
+// columns, and evaluates the column policy. The search expression cached by
+// a previous run is dropped, since the columns may have changed. The clauses
+// of a raw base query set with FromRaw are not inspected.
+func (dt *DataTable) prepare() {
+	dt.search = nil
+	dt.plan = nil
+	if !dt.rawBase {
+		dt.checkComplexQuery()
 	}
+	dt.detectUUIDColumns()
+	dt.detectCITextColumns()
+	dt.detectComputedColumns()
+	dt.applyColumnPolicy()
+}
 
-	if groupByIndex := strings.Index(sql, queryGroupBy); groupByIndex != -1 {
-		endIndex := len(sql)
-		if havingIndex := strings.Index(sql, queryHaving); havingIndex != -1 {
-			endIndex = havingIndex
-		}
-		groupByClause := sql[groupByIndex:endIndex]
-		dt.config.GroupBy = extractFields(groupByClause)
+// countRecords runs the total and filtered count queries and returns their
+// results. When the filtered query cannot filter out rows, the filtered
+// count query is skipped and the total count is reused.
+func (dt *DataTable) countRecords(countQuery, filteredQuery *gorm.DB) (int64, int64, error) {
+	ctx, p := dt.beginPhase(phaseCountTotal)
+	total, err := dt.getTotalCount(dt.intercept(phaseCountTotal, countQuery).WithContext(ctx))
+	p.end(err, "count", total)
+	if err != nil {
+		return 0, 0, err
+	}
+	if dt.filteredRecords == nil && !dt.filtersRows(countQuery) {
+		return total, total, nil
 	}
 
-	if havingIndex := strings.Index(sql, queryHaving); havingIndex != -1 {
-		havingClause := sql[havingIndex:]
-		dt.config.Having = extractFields(havingClause)
+	ctx, p = dt.beginPhase(phaseCountFiltered)
+	filtered, err := dt.getFilteredCount(dt.intercept(phaseCountFiltered, filteredQuery).WithContext(ctx))
+	p.end(err, "count", filtered)
+	if err != nil {
+		return 0, 0, err
 	}
+
+	return total, filtered, nil
 }
 
 // processQuery processes the DataTable's query by executing several steps to retrieve the data.
-// It first checks for complex query clauses like UNION, DISTINCT, GROUP BY, and HAVING.
+// It first resolves the request cursor, if any, into the page start, and checks for complex
+// query clauses like UNION, DISTINCT, GROUP BY, and HAVING.
 // Then, it builds the base query and creates a count and filtered query from it.
 // The function retrieves the total record count and the filtered record count,
 // applies ordering and pagination, and finally executes the query to get the data.
+// The columns denied by the column policy are removed and the masked columns
+// are masked before the AfterQuery hooks are called.
 // Returns the raw data, total record count, filtered record count, and any error encountered.
 func (dt *DataTable) processQuery() (any, int64, int64, error) {
-	dt.checkComplexQuery()
+	if err := dt.applyCursor(); err != nil {
+		return nil, 0, 0, err
+	}
+	dt.prepare()
 	baseQuery := dt.buildBaseQuery()
+	if dt.searchesInMemory() {
+		return dt.processInMemorySearch(baseQuery)
+	}
 	countQuery := dt.buildCountQuery(baseQuery)
 	filteredQuery := dt.buildFilteredQuery(baseQuery)
 
-	total, err := dt.getTotalCount(countQuery)
+	total, filtered, err := dt.countRecords(countQuery, filteredQuery)
 	if err != nil {
 		return nil, 0, 0, err
 	}
 
-	filtered, err := dt.getFilteredCount(filteredQuery)
+	if len(dt.summaryAggs) > 0 {
+		if dt.summary, err = dt.getSummary(filteredQuery); err != nil {
+			return nil, 0, 0, err
+		}
+	}
+
+	query := dt.buildFetchQuery(filteredQuery)
+	ctx, p := dt.beginPhase(phaseFetch)
+	rawData, err := dt.executeQuery(query.WithContext(ctx), dt.pageSize(filtered))
+	p.end(err, "rows", int64(len(rawData)))
 	if err != nil {
 		return nil, 0, 0, err
 	}
+	dt.verifyPage(query, rawData)
+	if p, ok := dt.paginator.(CursorPaginator); ok && dt.config.Paginate {
+		dt.pageCursor = p.NextCursor(dt.req, rawData)
+	}
+	dt.removeDeniedColumns(rawData)
+	dt.applyMasks(rawData)
 
-	query := dt.applyOrder(filteredQuery)
-	query = dt.applyPagination(query)
-	rawData, err := dt.executeQuery(query)
-	if err != nil {
+	if err := dt.runAfterQuery(total, filtered, rawData); err != nil {
 		return nil, 0, 0, err
 	}
 
@@ -388,3 +567,47 @@ func (dt *DataTable) Raw() (any, error) {
 	data, _, _, err := dt.processQuery()
 	return data, err
 }
+
+// RawFull works like Raw but also returns the total and filtered record
+// counts computed along the way, for callers building a custom response
+// envelope.
+func (dt *DataTable) RawFull() (data any, recordsTotal, recordsFiltered int64, err error) {
+	return dt.processQuery()
+}
+
+// Counts validates the DataTable and runs only the total and filtered count
+// queries, honoring the filters and the search, without fetching any data.
+// It is meant for badge counters and "X results" headers that don't need
+// rows.
+func (dt *DataTable) Counts() (recordsTotal, recordsFiltered int64, err error) {
+	if err := dt.Validate(); err != nil {
+		return 0, 0, err
+	}
+	dt.prepare()
+	baseQuery := dt.buildBaseQuery()
+	return dt.countRecords(dt.buildCountQuery(baseQuery), dt.buildFilteredQuery(baseQuery))
+}
+
+// filtersRows reports whether the filtered count may differ from the total
+// count of the given count query: the request has a global or per-column
+// search, the query is grouped, the total count is counted differently or
+// set with SetTotalRecords, a function is set with SetFilteredQuery, or
+// interceptors are set with Use, which may scope the filtered count.
+func (dt *DataTable) filtersRows(countQuery *gorm.DB) bool {
+	if dt.totalRecords != nil || dt.filteredQuery != nil || len(dt.interceptors) > 0 || dt.config.Distinct ||
+		len(dt.config.GroupBy) > 0 || hasGroupByClause(countQuery) {
+		return true
+	}
+	if !dt.config.Searchable {
+		return false
+	}
+	if dt.req.Search.Value != "" {
+		return true
+	}
+	for _, col := range dt.req.Columns {
+		if col.Search.Value != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package datatables
 
 import (
 	"database/sql/driver"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -342,7 +343,7 @@ func TestExecuteQuery(t *testing.T) {
 
 			dt := New(db)
 			query := dt.tx.Model(&User{})
-			result, err := dt.executeQuery(query)
+			result, err := dt.executeQuery(query, 0)
 
 			if err != tt.expectedErr {
 				t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
@@ -542,6 +543,7 @@ func TestBuildFilteredQuery(t *testing.T) {
 	tests := []struct {
 		name           string
 		config         Config
+		having         []clause.Expr
 		searchFilter   string
 		expectedQuery  string
 		expectedArgs   []driver.Value
@@ -564,6 +566,24 @@ func TestBuildFilteredQuery(t *testing.T) {
 				{"id": 2, "name": "John Smith", "age": 30, "group": "B"},
 			},
 		},
+		{
+			name: "with_inspected_having",
+			config: Config{
+				Searchable: true,
+				Orderable:  true,
+				Paginate:   true,
+				GroupBy:    []string{"group"},
+				Having:     []string{"COUNT(*) > 1"},
+			},
+			having:        []clause.Expr{{SQL: "COUNT(*) > ?", Vars: []any{2}}},
+			searchFilter:  "`name` LIKE ?",
+			expectedQuery: "SELECT * FROM `users` WHERE `name` LIKE ? GROUP BY `group` HAVING COUNT(*) > ?",
+			expectedArgs:  []driver.Value{"%John%", 2},
+			expectedResult: []map[string]any{
+				{"id": 1, "name": "John Doe", "age": 25, "group": "A"},
+				{"id": 2, "name": "John Smith", "age": 30, "group": "B"},
+			},
+		},
 		{
 			name: "without_group_by_and_having",
 			config: Config{
@@ -591,6 +611,7 @@ func TestBuildFilteredQuery(t *testing.T) {
 
 			dt := New(db)
 			dt.config = tt.config
+			dt.having = tt.having
 
 			baseQuery := dt.tx.Model(&User{}).Where(tt.searchFilter, "%John%")
 
@@ -855,6 +876,19 @@ func TestApplyOrder(t *testing.T) {
 			defaultSort: map[string]string{"age": "DESC"},
 			mockQuery:   "SELECT * FROM `users` ORDER BY `age` DESC",
 		},
+		{
+			name:        "with_default_sorting_by_data",
+			orderable:   true,
+			defaultSort: map[string]string{"name": "ASC", "age": "DESC"},
+			mockQuery:   "SELECT * FROM `users` ORDER BY `age` DESC,`name`",
+		},
+		{
+			name:        "with_default_sorting_for_unresolved_order",
+			orderable:   true,
+			order:       []Order{{Column: 5, Dir: "ASC"}},
+			defaultSort: map[string]string{"age": "DESC"},
+			mockQuery:   "SELECT * FROM `users` ORDER BY `age` DESC",
+		},
 		{
 			name:      "invalid_column_len",
 			orderable: true,
@@ -881,6 +915,7 @@ func TestApplyOrder(t *testing.T) {
 			dt.config.Union = tt.union
 			dt.req.Order = tt.order
 			dt.config.DefaultSort = tt.defaultSort
+			dt.plan = nil
 			dt.req.Columns = []ColumnRequest{
 				{Data: "name"},
 				{Data: "age"},
@@ -1016,10 +1051,12 @@ func TestCheckComplexQuery(t *testing.T) {
 	tests := []struct {
 		name     string
 		query    string
+		build    func(db *gorm.DB) *gorm.DB
+		skip     bool
 		union    bool
 		distinct bool
 		groupBy  []string
-		having   []string
+		having   []clause.Expr
 	}{
 		{
 			name:  "without_complex_clauses",
@@ -1038,20 +1075,67 @@ func TestCheckComplexQuery(t *testing.T) {
 		{
 			name:    "with_group_by",
 			query:   "SELECT age FROM users GROUP BY age",
-			groupBy: []string{"GROUP BY AGE"},
+			groupBy: []string{"age"},
 		},
 		{
 			name:    "with_group_by_and_having",
 			query:   "SELECT age FROM users GROUP BY age HAVING COUNT(*) > 1",
-			groupBy: []string{"GROUP BY AGE"},
-			having:  []string{"*"},
+			groupBy: []string{"age"},
+			having:  []clause.Expr{{SQL: "COUNT(*) > 1"}},
+		},
+		{
+			name: "with_having_vars",
+			build: func(db *gorm.DB) *gorm.DB {
+				return db.Raw("SELECT age FROM users WHERE name <> '?' AND age > ? GROUP BY age HAVING COUNT(*) > ? ORDER BY age LIMIT ?", 18, 1, 10)
+			},
+			groupBy: []string{"age"},
+			having:  []clause.Expr{{SQL: "COUNT(*) > ?", Vars: []any{1}}},
+		},
+		{
+			name:    "with_group_by_and_order",
+			query:   "SELECT age, name FROM users GROUP BY age, name ORDER BY age",
+			groupBy: []string{"age", "name"},
+		},
+		{
+			name:  "with_keyword_column_names",
+			query: "SELECT `union`, distinct_count, is_distinct FROM users WHERE name = 'union all'",
+		},
+		{
+			name:     "with_clauses",
+			build:    func(db *gorm.DB) *gorm.DB { return db.Table("users").Distinct("name") },
+			distinct: true,
+		},
+		{
+			name: "with_group_by_clauses",
+			build: func(db *gorm.DB) *gorm.DB {
+				return db.Table("users").Select("age").Group("age").Having("COUNT(*) > ?", 1)
+			},
+			groupBy: []string{"age"},
+			having:  []clause.Expr{{SQL: "COUNT(*) > ?", Vars: []any{1}}},
+		},
+		{
+			name: "with_union_table",
+			build: func(db *gorm.DB) *gorm.DB {
+				return db.Table("(?) AS u", db.Raw("SELECT id FROM users UNION SELECT id FROM admins"))
+			},
+			union: true,
+		},
+		{
+			name:  "with_inspection_skipped",
+			query: "SELECT DISTINCT age FROM users GROUP BY age",
+			skip:  true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			dt := New(db)
-			dt.tx = dt.tx.Raw(tt.query)
+			dt.config.SkipQueryInspection = tt.skip
+			if tt.build != nil {
+				dt.tx = tt.build(dt.tx)
+			} else {
+				dt.tx = dt.tx.Raw(tt.query)
+			}
 
 			dt.checkComplexQuery()
 
@@ -1067,8 +1151,8 @@ func TestCheckComplexQuery(t *testing.T) {
 				t.Errorf("expected GroupBy=%v, got %v", tt.groupBy, dt.config.GroupBy)
 			}
 
-			if !reflect.DeepEqual(dt.config.Having, tt.having) {
-				t.Errorf("expected Having=%v, got %v", tt.having, dt.config.Having)
+			if !reflect.DeepEqual(dt.having, tt.having) {
+				t.Errorf("expected having=%v, got %v", tt.having, dt.having)
 			}
 		})
 	}
@@ -1369,3 +1453,279 @@ func TestHasHavingClause(t *testing.T) {
 		})
 	}
 }
+
+func TestRawFull(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(25))
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE `name` LIKE ?")).
+		WithArgs("%John%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(qm("SELECT * FROM `users` WHERE `name` LIKE ? LIMIT ?")).
+		WithArgs("%John%", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(1, "John Doe").
+			AddRow(2, "John Smith"))
+
+	dt := New(db).Model(&User{})
+	dt.Req(Request{
+		Draw:    1,
+		Length:  10,
+		Search:  Search{Value: "John"},
+		Columns: []ColumnRequest{{Name: "name", Data: "name", Searchable: true}},
+	})
+
+	data, total, filtered, err := dt.RawFull()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if total != 25 || filtered != 2 {
+		t.Errorf("expected counts 25 and 2, got %d and %d", total, filtered)
+	}
+	if rows := data.([]map[string]any); len(rows) != 2 {
+		t.Errorf("expected 2 rows, got %d", len(rows))
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestCounts(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE active = ?")).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(25))
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE active = ? AND `name` LIKE ?")).
+		WithArgs(true, "%John%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	dt := New(db).Model(&User{})
+	dt.Filter(func(db *gorm.DB) *gorm.DB { return db.Where("active = ?", true) })
+	dt.Req(Request{
+		Draw:    1,
+		Search:  Search{Value: "John"},
+		Columns: []ColumnRequest{{Name: "name", Data: "name", Searchable: true}},
+	})
+
+	total, filtered, err := dt.Counts()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if total != 25 || filtered != 2 {
+		t.Errorf("expected counts 25 and 2, got %d and %d", total, filtered)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+
+	if _, _, err := New(db).Counts(); err == nil {
+		t.Errorf("expected validation error, got nil")
+	}
+}
+
+func TestGroupedFilteredCount(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         func(*gorm.DB) *gorm.DB
+		totalQuery    string
+		filteredQuery string
+		filteredArgs  []driver.Value
+	}{
+		{
+			name: "grouped",
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Model(&User{}).Group("name")
+			},
+			totalQuery:    "SELECT count(*) FROM `users` GROUP BY `name`",
+			filteredQuery: "SELECT COUNT(*) AS count FROM (SELECT * FROM `users` WHERE `name` LIKE ? GROUP BY `name`) subquery",
+			filteredArgs:  []driver.Value{"%John%"},
+		},
+		{
+			name: "grouped_having",
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Model(&User{}).Where("id > ?", 0).Group("name").Having("COUNT(*) > ?", 1)
+			},
+			totalQuery:    "SELECT count(*) FROM `users` WHERE id > ? GROUP BY `name`",
+			filteredQuery: "SELECT COUNT(*) AS count FROM (SELECT * FROM `users` WHERE id > ? AND `name` LIKE ? GROUP BY `name` HAVING COUNT(*) > ?) subquery",
+			filteredArgs:  []driver.Value{0, "%John%", 1},
+		},
+		{
+			name: "grouped_having_joined",
+			query: func(db *gorm.DB) *gorm.DB {
+				return db.Model(&User{}).
+					Joins("JOIN profiles ON profiles.user_id = users.id").
+					Group("name").
+					Having("COUNT(profiles.id) > ?", 2)
+			},
+			totalQuery:    "SELECT count(*) FROM `users` JOIN profiles ON profiles.user_id = users.id GROUP BY `name`",
+			filteredQuery: "SELECT COUNT(*) AS count FROM (SELECT `users`.`id`,`users`.`name` FROM `users` JOIN profiles ON profiles.user_id = users.id WHERE `name` LIKE ? GROUP BY `name` HAVING COUNT(profiles.id) > ?) subquery",
+			filteredArgs:  []driver.Value{"%John%", 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+
+			mock.ExpectQuery(qm(tt.totalQuery)).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1).AddRow(1).AddRow(1))
+			mock.ExpectQuery(qm(tt.filteredQuery)).
+				WithArgs(tt.filteredArgs...).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+			dt := New(tt.query(db))
+			dt.Req(Request{
+				Draw:    1,
+				Search:  Search{Value: "John"},
+				Columns: []ColumnRequest{{Name: "name", Data: "name", Searchable: true}},
+			})
+			if err := dt.Validate(); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			dt.prepare()
+			baseQuery := dt.buildBaseQuery()
+
+			total, filtered, err := dt.countRecords(dt.buildCountQuery(baseQuery), dt.buildFilteredQuery(baseQuery))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if total != 3 || filtered != 2 {
+				t.Errorf("expected counts 3 and 2, got %d and %d", total, filtered)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func BenchmarkApplySearch(b *testing.B) {
+	db, _ := newMockDB(b)
+	dt := New(db).Model(&User{})
+	req := Request{Draw: 1, Search: Search{Value: "John"}}
+	for i := range 50 {
+		data := fmt.Sprintf("col_%d", i)
+		req.Columns = append(req.Columns, ColumnRequest{Name: data, Data: data, Searchable: true})
+	}
+	dt.Req(req)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		dt.applySearch(db.Model(&User{}))
+		dt.applySearch(db.Model(&User{}))
+	}
+}
+
+func TestSkipFilteredCount(t *testing.T) {
+	const total = "SELECT count(*) FROM `users`"
+	tests := []struct {
+		name         string
+		setup        func(dt *DataTable)
+		search       string
+		columnSearch string
+		queries      []string
+		filtered     int64
+	}{
+		{name: "no_search", queries: []string{total}, filtered: 5},
+		{name: "global_search", search: "John", queries: []string{total, total + " WHERE `name` LIKE ?"}, filtered: 2},
+		{name: "column_search", columnSearch: "John", queries: []string{total, total + " WHERE `name` LIKE ?"}, filtered: 2},
+		{name: "filtered_query", setup: func(dt *DataTable) {
+			dt.SetFilteredQuery(func(q *gorm.DB) *gorm.DB { return q.Where("active = ?", true) })
+		}, queries: []string{total, total + " WHERE active = ?"}, filtered: 2},
+		{name: "interceptor", setup: func(dt *DataTable) {
+			dt.Use(func(phase string, q *gorm.DB) *gorm.DB {
+				if phase == PhaseCountFiltered {
+					return q.Where("tenant_id = ?", 7)
+				}
+				return q
+			})
+		}, queries: []string{total, total + " WHERE tenant_id = ?"}, filtered: 2},
+		{name: "not_searchable", search: "John", setup: func(dt *DataTable) {
+			cfg := defaultConfig()
+			cfg.Searchable = false
+			dt.SetConfig(cfg)
+		}, queries: []string{total}, filtered: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			mock.ExpectQuery(qm(tt.queries[0]) + "$").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(5)))
+			for _, query := range tt.queries[1:] {
+				mock.ExpectQuery(qm(query) + "$").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(2)))
+			}
+
+			dt := New(db).Model(&User{})
+			if tt.setup != nil {
+				tt.setup(dt)
+			}
+			dt.Req(Request{
+				Draw:   1,
+				Search: Search{Value: tt.search},
+				Columns: []ColumnRequest{
+					{Name: "name", Data: "name", Searchable: true, Search: Search{Value: tt.columnSearch}},
+				},
+			})
+
+			total, filtered, err := dt.Counts()
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if total != 5 || filtered != tt.filtered {
+				t.Errorf("expected counts 5 and %d, got %d and %d", tt.filtered, total, filtered)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestApplyColumnSearches(t *testing.T) {
+	tests := []struct {
+		name   string
+		search Search
+		sql    string
+		arg    driver.Value
+	}{
+		{name: "like", search: Search{Value: "John"}, sql: "SELECT count(*) FROM `users` WHERE `name` LIKE ?", arg: "%John%"},
+		{name: "regex", search: Search{Value: "^J(ohn|ane)$", Regex: true}, sql: "SELECT count(*) FROM `users` WHERE `name` REGEXP ?", arg: "^J(ohn|ane)$"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			mock.ExpectQuery(qm("SELECT count(*) FROM `users`") + "$").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(5)))
+			mock.ExpectQuery(qm(tt.sql)).
+				WithArgs(tt.arg).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(2)))
+
+			cfg := defaultConfig()
+			cfg.CaseInsensitive = false
+			dt := New(db, WithConfig(cfg)).Model(&User{})
+			dt.Req(Request{
+				Draw: 1,
+				Columns: []ColumnRequest{
+					{Name: "id", Data: "id", Searchable: true},
+					{Name: "name", Data: "name", Searchable: true, Search: tt.search},
+				},
+			})
+
+			total, filtered, err := dt.Counts()
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if total != 5 || filtered != 2 {
+				t.Errorf("expected counts 5 and 2, got %d and %d", total, filtered)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package datatables
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// rawAlias is the alias of the derived table wrapping a raw base query.
+const rawAlias = "datatables_base"
+
+// FromRaw uses the given raw SQL query, with its bound arguments, as the base
+// of the DataTable:
+//
+//	dt.FromRaw("SELECT o.*, c.name AS customer FROM orders o JOIN customers c ON c.id = o.customer_id WHERE o.tenant_id = ?", tenantID)
+//
+// The query is wrapped as a derived table, so the search, ordering,
+// pagination, and counts compose over its result columns. Clauses such as
+// GROUP BY or UNION inside the raw query are not inspected, since they are
+// encapsulated by the derived table. FromRaw replaces the model.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) FromRaw(sql string, args ...any) *DataTable {
+	if dt.tx == nil {
+		dt.addError(errors.New(dt.translate(MsgNoTxOrModel, "no tx or model provided")))
+		return dt
+	}
+	dt.fromDerived(dt.tx.Session(&gorm.Session{NewDB: true}).Raw(sql, args...))
+	return dt
+}
+
+// fromDerived uses the given query, wrapped as a derived table, as the base
+// of the DataTable, replacing the model.
+func (dt *DataTable) fromDerived(query *gorm.DB) {
+	dt.tx = dt.tx.Table("(?) AS "+rawAlias, query)
+	dt.model = rawAlias
+	dt.rawBase = true
+}
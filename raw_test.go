@@ -0,0 +1,49 @@
+package datatables
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestFromRaw(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	base := "(SELECT orders.id, customers.name AS customer FROM orders JOIN customers ON customers.id = orders.customer_id WHERE orders.tenant_id = ? GROUP BY orders.id) AS datatables_base"
+	mock.ExpectQuery(qm("SELECT count(*) FROM " + base)).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(3)))
+	mock.ExpectQuery(qm("SELECT count(*) FROM "+base+" WHERE `customer` LIKE ?")).
+		WithArgs(7, "%John%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+	mock.ExpectQuery(qm("SELECT * FROM "+base+" WHERE `customer` LIKE ? ORDER BY `customer` DESC LIMIT ?")).
+		WithArgs(7, "%John%", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "customer"}).AddRow(1, "John"))
+
+	dt := New(db).FromRaw("SELECT orders.id, customers.name AS customer FROM orders JOIN customers ON customers.id = orders.customer_id WHERE orders.tenant_id = ? GROUP BY orders.id", 7)
+	dt.Req(Request{
+		Draw:    1,
+		Length:  10,
+		Search:  Search{Value: "John"},
+		Order:   []Order{{Column: 0, Dir: "desc"}},
+		Columns: []ColumnRequest{{Name: "customer", Data: "customer", Searchable: true, Orderable: true}},
+	})
+
+	res, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res["recordsFiltered"] != int64(1) {
+		t.Errorf("expected 1 filtered record, got %v", res["recordsFiltered"])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestFromRawWithoutTx(t *testing.T) {
+	dt := New(nil).FromRaw("SELECT 1")
+	if err := dt.Validate(); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
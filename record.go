@@ -0,0 +1,151 @@
+package datatables
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// recordingKey is the response key of the ID of the request recording.
+const recordingKey = "recordingId"
+
+// ErrRecordingNotFound is returned by a RecordingStore when no recording
+// exists with the requested ID.
+var ErrRecordingNotFound = errors.New("recording not found")
+
+// Recording is a DataTable request captured by Record, to reproduce it
+// later with Replay.
+//
+// Fields:
+//   - ID: The identifier assigned by the store.
+//   - Table: The table the request was made on.
+//   - Request: The request as received, before the cursor and the page
+//     length rules are applied.
+//   - Config: The configuration the request was processed with.
+//   - CreatedAt: When the request was recorded.
+type Recording struct {
+	ID        string
+	Table     string
+	Request   Request
+	Config    Config
+	CreatedAt time.Time
+}
+
+// RecordingStore persists recordings. Implementations must be safe for
+// concurrent use.
+type RecordingStore interface {
+	// Save stores the recording, assigning its ID if empty, and returns the
+	// stored value.
+	Save(ctx context.Context, recording Recording) (Recording, error)
+	// Get returns the recording with the given ID, or ErrRecordingNotFound.
+	Get(ctx context.Context, id string) (Recording, error)
+}
+
+// memoryRecordingStore is an in-memory RecordingStore.
+type memoryRecordingStore struct {
+	mu         sync.RWMutex
+	recordings map[string]Recording
+}
+
+// NewMemoryRecordingStore returns a RecordingStore keeping the recordings in
+// memory, suitable for tests and development.
+func NewMemoryRecordingStore() RecordingStore {
+	return &memoryRecordingStore{recordings: make(map[string]Recording)}
+}
+
+// Save implements RecordingStore.
+func (s *memoryRecordingStore) Save(ctx context.Context, recording Recording) (Recording, error) {
+	if recording.ID == "" {
+		id := make([]byte, 8)
+		if _, err := rand.Read(id); err != nil {
+			return Recording{}, err
+		}
+		recording.ID = hex.EncodeToString(id)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recordings[recording.ID] = recording
+	return recording, nil
+}
+
+// Get implements RecordingStore.
+func (s *memoryRecordingStore) Get(ctx context.Context, id string) (Recording, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	recording, ok := s.recordings[id]
+	if !ok {
+		return Recording{}, ErrRecordingNotFound
+	}
+	return recording, nil
+}
+
+// Record enables the recording of the DataTable requests to the store.
+//
+// Each Make call saves the request together with the configuration it is
+// processed with, and adds the ID of the recording to the response under
+// the recordingId key, so that a report of wrong data can quote it and the
+// request be reproduced exactly with Replay. A failure to save the recording
+// is logged and does not fail the request.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) Record(store RecordingStore) *DataTable {
+	dt.recordings = store
+	return dt
+}
+
+// record saves the current request to the recording store, if any, and
+// keeps the ID of the recording for the response.
+func (dt *DataTable) record() {
+	dt.recordingID = ""
+	if dt.recordings == nil || dt.replaying {
+		return
+	}
+
+	start := time.Now()
+	recording, err := dt.recordings.Save(dt.context(), Recording{
+		Table:     dt.tableName(),
+		Request:   dt.req,
+		Config:    dt.config,
+		CreatedAt: start,
+	})
+	dt.logPhase(phaseRecord, start, err)
+	if err == nil {
+		dt.recordingID = recording.ID
+	}
+}
+
+// Replay loads the recording with the given ID from the store set with
+// Record and processes it again on the DataTable, replacing its request.
+// The recorded configuration is used for the replay only, the configuration
+// of the DataTable is restored afterwards. The DataTable must be defined
+// like the one the request was recorded on: same model, columns, filters,
+// and hooks, which cannot be recorded. The replayed request is not recorded
+// again.
+//
+// Returns an error if no store is set, or the recording cannot be loaded or
+// belongs to another table.
+func (dt *DataTable) Replay(id string) (map[string]any, error) {
+	if dt.recordings == nil {
+		return nil, errors.New(dt.translate(MsgNoRecordingStore, "no recording store, see Record"))
+	}
+	recording, err := dt.recordings.Get(dt.context(), id)
+	if err != nil {
+		return nil, err
+	}
+	if table := dt.tableName(); recording.Table != "" && table != "" && recording.Table != table {
+		return nil, ErrRecordingNotFound
+	}
+
+	config := dt.config
+	dt.SetConfig(recording.Config)
+	dt.Req(recording.Request)
+	dt.replaying = true
+	defer func() {
+		dt.replaying = false
+		dt.SetConfig(config)
+	}()
+	return dt.Make()
+}
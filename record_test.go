@@ -0,0 +1,91 @@
+package datatables
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestRecordReplay(t *testing.T) {
+	store := NewMemoryRecordingStore()
+	expect := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+		mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE LOWER(`name`) LIKE LOWER(?)")).
+			WithArgs("%John%").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(qm("SELECT * FROM `users` WHERE LOWER(`name`) LIKE LOWER(?) ORDER BY `name` DESC LIMIT ?")).
+			WithArgs("%John%", 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+	}
+
+	db, mock := newMockDB(t)
+	expect(mock)
+	config := defaultConfig()
+	config.CaseInsensitive = true
+	config.LowerColumns = true
+	dt := New(db).Model(&User{}).SetConfig(config).Record(store).Req(Request{
+		Draw:    1,
+		Length:  10,
+		Search:  Search{Value: "John"},
+		Order:   []Order{{Column: 0, Dir: "desc"}},
+		Columns: []ColumnRequest{{Name: "name", Data: "name", Searchable: true, Orderable: true}},
+	})
+
+	response, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	id, _ := response[recordingKey].(string)
+	if id == "" {
+		t.Fatalf("expected a recording id in %v", response)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		expect(mock)
+
+		dt := New(db).Model(&User{}).Record(store)
+		replayed, err := dt.Replay(id)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !reflect.DeepEqual(dt.config, defaultConfig()) {
+			t.Errorf("expected the configuration to be restored, got %+v", dt.config)
+		}
+		if _, ok := replayed[recordingKey]; ok {
+			t.Errorf("expected replayed request not to be recorded, got %v", replayed)
+		}
+		if replayed["recordsFiltered"] != int64(1) {
+			t.Errorf("expected 1 filtered record, got %v", replayed["recordsFiltered"])
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet expectations: %v", err)
+		}
+	})
+
+	t.Run("unknown_id", func(t *testing.T) {
+		db, _ := newMockDB(t)
+		if _, err := New(db).Model(&User{}).Record(store).Replay("nope"); !errors.Is(err, ErrRecordingNotFound) {
+			t.Errorf("expected ErrRecordingNotFound, got %v", err)
+		}
+	})
+
+	t.Run("other_table", func(t *testing.T) {
+		db, _ := newMockDB(t)
+		if _, err := New(db).Model("orders").Record(store).Replay(id); !errors.Is(err, ErrRecordingNotFound) {
+			t.Errorf("expected ErrRecordingNotFound, got %v", err)
+		}
+	})
+
+	t.Run("no_store", func(t *testing.T) {
+		if _, err := New(db).Model(&User{}).Replay(id); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
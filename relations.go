@@ -0,0 +1,119 @@
+package datatables
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Formats of the preloaded relations in the rows, selected with
+// Config.RelationsFormat.
+const (
+	RelationsNested = "nested" // Each relation is an object, or a list of objects, under its own key.
+	RelationsFlat   = "flat"   // The fields of a single relation are prefixed with its key.
+)
+
+// includesRelations reports whether the rows are fetched as model structs so
+// that the relations preloaded with With are included in the response.
+func (dt *DataTable) includesRelations() bool {
+	if dt.config.RelationsFormat == "" || len(dt.relations) == 0 || dt.model == nil {
+		return false
+	}
+	_, ok := dt.model.(string)
+	return !ok
+}
+
+// executeWithRelations executes the given query into a slice of the model,
+// so that the preloaded relations are loaded, and converts every record to
+// a row keyed by column name. The relations are added to the rows according
+// to Config.RelationsFormat. Selected values that are not fields of the model
+// are not included.
+func (dt *DataTable) executeWithRelations(query *gorm.DB) ([]map[string]any, error) {
+	stmt := dt.tx.Session(&gorm.Session{NewDB: true}).Statement
+	if err := stmt.Parse(dt.model); err != nil {
+		return nil, err
+	}
+
+	records := reflect.New(reflect.SliceOf(stmt.Schema.ModelType))
+	if err := query.Find(records.Interface()).Error; err != nil {
+		return nil, err
+	}
+
+	ctx := query.Statement.Context
+	records = records.Elem()
+	rows := make([]map[string]any, records.Len())
+	for i := range rows {
+		rows[i] = dt.recordToRow(ctx, stmt.Schema, records.Index(i), dt.config.RelationsFormat == RelationsFlat)
+	}
+	return rows, nil
+}
+
+// recordToRow converts a record of the given schema to a row keyed by column
+// name. Loaded relations are added under their snake-cased name, or, when
+// flat is true and the relation holds a single record, as fields prefixed
+// with it.
+func (dt *DataTable) recordToRow(ctx context.Context, s *schema.Schema, record reflect.Value, flat bool) map[string]any {
+	row := make(map[string]any, len(s.Fields))
+	for _, field := range s.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		value, _ := field.ValueOf(ctx, record)
+		row[field.DBName] = value
+	}
+
+	for _, rel := range s.Relationships.Relations {
+		if rel.Field.Schema != s {
+			// GORM registers the relations of the owning schema on the
+			// related schema as well; they are not fields of this record.
+			continue
+		}
+		value := rel.Field.ReflectValueOf(ctx, record)
+		if value.IsZero() {
+			continue
+		}
+		key := dt.tx.NamingStrategy.ColumnName("", rel.Name)
+		converted := dt.relationValue(ctx, rel.FieldSchema, value)
+		if nested, ok := converted.(map[string]any); ok && flat {
+			for k, v := range nested {
+				row[key+"_"+k] = v
+			}
+			continue
+		}
+		row[key] = converted
+	}
+	return row
+}
+
+// relationValue converts the value of a relation, a record or a slice of
+// records, to a row or a slice of rows.
+func (dt *DataTable) relationValue(ctx context.Context, s *schema.Schema, value reflect.Value) any {
+	value = reflect.Indirect(value)
+	if value.Kind() != reflect.Slice {
+		return dt.recordToRow(ctx, s, value, false)
+	}
+	rows := make([]map[string]any, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		if elem := reflect.Indirect(value.Index(i)); elem.IsValid() {
+			rows = append(rows, dt.recordToRow(ctx, s, elem, false))
+		}
+	}
+	return rows
+}
+
+// relationNames returns the relations to preload, split on commas so that
+// With("Profile,Orders") and With("Profile", "Orders") are equivalent.
+func (dt *DataTable) relationNames() []string {
+	var names []string
+	for _, rel := range dt.relations {
+		for _, name := range strings.Split(rel, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,94 @@
+package datatables
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+type Address struct {
+	ID       int
+	AuthorID int
+	City     string
+}
+
+type Author struct {
+	ID      int
+	Name    string
+	Address *Address
+	Profile []Profile `gorm:"foreignKey:UserID"`
+}
+
+func TestRelationNames(t *testing.T) {
+	dt := New(nil).With("Profile, Address", "Orders.Items")
+	expected := []string{"Profile", "Address", "Orders.Items"}
+	if names := dt.relationNames(); !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
+
+func TestMakeWithRelations(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		expected []map[string]any
+	}{
+		{
+			name:   "nested",
+			format: RelationsNested,
+			expected: []map[string]any{{
+				"id":      1,
+				"name":    "John",
+				"address": map[string]any{"id": 10, "author_id": 1, "city": "Jakarta"},
+				"profile": []map[string]any{{"id": 5, "user_id": 1, "details": "bio"}},
+			}},
+		},
+		{
+			name:   "flat",
+			format: RelationsFlat,
+			expected: []map[string]any{{
+				"id":                1,
+				"name":              "John",
+				"address_id":        10,
+				"address_author_id": 1,
+				"address_city":      "Jakarta",
+				"profile":           []map[string]any{{"id": 5, "user_id": 1, "details": "bio"}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+
+			mock.ExpectQuery(qm("SELECT count(*) FROM `authors`")).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+			mock.ExpectQuery(qm("SELECT * FROM `authors` LIMIT ?")).
+				WithArgs(10).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John"))
+			mock.ExpectQuery(qm("SELECT * FROM `addresses` WHERE `addresses`.`author_id` = ?")).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "author_id", "city"}).AddRow(10, 1, "Jakarta"))
+			mock.ExpectQuery(qm("SELECT * FROM `profiles` WHERE `profiles`.`user_id` = ?")).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "details"}).AddRow(5, 1, "bio"))
+
+			cfg := defaultConfig()
+			cfg.RelationsFormat = tt.format
+			dt := New(db, WithConfig(cfg)).Model(&Author{}).With("Address", "Profile")
+			dt.Req(Request{Draw: 1, Length: 10})
+
+			res, err := dt.Make()
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(res["data"], tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, res["data"])
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
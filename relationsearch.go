@@ -0,0 +1,95 @@
+package datatables
+
+import (
+	"errors"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// relationAlias is the alias of the related table in the EXISTS subqueries
+// searching the columns of a relation, so that the related table can be told
+// apart from the table of the model, even when the relation references the
+// model itself.
+const relationAlias = "datatables_related"
+
+// relationScoped returns the condition on a column of a relation, declared
+// with Column.Relation, wrapped in an EXISTS subquery on the related table
+// joined to the current row, so that the rows having a related record
+// matching the condition are selected. The condition is returned as is for
+// the other columns. The related records soft deleted with gorm.DeletedAt
+// are ignored.
+func (dt *DataTable) relationScoped(col Column, cond clause.Expression) clause.Expression {
+	if col.Relation == "" {
+		return cond
+	}
+	rel, err := dt.relation(col)
+	if err != nil {
+		return clause.Expr{SQL: "1 = 0"}
+	}
+
+	sql := "EXISTS (SELECT 1 FROM ? WHERE "
+	vars := []any{clause.Table{Name: rel.FieldSchema.Table, Alias: relationAlias}}
+	for i, ref := range rel.References {
+		if i > 0 {
+			sql += " AND "
+		}
+		sql += "? = ?"
+		switch {
+		case ref.PrimaryKey == nil:
+			vars = append(vars, clause.Column{Table: relationAlias, Name: ref.ForeignKey.DBName}, ref.PrimaryValue)
+		case ref.OwnPrimaryKey:
+			vars = append(vars,
+				clause.Column{Table: relationAlias, Name: ref.ForeignKey.DBName},
+				clause.Column{Table: clause.CurrentTable, Name: ref.PrimaryKey.DBName},
+			)
+		default:
+			vars = append(vars,
+				clause.Column{Table: relationAlias, Name: ref.PrimaryKey.DBName},
+				clause.Column{Table: clause.CurrentTable, Name: ref.ForeignKey.DBName},
+			)
+		}
+	}
+	for _, field := range rel.FieldSchema.Fields {
+		if field.DBName != "" && field.FieldType == reflect.TypeOf(gorm.DeletedAt{}) {
+			sql += " AND ? IS NULL"
+			vars = append(vars, clause.Column{Table: relationAlias, Name: field.DBName})
+		}
+	}
+	sql += " AND ?)"
+	vars = append(vars, cond)
+	return clause.Expr{SQL: sql, Vars: vars}
+}
+
+// relation returns the relationship of the model named by the Relation of
+// the given column. Returns an error if the model has no such relationship,
+// or if it is a many to many relationship, which is not supported.
+func (dt *DataTable) relation(col Column) (*schema.Relationship, error) {
+	s := dt.modelSchema()
+	if s == nil {
+		return nil, errors.New(dt.translatef(MsgRelationModel, "column %q: relation columns require a model", col.Data))
+	}
+	rel, ok := s.Relationships.Relations[col.Relation]
+	if !ok {
+		return nil, errors.New(dt.translatef(MsgUnknownRelation, "column %q: unknown relation %q", col.Data, col.Relation))
+	}
+	if rel.JoinTable != nil {
+		return nil, errors.New(dt.translatef(MsgManyToMany, "column %q: many to many relation %q is not supported", col.Data, col.Relation))
+	}
+	return rel, nil
+}
+
+// validateRelations checks the relations of the columns declared with
+// Column.Relation. Returns the first error found.
+func (dt *DataTable) validateRelations() error {
+	for _, col := range dt.columns {
+		if col := dt.columnsMap[col.Data]; col.Relation != "" {
+			if _, err := dt.relation(col); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
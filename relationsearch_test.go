@@ -0,0 +1,71 @@
+package datatables
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestRelationSearch(t *testing.T) {
+	const exists = "EXISTS (SELECT 1 FROM `profiles` `datatables_related` WHERE `datatables_related`.`user_id` = `users`.`id` AND `datatables_related`.`details` LIKE ?)"
+
+	db, mock := newMockDB(t)
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`") + "$").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(5)))
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE (`name` LIKE ? OR ("+exists+"))")).
+		WithArgs("%gopher%", "%gopher%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+	mock.ExpectQuery(qm("SELECT * FROM `users` WHERE (`name` LIKE ? OR ("+exists+")) ORDER BY `name` LIMIT ?")).
+		WithArgs("%gopher%", "%gopher%", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+
+	dt := New(db).Model(&User{}).AddColumns(
+		Column{Name: "name", Data: "name", Searchable: true, Orderable: true},
+		Column{Name: "details", Data: "details", Searchable: true, Orderable: true, Relation: "Profile"},
+	)
+	dt.Req(Request{
+		Draw:   1,
+		Length: 10,
+		Search: Search{Value: "gopher"},
+		Order:  []Order{{Column: 1, Dir: "asc"}, {Column: 0, Dir: "asc"}},
+		Columns: []ColumnRequest{
+			{Data: "name", Searchable: true, Orderable: true},
+			{Data: "details", Searchable: true, Orderable: true},
+		},
+	})
+
+	response, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if response["recordsFiltered"] != int64(1) {
+		t.Errorf("expected 1 filtered record, got %v", response["recordsFiltered"])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestValidateRelations(t *testing.T) {
+	db, _ := newMockDB(t)
+	tests := []struct {
+		name     string
+		model    any
+		relation string
+		wantErr  bool
+	}{
+		{name: "has_many", model: &User{}, relation: "Profile"},
+		{name: "unknown_relation", model: &User{}, relation: "Orders", wantErr: true},
+		{name: "table_model", model: "users", relation: "Profile", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dt := New(db).Model(tt.model).AddColumn(Column{Name: "details", Data: "details", Searchable: true, Relation: tt.relation})
+			dt.Req(Request{Draw: 1, Columns: []ColumnRequest{{Data: "details", Searchable: true}}})
+			if err := dt.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("expected error = %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package datatables
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// RenderError describes a RenderFunc that failed for a row, when render
+// errors are isolated with IsolateRenderErrors.
+//
+// Fields:
+//   - Row: The index of the row in the page.
+//   - Column: The data of the column.
+//   - Err: The error returned by the RenderFunc, or built from its panic.
+type RenderError struct {
+	Row    int
+	Column string
+	Err    error
+}
+
+// Error implements error.
+func (e RenderError) Error() string {
+	return fmt.Sprintf("render column %s of row %d: %v", e.Column, e.Row, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e RenderError) Unwrap() error {
+	return e.Err
+}
+
+// renderIsolation holds the settings of IsolateRenderErrors.
+type renderIsolation struct {
+	fallback any
+	report   func(context.Context, []RenderError)
+}
+
+// IsolateRenderErrors keeps a failing RenderFunc from failing the whole
+// response. A RenderFunc fails when it panics or returns an error value; the
+// cell is then set to the fallback value, such as nil or "#ERROR", and the
+// other cells and rows are rendered as usual.
+//
+// The errors of a request are passed to report, if not nil, and logged as a
+// single warning record when a logger is set.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) IsolateRenderErrors(fallback any, report func(ctx context.Context, errs []RenderError)) *DataTable {
+	dt.renderIsolation = &renderIsolation{fallback: fallback, report: report}
+	return dt
+}
+
+// renderCell runs the rendering function on the row, turning a panic or a
+// returned error value into an error.
+func renderCell(render func(map[string]any) any, row map[string]any) (value any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			value, err = nil, fmt.Errorf("panic: %v", r)
+		}
+	}()
+	value = render(row)
+	if err, ok := value.(error); ok {
+		return nil, err
+	}
+	return value, nil
+}
+
+// reportRenderErrors passes the render errors of the request to the report
+// function and logs them. It does nothing if there are no errors.
+func (dt *DataTable) reportRenderErrors(errs []RenderError) {
+	if len(errs) == 0 {
+		return
+	}
+
+	ctx := dt.context()
+	if dt.renderIsolation.report != nil {
+		dt.renderIsolation.report(ctx, errs)
+	}
+	if dt.logger != nil {
+		dt.logger.LogAttrs(ctx, slog.LevelWarn, "datatables: render failed",
+			slog.String("table", dt.tableName()),
+			slog.Int("errors", len(errs)),
+			slog.String("first", errs[0].Error()),
+		)
+	}
+}
@@ -0,0 +1,74 @@
+package datatables
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestIsolateRenderErrors(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(qm("SELECT * FROM `users` LIMIT ?")).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "age"}).
+			AddRow(1, "John", 20).
+			AddRow(2, nil, 30).
+			AddRow(3, "Jim", -1))
+
+	errNegative := errors.New("negative age")
+	var reported []RenderError
+	var buf bytes.Buffer
+	dt := New(db).Model(&User{}).
+		SetLogger(slog.New(slog.NewTextHandler(&buf, nil))).
+		AddColumns(
+			Column{Data: "name", Name: "name", RenderFunc: func(row map[string]any) any {
+				return strings.ToUpper(row["name"].(string))
+			}},
+			Column{Data: "age", Name: "age", RenderFunc: func(row map[string]any) any {
+				if row["age"].(int64) < 0 {
+					return errNegative
+				}
+				return row["age"]
+			}},
+		).
+		IsolateRenderErrors("#ERROR", func(ctx context.Context, errs []RenderError) {
+			reported = errs
+		}).
+		Req(Request{Draw: 1, Length: 10})
+
+	response, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []map[string]any{
+		{"id": 1, "name": "JOHN", "age": int64(20)},
+		{"id": 2, "name": "#ERROR", "age": int64(30)},
+		{"id": 3, "name": "JIM", "age": "#ERROR"},
+	}
+	if !reflect.DeepEqual(response["data"], expected) {
+		t.Errorf("expected data %v, got %v", expected, response["data"])
+	}
+
+	if len(reported) != 2 {
+		t.Fatalf("expected 2 render errors, got %v", reported)
+	}
+	if reported[0].Row != 1 || reported[0].Column != "name" || !strings.Contains(reported[0].Error(), "panic") {
+		t.Errorf("unexpected first error %v", reported[0])
+	}
+	if reported[1].Row != 2 || reported[1].Column != "age" || !errors.Is(reported[1], errNegative) {
+		t.Errorf("unexpected second error %v", reported[1])
+	}
+	if !strings.Contains(buf.String(), "errors=2") {
+		t.Errorf("expected a warning with the error count, got %q", buf.String())
+	}
+}
@@ -2,8 +2,14 @@ package datatables
 
 import (
 	"fmt"
+	"maps"
 	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
 // Search represents the search criteria for a DataTable.
@@ -34,12 +40,15 @@ type Order struct {
 //   - Data: The data property name of the column.
 //   - Name: The display name of the column.
 //   - Search: The search criteria applied to the column.
+//   - Hidden: Indicates if the column is hidden on the client, sent as
+//     columns[i][visible]=false by some configurations.
 type ColumnRequest struct {
 	Searchable bool   `form:"searchable"`
 	Orderable  bool   `form:"orderable"`
 	Data       string `form:"data"`
 	Name       string `form:"name"`
 	Search     Search `form:"search"`
+	Hidden     bool   `form:"-"`
 }
 
 // Request represents a DataTables request.
@@ -51,88 +60,196 @@ type ColumnRequest struct {
 //   - Search: The search criteria for this request.
 //   - Order: The ordering criteria for this request.
 //   - Columns: The columns to be processed for this request.
+//   - Filters: The names of the server-defined filter presets to apply.
+//   - SignedFilters: The signed filter tokens issued with SignFilter.
+//   - Cursor: The nextCursor of the previous response, when cursor
+//     pagination is enabled.
+//   - Extensions: The values parsed by the registered plugins, by plugin
+//     name.
 type Request struct {
-	Draw    int             `form:"draw"`
-	Start   int             `form:"start"`
-	Length  int             `form:"length"`
-	Search  Search          `form:"search"`
-	Order   []Order         `form:"order"`
-	Columns []ColumnRequest `form:"columns"`
+	Draw          int             `form:"draw"`
+	Start         int             `form:"start"`
+	Length        int             `form:"length"`
+	Search        Search          `form:"search"`
+	Order         []Order         `form:"order"`
+	Columns       []ColumnRequest `form:"columns"`
+	Filters       []string        `form:"filter"`
+	SignedFilters []string        `form:"signed_filter"`
+	Cursor        string          `form:"cursor"`
+	Extensions    map[string]any  `form:"-"`
+}
+
+// maxRequestColumns bounds the column and order indices accepted by
+// ParseRequest, so that a hostile request cannot make it allocate huge
+// slices.
+const maxRequestColumns = 1000
+
+// indexedParam matches the columns[i][...] and order[i][...] parameters,
+// capturing the group, the index, and the bracketed suffix.
+var indexedParam = regexp.MustCompile(`^(columns|order)\[(0|[1-9][0-9]*)\]((?:\[[^\[\]]*\])+)$`)
+
+// ParseOptions configures ParseRequestWith.
+//
+// Fields:
+//   - ParamStyles: The parameter styles accepted in addition to
+//     ParamStyleBracket. The style is detected per parameter, so the
+//     columns, order, and search parameters of such styles are parsed as
+//     their bracketed equivalent, and repeating a parameter in two styles
+//     is rejected as a duplicate. Other parameters starting with columns,
+//     order, or search followed by the separator of an accepted style are
+//     rejected as malformed.
+//   - DefaultOrder: Whether a request without order is ordered by its first
+//     column, ascending, when that column is orderable. When disabled, the
+//     default, such a request has no order, so that Config.DefaultSort, if
+//     any, is applied by the DataTable, and the rows are otherwise returned
+//     in the order of the database.
+type ParseOptions struct {
+	ParamStyles  []ParamStyle
+	DefaultOrder bool
 }
 
 // ParseRequest parses a DataTables request from the given http request.
 //
 // It will automatically parse the draw, start, length, search, order, and columns
 // parameters from the request. The request is validated and an error is returned if
-// any part of the request is invalid.
+// any part of the request is invalid: malformed or duplicated columns[i] and
+// order[i] parameters, indices that are out of range or leave gaps, invalid
+// numbers or booleans, and values that are not valid UTF-8 are rejected
+// rather than producing a partial request. The Parse hooks of the registered
+// plugins are then run to fill the Extensions of the request. A request
+// without order is left without order, so that Config.DefaultSort applies.
 //
 // The function returns the parsed request and nil if the request is valid,
 // otherwise it returns nil and an error.
 func ParseRequest(r *http.Request) (*Request, error) {
+	return ParseRequestWith(r, ParseOptions{})
+}
+
+// ParseRequestWith is like ParseRequest but parses the request according to
+// the given options.
+func ParseRequestWith(r *http.Request, opts ParseOptions) (*Request, error) {
 	var (
 		err  error
 		data Request
 	)
 
-	_ = r.ParseForm()
-
-	data.Draw, err = strconv.Atoi(r.Form.Get("draw"))
-	if err != nil {
-		return nil, fmt.Errorf("invalid value for draw: %v", err)
+	if err := r.ParseForm(); err != nil {
+		return nil, fmt.Errorf("invalid request parameters: %v", err)
 	}
-	data.Start, err = strconv.Atoi(r.Form.Get("start"))
-	if err != nil {
-		return nil, fmt.Errorf("invalid value for start: %v", err)
+	for key, values := range r.Form {
+		if !utf8.ValidString(key) {
+			return nil, fmt.Errorf("invalid UTF-8 in parameter name %q", key)
+		}
+		for _, v := range values {
+			if !utf8.ValidString(v) {
+				return nil, fmt.Errorf("invalid UTF-8 in parameter %s", key)
+			}
+		}
 	}
-	data.Length, _ = strconv.Atoi(r.Form.Get("length"))
-	data.Search.Value = r.Form.Get("search[value]")
-	data.Search.Regex, err = strconv.ParseBool(r.Form.Get("search[regex]"))
-	if err != nil {
-		return nil, fmt.Errorf("invalid value for search[regex]: %v", err)
+
+	form := normalizeParams(r.Form, opts.ParamStyles)
+	for _, key := range []string{"draw", "start", "length", "search[value]", "search[regex]", "cursor"} {
+		if len(form[key]) > 1 {
+			return nil, fmt.Errorf("duplicate parameter %q", key)
+		}
 	}
 
-	columnCount := 0
-	for {
-		columnName := r.Form.Get(fmt.Sprintf("columns[%d][data]", columnCount))
-		if columnName == "" {
-			break
+	// Infinite scroll clients sending a cursor may leave out the draw, start,
+	// and search[regex] parameters, which only DataTables requires.
+	data.Cursor = form.Get("cursor")
+	lenient := data.Cursor != ""
+
+	if v := form.Get("draw"); v != "" || !lenient {
+		data.Draw, err = strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for draw: %v", err)
+		}
+	}
+	if v := form.Get("start"); v != "" || !lenient {
+		data.Start, err = strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for start: %v", err)
+		}
+		if data.Start < 0 {
+			return nil, fmt.Errorf("invalid value for start: %d", data.Start)
 		}
+	}
+	if v := form.Get("length"); v != "" {
+		data.Length, err = strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for length: %v", err)
+		}
+		if data.Length < -1 {
+			return nil, fmt.Errorf("invalid value for length: %d", data.Length)
+		}
+	}
+	data.Search.Value = form.Get("search[value]")
+	if v := form.Get("search[regex]"); v != "" || !lenient {
+		data.Search.Regex, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for search[regex]: %v", err)
+		}
+	}
 
+	data.Filters = form["filter"]
+	data.SignedFilters = form["signed_filter"]
+
+	columns, orders, err := parseIndexedParams(form)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, params := range columns {
 		column := ColumnRequest{
-			Data:       columnName,
-			Name:       r.Form.Get(fmt.Sprintf("columns[%d][name]", columnCount)),
-			Searchable: r.Form.Get(fmt.Sprintf("columns[%d][searchable]", columnCount)) == "true",
-			Orderable:  r.Form.Get(fmt.Sprintf("columns[%d][orderable]", columnCount)) == "true",
+			Data: params["[data]"],
+			Name: params["[name]"],
 			Search: Search{
-				Value: r.Form.Get(fmt.Sprintf("columns[%d][search][value]", columnCount)),
-				Regex: r.Form.Get(fmt.Sprintf("columns[%d][search][regex]", columnCount)) == "true",
+				Value: params["[search][value]"],
 			},
 		}
+		for _, flag := range []struct {
+			suffix string
+			dest   *bool
+		}{
+			{"[searchable]", &column.Searchable},
+			{"[orderable]", &column.Orderable},
+			{"[search][regex]", &column.Search.Regex},
+		} {
+			if v := params[flag.suffix]; v != "" {
+				if *flag.dest, err = strconv.ParseBool(v); err != nil {
+					return nil, fmt.Errorf("invalid value for columns[%d]%s: %v", i, flag.suffix, err)
+				}
+			}
+		}
+		if v := params["[visible]"]; v != "" {
+			visible, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for columns[%d][visible]: %v", i, err)
+			}
+			column.Hidden = !visible
+		}
 		data.Columns = append(data.Columns, column)
-		columnCount++
 	}
 
-	orderCount := 0
-	for {
-		columnIndex := r.Form.Get(fmt.Sprintf("order[%d][column]", orderCount))
-		if columnIndex == "" {
-			break
+	for i, params := range orders {
+		col, err := strconv.Atoi(params["[column]"])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for order[%d][column]: %v", i, err)
+		}
+		if col < 0 || col >= len(data.Columns) {
+			return nil, fmt.Errorf("invalid value for order[%d][column]: no column %d", i, col)
 		}
 
-		col, _ := strconv.Atoi(columnIndex)
-		dir := r.Form.Get(fmt.Sprintf("order[%d][dir]", orderCount))
-
-		if col >= 0 && col < len(data.Columns) && data.Columns[col].Orderable {
+		if data.Columns[col].Orderable {
 			order := Order{
 				Column: col,
-				Dir:    dir,
+				Dir:    params["[dir]"],
 			}
 			data.Order = append(data.Order, order)
 		}
-		orderCount++
 	}
 
-	if len(data.Order) == 0 {
+	if len(data.Order) == 0 && opts.DefaultOrder {
 		defaultSort := Order{
 			Column: 0,
 			Dir:    "asc",
@@ -142,5 +259,56 @@ func ParseRequest(r *http.Request) (*Request, error) {
 		}
 	}
 
+	if err := parsePlugins(r, &data); err != nil {
+		return nil, err
+	}
+
 	return &data, nil
 }
+
+// parseIndexedParams groups the columns[i][...] and order[i][...] parameters
+// of the form by index, keyed by their bracketed suffix, such as [data] or
+// [search][value]. Unknown suffixes are kept, and ignored by the caller, so
+// that newer clients sending more parameters are accepted. An error is
+// returned for a malformed or repeated parameter, an index of
+// maxRequestColumns or more, or indices that leave gaps.
+func parseIndexedParams(form url.Values) (columns, orders []map[string]string, err error) {
+	groups := map[string]map[int]map[string]string{
+		"columns": {},
+		"order":   {},
+	}
+	for _, key := range slices.Sorted(maps.Keys(form)) {
+		if !strings.HasPrefix(key, "columns[") && !strings.HasPrefix(key, "order[") {
+			continue
+		}
+		match := indexedParam.FindStringSubmatch(key)
+		if match == nil {
+			return nil, nil, fmt.Errorf("malformed parameter %q", key)
+		}
+		index, err := strconv.Atoi(match[2])
+		if err != nil || index >= maxRequestColumns {
+			return nil, nil, fmt.Errorf("index out of range in parameter %q", key)
+		}
+		if len(form[key]) > 1 {
+			return nil, nil, fmt.Errorf("duplicate parameter %q", key)
+		}
+
+		group := groups[match[1]]
+		if group[index] == nil {
+			group[index] = map[string]string{}
+		}
+		group[index][match[3]] = form.Get(key)
+	}
+
+	result := make(map[string][]map[string]string, len(groups))
+	for name, group := range groups {
+		for i := range len(group) {
+			params, ok := group[i]
+			if !ok {
+				return nil, nil, fmt.Errorf("missing parameters for %s[%d]", name, i)
+			}
+			result[name] = append(result[name], params)
+		}
+	}
+	return result["columns"], result["order"], nil
+}
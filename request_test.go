@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 func TestParseRequest(t *testing.T) {
@@ -21,6 +22,7 @@ func TestParseRequest(t *testing.T) {
 		ExpectedSearch string
 		ExpectedCols   []string
 		ExpectedOrder  []Order
+		Options        ParseOptions
 	}
 
 	tests := []TestCaseParseRequest{
@@ -60,6 +62,17 @@ func TestParseRequest(t *testing.T) {
 			QueryParams:   url.Values{"draw": {"1"}, "start": {"0"}, "length": {"10"}, "search[value]": {"test"}, "search[regex]": {"invalid"}},
 			ExpectedError: true,
 		},
+		{
+			Name:           "no_default_sorting",
+			Method:         http.MethodGet,
+			QueryParams:    url.Values{"draw": {"1"}, "start": {"0"}, "length": {"10"}, "columns[0][data]": {"no"}, "columns[0][name]": {"no"}, "columns[0][searchable]": {"true"}, "columns[0][orderable]": {"true"}, "search[regex]": {"false"}},
+			ExpectedError:  false,
+			ExpectedDraw:   1,
+			ExpectedStart:  0,
+			ExpectedLength: 10,
+			ExpectedSearch: "",
+			ExpectedCols:   []string{"no"},
+		},
 		{
 			Name:           "default_sorting_applied",
 			Method:         http.MethodGet,
@@ -71,6 +84,7 @@ func TestParseRequest(t *testing.T) {
 			ExpectedSearch: "",
 			ExpectedCols:   []string{"no"},
 			ExpectedOrder:  []Order{{Column: 0, Dir: "asc"}},
+			Options:        ParseOptions{DefaultOrder: true},
 		},
 		{
 			Name:           "valid_post_request",
@@ -84,6 +98,14 @@ func TestParseRequest(t *testing.T) {
 			ExpectedCols:   []string{"no", "name"},
 			ExpectedOrder:  []Order{{Column: 0, Dir: "asc"}},
 		},
+		{
+			Name:           "cursor_without_datatables_params",
+			Method:         http.MethodGet,
+			QueryParams:    url.Values{"cursor": {"eyJvZmZzZXQiOjEwfQ"}, "length": {"10"}, "columns[0][data]": {"name"}},
+			ExpectedError:  false,
+			ExpectedLength: 10,
+			ExpectedCols:   []string{"name"},
+		},
 		{
 			Name:          "empty_post_body",
 			Method:        http.MethodPost,
@@ -113,6 +135,7 @@ func TestParseRequest(t *testing.T) {
 			ExpectedSearch: "",
 			ExpectedCols:   []string{"no"},
 			ExpectedOrder:  []Order{{Column: 0, Dir: "asc"}},
+			Options:        ParseOptions{DefaultOrder: true},
 		},
 	}
 
@@ -129,7 +152,7 @@ func TestParseRequest(t *testing.T) {
 				t.Fatalf("Unsupported HTTP method: %s", tt.Method)
 			}
 
-			parsedRequest, err := ParseRequest(req)
+			parsedRequest, err := ParseRequestWith(req, tt.Options)
 
 			if tt.ExpectedError && err == nil {
 				t.Fatal("Expected an error but got nil")
@@ -181,3 +204,135 @@ func TestParseRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRequestHostile(t *testing.T) {
+	const base = "draw=1&start=0&length=10&search[regex]=false&"
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"huge_column_index", base + "columns[99999999999999999999][data]=name"},
+		{"column_index_above_limit", base + "columns[1000][data]=name"},
+		{"column_index_gap", base + "columns[0][data]=id&columns[2][data]=name"},
+		{"leading_zero_index", base + "columns[00][data]=name"},
+		{"negative_index", base + "columns[-1][data]=name"},
+		{"unclosed_bracket", base + "columns[0][data=name"},
+		{"missing_suffix", base + "columns[0]=name"},
+		{"nested_bracket", base + "columns[0][[data]]=name"},
+		{"duplicate_parameter", base + "columns[0][data]=id&columns[0][data]=name"},
+		{"duplicate_draw", base + "columns[0][data]=name&draw=2"},
+		{"invalid_searchable", base + "columns[0][data]=name&columns[0][searchable]=maybe"},
+		{"order_out_of_range", base + "columns[0][data]=name&order[0][column]=5&order[0][dir]=asc"},
+		{"order_not_a_number", base + "columns[0][data]=name&order[0][column]=x&order[0][dir]=asc"},
+		{"invalid_length", "draw=1&start=0&length=ten&search[regex]=false"},
+		{"negative_start", "draw=1&start=-10&length=10&search[regex]=false"},
+		{"invalid_utf8", base + "columns[0][data]=%ff%fe"},
+		{"invalid_escape", base + "columns[0][data]=%zz"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &http.Request{Method: http.MethodGet, URL: &url.URL{RawQuery: tt.query}}
+			if req, err := ParseRequest(r); err == nil {
+				t.Errorf("expected error, got %+v", req)
+			}
+		})
+	}
+
+	t.Run("visible", func(t *testing.T) {
+		r := &http.Request{Method: http.MethodGet, URL: &url.URL{RawQuery: base + "columns[0][data]=id&columns[0][visible]=true&columns[1][data]=body&columns[1][visible]=false"}}
+		req, err := ParseRequest(r)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if req.Columns[0].Hidden || !req.Columns[1].Hidden {
+			t.Errorf("expected only the second column to be hidden, got %+v", req.Columns)
+		}
+	})
+
+	t.Run("empty_data_kept", func(t *testing.T) {
+		r := &http.Request{Method: http.MethodGet, URL: &url.URL{RawQuery: base + "columns[0][data]=&columns[1][data]=name"}}
+		req, err := ParseRequest(r)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(req.Columns) != 2 || req.Columns[1].Data != "name" {
+			t.Errorf("expected both columns, got %+v", req.Columns)
+		}
+	})
+}
+
+func FuzzParseRequest(f *testing.F) {
+	f.Add("draw=1&start=0&length=10&search[value]=test&search[regex]=false&columns[0][data]=name&columns[0][orderable]=true&order[0][column]=0&order[0][dir]=asc")
+	f.Add("cursor=eyJvZmZzZXQiOjEwfQ&columns[0][data]=name")
+	f.Add("draw=1&start=0&search[regex]=false&columns[1][data]=x&order[0][column]=9")
+	f.Add("columns[0][search][value]=%ff&filter=a&filter=b")
+
+	f.Fuzz(func(t *testing.T, query string) {
+		r := &http.Request{Method: http.MethodGet, URL: &url.URL{RawQuery: query}}
+		req, err := ParseRequest(r)
+		if err != nil {
+			return
+		}
+		if len(req.Columns) > maxRequestColumns {
+			t.Errorf("parsed %d columns", len(req.Columns))
+		}
+		for _, order := range req.Order {
+			if order.Column < 0 || order.Column >= len(req.Columns) {
+				t.Errorf("order on column %d out of %d", order.Column, len(req.Columns))
+			}
+		}
+		for _, col := range req.Columns {
+			if !utf8.ValidString(col.Data) || !utf8.ValidString(col.Name) || !utf8.ValidString(col.Search.Value) {
+				t.Errorf("invalid UTF-8 in column %+v", col)
+			}
+		}
+	})
+}
+
+func TestParseRequestWithParamStyles(t *testing.T) {
+	styles := ParseOptions{ParamStyles: []ParamStyle{ParamStyleDot, ParamStyleUnderscore}}
+	tests := []struct {
+		name    string
+		query   string
+		opts    ParseOptions
+		wantErr bool
+	}{
+		{name: "bracket", query: "draw=1&start=0&length=10&search[value]=john&search[regex]=false&columns[0][data]=name&columns[0][orderable]=true&columns[0][search][value]=doe&order[0][column]=0&order[0][dir]=desc", opts: styles},
+		{name: "dot", query: "draw=1&start=0&length=10&search.value=john&search.regex=false&columns.0.data=name&columns.0.orderable=true&columns.0.search.value=doe&order.0.column=0&order.0.dir=desc", opts: styles},
+		{name: "underscore", query: "draw=1&start=0&length=10&search_value=john&search_regex=false&columns_0_data=name&columns_0_orderable=true&columns_0_search_value=doe&order_0_column=0&order_0_dir=desc", opts: styles},
+		{name: "mixed", query: "draw=1&start=0&length=10&search[value]=john&search_regex=false&columns.0.data=name&columns_0_orderable=true&columns[0][search][value]=doe&order.0.column=0&order_0_dir=desc", opts: styles},
+		{name: "duplicate_across_styles", query: "draw=1&start=0&length=10&search[regex]=false&columns[0][data]=name&columns.0.data=email", opts: styles, wantErr: true},
+		{name: "dot_not_accepted", query: "draw=1&start=0&length=10&search[regex]=false&columns.0.data=name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &http.Request{Method: http.MethodGet, URL: &url.URL{RawQuery: tt.query}}
+			req, err := ParseRequestWith(r, tt.opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %+v", req)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(tt.opts.ParamStyles) == 0 {
+				if len(req.Columns) != 0 {
+					t.Errorf("expected the dot parameters to be ignored, got %+v", req.Columns)
+				}
+				return
+			}
+			if req.Search.Value != "john" {
+				t.Errorf("expected search value john, got %q", req.Search.Value)
+			}
+			if len(req.Columns) != 1 || req.Columns[0].Data != "name" || !req.Columns[0].Orderable || req.Columns[0].Search.Value != "doe" {
+				t.Errorf("unexpected columns %+v", req.Columns)
+			}
+			if len(req.Order) != 1 || req.Order[0].Column != 0 || req.Order[0].Dir != "desc" {
+				t.Errorf("unexpected order %+v", req.Order)
+			}
+		})
+	}
+}
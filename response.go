@@ -1,5 +1,45 @@
 package datatables
 
+import "maps"
+
+// schemaKey returns the response key to use given the configured key and the
+// DataTables default. An empty configured key selects the default.
+func schemaKey(configured, fallback string) string {
+	if configured == "" {
+		return fallback
+	}
+	return configured
+}
+
+// buildResponse assembles the response returned by Make from the given
+// result.
+//
+// The draw counter, counts, and data are stored under the keys defined by
+// the DataTable's response schema, keys set to "-" are left out, and the
+// additional data and meta fields are merged in. If the schema defines a wrap key, the whole
+// response is nested under it.
+func (dt *DataTable) buildResponse(res *result) map[string]any {
+	schema := dt.config.ResponseSchema
+	response := make(map[string]any, len(dt.additionalData)+4)
+	for key, value := range map[string]any{
+		schemaKey(schema.Draw, "draw"):                       dt.req.Draw,
+		schemaKey(schema.RecordsTotal, "recordsTotal"):       res.total,
+		schemaKey(schema.RecordsFiltered, "recordsFiltered"): res.filtered,
+		schemaKey(schema.Data, "data"):                       res.data,
+	} {
+		if key != "-" {
+			response[key] = value
+		}
+	}
+	maps.Copy(response, dt.additionalData)
+	maps.Copy(response, res.meta)
+
+	if schema.Wrap != "" {
+		return map[string]any{schema.Wrap: response}
+	}
+	return response
+}
+
 // applyCustomColumns applies all custom column editors to the given data.
 //
 // Custom column editors are functions that take a row (map[string]any) and
@@ -18,7 +58,8 @@ func (dt *DataTable) applyCustomColumns(data []map[string]any) {
 // This function iterates through each row in the provided data slice and
 // applies the row ID, class, and data attributes if they are defined.
 // The row ID is determined by the rowIdFunc, which generates an ID based on
-// each row's data. The row class is applied if the rowClass field is set.
+// each row's data. The row class is applied if the rowClass field or the
+// rowClassFunc function, which may vary the class per row, is set.
 // Additionally, custom data attributes are added to each row using the
 // rowDataFunc, which returns a map of key-value pairs to be prefixed and
 // appended as data-* attributes.
@@ -31,7 +72,11 @@ func (dt *DataTable) applyRowAttributes(data []map[string]any) {
 		if dt.rowIdFunc != nil {
 			row[datatableRowID] = dt.rowIdFunc(row)
 		}
-		if dt.rowClass != "" {
+		if dt.rowClassFunc != nil {
+			if class := dt.rowClassFunc(row); class != "" {
+				row[datatableRowClass] = class
+			}
+		} else if dt.rowClass != "" {
 			row[datatableRowClass] = dt.rowClass
 		}
 		if dt.rowDataFunc != nil {
@@ -75,3 +120,47 @@ func (dt *DataTable) getFilteredColumns() []Column {
 
 	return filtered
 }
+
+// computeMeta calls the functions registered with WithMeta and merges their
+// results, later functions overriding earlier ones.
+func (dt *DataTable) computeMeta(total, filtered int64, data []map[string]any) map[string]any {
+	meta := make(map[string]any)
+	for _, metaFunc := range dt.metaFuncs {
+		maps.Copy(meta, metaFunc(total, filtered, data))
+	}
+	return meta
+}
+
+// isColumnHidden reports whether the column is left out of the response:
+// excluded with Except, denied by the column policy, or blacklisted. The row
+// number column, which is blacklisted so that it is never searched or
+// ordered on, is not hidden.
+func (dt *DataTable) isColumnHidden(data string) bool {
+	if dt.excludedColumns[data] || dt.deniedColumns[data] {
+		return true
+	}
+	return dt.blacklistColumns[data] && (dt.indexColumn == nil || dt.indexColumn.data != data)
+}
+
+// removeHiddenColumns removes the columns excluded with Except, the columns
+// denied by the column policy, and the blacklisted columns from the given
+// data in place.
+func (dt *DataTable) removeHiddenColumns(data []map[string]any) {
+	var hidden []string
+	for _, columns := range []map[string]bool{dt.deniedColumns, dt.excludedColumns, dt.blacklistColumns} {
+		for data := range columns {
+			if dt.isColumnHidden(data) {
+				hidden = append(hidden, data)
+			}
+		}
+	}
+	if len(hidden) == 0 {
+		return
+	}
+	for _, row := range data {
+		for _, data := range hidden {
+			delete(row, data)
+			delete(row, data+rawValueSuffix)
+		}
+	}
+}
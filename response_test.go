@@ -173,3 +173,104 @@ func TestGetFilteredColumns(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildResponse(t *testing.T) {
+	res := &result{total: 10, filtered: 5, rows: 1, data: []map[string]any{{"id": 1}}}
+
+	tests := []struct {
+		name     string
+		schema   ResponseSchema
+		expected map[string]any
+	}{
+		{
+			name:   "default_schema",
+			schema: ResponseSchema{},
+			expected: map[string]any{
+				"draw":            2,
+				"recordsTotal":    int64(10),
+				"recordsFiltered": int64(5),
+				"data":            []map[string]any{{"id": 1}},
+				"extra":           "value",
+			},
+		},
+		{
+			name:   "renamed_and_removed_keys",
+			schema: ResponseSchema{Draw: "-", RecordsTotal: "total", RecordsFiltered: "filtered", Data: "rows"},
+			expected: map[string]any{
+				"total":    int64(10),
+				"filtered": int64(5),
+				"rows":     []map[string]any{{"id": 1}},
+				"extra":    "value",
+			},
+		},
+		{
+			name:   "wrapped",
+			schema: ResponseSchema{Wrap: "result", Data: "rows"},
+			expected: map[string]any{
+				"result": map[string]any{
+					"draw":            2,
+					"recordsTotal":    int64(10),
+					"recordsFiltered": int64(5),
+					"rows":            []map[string]any{{"id": 1}},
+					"extra":           "value",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dt := New(nil)
+			dt.req.Draw = 2
+			dt.WithData("extra", "value")
+			dt.SetResponseSchema(tt.schema)
+
+			response := dt.buildResponse(res)
+			if !reflect.DeepEqual(response, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, response)
+			}
+		})
+	}
+}
+
+func TestComputeMeta(t *testing.T) {
+	dt := New(nil)
+	dt.WithMeta(func(total, filtered int64, data []map[string]any) map[string]any {
+		return map[string]any{"filteredOut": total - filtered, "flag": false}
+	})
+	dt.WithMeta(func(total, filtered int64, data []map[string]any) map[string]any {
+		sum := 0
+		for _, row := range data {
+			sum += row["amount"].(int)
+		}
+		return map[string]any{"pageAmount": sum, "flag": true}
+	})
+
+	meta := dt.computeMeta(10, 4, []map[string]any{{"amount": 3}, {"amount": 4}})
+	expected := map[string]any{"filteredOut": int64(6), "pageAmount": 7, "flag": true}
+	if !reflect.DeepEqual(meta, expected) {
+		t.Errorf("expected %v, got %v", expected, meta)
+	}
+
+	response := dt.buildResponse(&result{total: 10, filtered: 4, meta: meta})
+	if response["pageAmount"] != 7 {
+		t.Errorf("expected meta to be merged into the response, got %v", response)
+	}
+}
+
+func TestRemoveHiddenColumns(t *testing.T) {
+	dt := New(nil)
+	dt.Except("password_hash", "token")
+	dt.deniedColumns = map[string]bool{"salary": true}
+
+	data := []map[string]any{{"id": 1, "salary": 1000, "password_hash": "x", "token": "y"}}
+	dt.removeHiddenColumns(data)
+
+	expected := []map[string]any{{"id": 1}}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("expected %v, got %v", expected, data)
+	}
+	if dt.isColumnAllowed("token") {
+		t.Errorf("expected excluded column not to be searchable or orderable")
+	}
+}
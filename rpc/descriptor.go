@@ -0,0 +1,110 @@
+package rpc
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	_ "google.golang.org/protobuf/types/known/structpb" // registers google/protobuf/struct.proto
+)
+
+// serviceName is the full name of the DataTables service.
+const serviceName = "datatables.v1.DataTables"
+
+// Descriptors of the messages of datatables.proto.
+var (
+	requestDesc  protoreflect.MessageDescriptor
+	responseDesc protoreflect.MessageDescriptor
+)
+
+func init() {
+	file, err := protodesc.NewFile(fileDescriptor(), protoregistry.GlobalFiles)
+	if err != nil {
+		panic(err)
+	}
+	requestDesc = file.Messages().ByName("Request")
+	responseDesc = file.Messages().ByName("Response")
+}
+
+// fileDescriptor returns the descriptor of datatables.proto. It is written
+// by hand so that the service can be served without generated code, and
+// must be kept in sync with the .proto file.
+func fileDescriptor() *descriptorpb.FileDescriptorProto {
+	return &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("datatables.proto"),
+		Package:    proto.String("datatables.v1"),
+		Dependency: []string{"google/protobuf/struct.proto"},
+		Syntax:     proto.String("proto3"),
+		Options:    &descriptorpb.FileOptions{GoPackage: proto.String("github.com/ZihxS/golang-gorm-datatables/rpc")},
+		MessageType: []*descriptorpb.DescriptorProto{
+			message("Search",
+				field("value", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+				field("regex", 2, descriptorpb.FieldDescriptorProto_TYPE_BOOL, ""),
+			),
+			message("Order",
+				field("column", 1, descriptorpb.FieldDescriptorProto_TYPE_INT32, ""),
+				field("dir", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+			),
+			message("Column",
+				field("data", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+				field("name", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+				field("searchable", 3, descriptorpb.FieldDescriptorProto_TYPE_BOOL, ""),
+				field("orderable", 4, descriptorpb.FieldDescriptorProto_TYPE_BOOL, ""),
+				field("search", 5, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".datatables.v1.Search"),
+			),
+			message("Request",
+				field("table", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+				field("draw", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, ""),
+				field("start", 3, descriptorpb.FieldDescriptorProto_TYPE_INT32, ""),
+				field("length", 4, descriptorpb.FieldDescriptorProto_TYPE_INT32, ""),
+				field("search", 5, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".datatables.v1.Search"),
+				repeated(field("order", 6, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".datatables.v1.Order")),
+				repeated(field("columns", 7, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".datatables.v1.Column")),
+				repeated(field("filters", 8, descriptorpb.FieldDescriptorProto_TYPE_STRING, "")),
+				field("cursor", 9, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+			),
+			message("Response",
+				field("draw", 1, descriptorpb.FieldDescriptorProto_TYPE_INT32, ""),
+				field("records_total", 2, descriptorpb.FieldDescriptorProto_TYPE_INT64, ""),
+				field("records_filtered", 3, descriptorpb.FieldDescriptorProto_TYPE_INT64, ""),
+				repeated(field("data", 4, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Value")),
+				field("meta", 5, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Struct"),
+			),
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("DataTables"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("Process"),
+				InputType:  proto.String(".datatables.v1.Request"),
+				OutputType: proto.String(".datatables.v1.Response"),
+			}},
+		}},
+	}
+}
+
+// message returns the descriptor of a message with the given fields.
+func message(name string, fields ...*descriptorpb.FieldDescriptorProto) *descriptorpb.DescriptorProto {
+	return &descriptorpb.DescriptorProto{Name: &name, Field: fields}
+}
+
+// field returns the descriptor of an optional field. typeName is the full
+// name of the message type of message fields, and is empty otherwise.
+func field(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+	f := &descriptorpb.FieldDescriptorProto{
+		Name:   &name,
+		Number: &number,
+		Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:   typ.Enum(),
+	}
+	if typeName != "" {
+		f.TypeName = &typeName
+	}
+	return f
+}
+
+// repeated marks the field as repeated.
+func repeated(f *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+	f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	return f
+}
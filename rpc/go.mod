@@ -0,0 +1,24 @@
+module github.com/ZihxS/golang-gorm-datatables/rpc
+
+go 1.24
+
+require (
+	github.com/DATA-DOG/go-sqlmock v1.5.2
+	github.com/ZihxS/golang-gorm-datatables v0.0.0-20261016200854-14a58338f5da
+	google.golang.org/grpc v1.72.2
+	google.golang.org/protobuf v1.36.5
+	gorm.io/driver/mysql v1.5.7
+	gorm.io/gorm v1.26.0
+)
+
+require (
+	filippo.io/edwards25519 v1.1.0 // indirect
+	github.com/go-sql-driver/mysql v1.9.2 // indirect
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+	golang.org/x/net v0.35.0 // indirect
+	golang.org/x/sys v0.30.0 // indirect
+	golang.org/x/text v0.24.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250218202821-56aae31c358a // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
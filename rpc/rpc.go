@@ -0,0 +1,118 @@
+// Package rpc provides a gRPC implementation of the DataTables service
+// defined in datatables.proto, so that internal services and non-browser
+// clients can query tables over gRPC with the same semantics as the HTTP
+// endpoints.
+//
+// Clients generate their stubs from datatables.proto. The server does not
+// need generated code and is registered on any grpc.ServiceRegistrar:
+//
+//	server := grpc.NewServer()
+//	rpc.Register(server, func(ctx context.Context, table string, req datatables.Request) (*datatables.DataTable, error) {
+//		return datatables.New(db.WithContext(ctx)).Model(models[table]).Req(req), nil
+//	})
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+
+	datatables "github.com/ZihxS/golang-gorm-datatables"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// BuildFunc returns the DataTable answering a request for the given table.
+// The request must be passed to the DataTable's Req method.
+type BuildFunc func(ctx context.Context, table string, req datatables.Request) (*datatables.DataTable, error)
+
+// Register registers the DataTables service on the given registrar, with
+// the DataTables returned by build answering the requests.
+//
+// The response keys of the DataTables must be the default ones: the draw,
+// recordsTotal, recordsFiltered, and data keys are mapped to the fields of
+// the Response message, and any other key is added to its meta field.
+// Errors returned by build are returned with the InvalidArgument code, and
+// errors of the DataTable with the Internal code.
+func Register(s grpc.ServiceRegistrar, build BuildFunc) {
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: serviceName,
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Process",
+			Handler: func(_ any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+				in := dynamicpb.NewMessage(requestDesc)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				handler := func(ctx context.Context, in any) (any, error) {
+					return process(ctx, build, in.(*dynamicpb.Message))
+				}
+				if interceptor == nil {
+					return handler(ctx, in)
+				}
+				info := &grpc.UnaryServerInfo{FullMethod: "/" + serviceName + "/Process"}
+				return interceptor(ctx, in, info, handler)
+			},
+		}},
+		Metadata: "datatables.proto",
+	}, struct{}{})
+}
+
+// request is the JSON form of the Request message.
+type request struct {
+	Table string `json:"table"`
+	datatables.Request
+}
+
+// process answers the given Request message with a Response message.
+func process(ctx context.Context, build BuildFunc, in *dynamicpb.Message) (*dynamicpb.Message, error) {
+	b, err := protojson.Marshal(in)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var req request
+	if err := json.Unmarshal(b, &req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	dt, err := build(ctx, req.Table, req.Request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	response, err := dt.Make()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	out, err := toResponse(response)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return out, nil
+}
+
+// toResponse converts the response of Make to a Response message.
+func toResponse(response map[string]any) (*dynamicpb.Message, error) {
+	fields := map[string]any{"meta": map[string]any{}}
+	for key, value := range response {
+		switch key {
+		case "draw", "recordsTotal", "recordsFiltered", "data":
+			fields[key] = value
+		default:
+			fields["meta"].(map[string]any)[key] = value
+		}
+	}
+
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	out := dynamicpb.NewMessage(responseDesc)
+	if err := protojson.Unmarshal(b, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
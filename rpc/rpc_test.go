@@ -0,0 +1,132 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	datatables "github.com/ZihxS/golang-gorm-datatables"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type User struct {
+	ID   int
+	Name string
+}
+
+// dial starts a server with the DataTables service answered by build and
+// returns a client connection to it.
+func dial(t *testing.T, build BuildFunc) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	Register(server, build)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// invoke calls the Process method with the request given as JSON and
+// returns the response as JSON.
+func invoke(conn *grpc.ClientConn, request string) (string, error) {
+	in := dynamicpb.NewMessage(requestDesc)
+	if err := protojson.Unmarshal([]byte(request), in); err != nil {
+		return "", err
+	}
+	out := dynamicpb.NewMessage(responseDesc)
+	if err := conn.Invoke(context.Background(), "/"+serviceName+"/Process", in, out); err != nil {
+		return "", err
+	}
+	b, err := protojson.MarshalOptions{}.Marshal(out)
+	return string(b), err
+}
+
+func TestProcess(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm DB: %v", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users` WHERE `name` LIKE ?")).
+		WithArgs("%John%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE `name` LIKE ? LIMIT ?")).
+		WithArgs("%John%", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+
+	var table string
+	conn := dial(t, func(ctx context.Context, name string, req datatables.Request) (*datatables.DataTable, error) {
+		table = name
+		if name != "users" {
+			return nil, errors.New("unknown table")
+		}
+		return datatables.New(db).Model(&User{}).WithData("source", "grpc").Req(req), nil
+	})
+
+	got, err := invoke(conn, `{"table":"users","draw":1,"length":10,"search":{"value":"John"},"columns":[{"data":"name","name":"name","searchable":true}]}`)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := `{"draw":1,"recordsTotal":"3","recordsFiltered":"1","data":[{"id":1,"name":"John Doe"}],"meta":{"source":"grpc"}}`
+	if normalize(t, got) != normalize(t, expected) {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+	if table != "users" {
+		t.Errorf("expected table users, got %q", table)
+	}
+
+	_, err = invoke(conn, `{"table":"orders","draw":1}`)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+// normalize returns the JSON response in the canonical protojson form, so
+// that responses can be compared regardless of whitespace.
+func normalize(t *testing.T, s string) string {
+	t.Helper()
+
+	m := dynamicpb.NewMessage(responseDesc)
+	if err := protojson.Unmarshal([]byte(s), m); err != nil {
+		t.Fatalf("failed to parse %s: %v", s, err)
+	}
+	b, err := protojson.MarshalOptions{}.Marshal(m)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	return string(b)
+}
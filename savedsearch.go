@@ -0,0 +1,155 @@
+package datatables
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrSavedSearchNotFound is returned by a SavedSearchStore when no saved
+// search exists with the requested ID.
+var ErrSavedSearchNotFound = errors.New("saved search not found")
+
+// SavedSearch is a named snapshot of a DataTable request, powering "saved
+// views" in admin UIs.
+//
+// Fields:
+//   - ID: The identifier assigned by the store.
+//   - Owner: The user who saved the search.
+//   - Name: The display name of the saved search.
+//   - Table: The table the search applies to.
+//   - Request: The saved request: search, per-column filters, ordering,
+//     page length, and filter presets.
+//   - CreatedAt: When the search was saved.
+type SavedSearch struct {
+	ID        string
+	Owner     string
+	Name      string
+	Table     string
+	Request   Request
+	CreatedAt time.Time
+}
+
+// SavedSearchStore persists saved searches. Implementations must be safe
+// for concurrent use.
+type SavedSearchStore interface {
+	// Save stores the saved search, assigning its ID if empty, and returns
+	// the stored value.
+	Save(ctx context.Context, search SavedSearch) (SavedSearch, error)
+	// Get returns the saved search with the given ID, or
+	// ErrSavedSearchNotFound.
+	Get(ctx context.Context, id string) (SavedSearch, error)
+	// List returns the saved searches of the owner for the table.
+	List(ctx context.Context, owner, table string) ([]SavedSearch, error)
+	// Delete removes the saved search with the given ID.
+	Delete(ctx context.Context, id string) error
+}
+
+// memorySavedSearchStore is an in-memory SavedSearchStore.
+type memorySavedSearchStore struct {
+	mu       sync.RWMutex
+	searches map[string]SavedSearch
+}
+
+// NewMemorySavedSearchStore returns a SavedSearchStore keeping the saved
+// searches in memory, suitable for tests and single-instance deployments.
+func NewMemorySavedSearchStore() SavedSearchStore {
+	return &memorySavedSearchStore{searches: make(map[string]SavedSearch)}
+}
+
+// Save implements SavedSearchStore.
+func (s *memorySavedSearchStore) Save(ctx context.Context, search SavedSearch) (SavedSearch, error) {
+	if search.ID == "" {
+		id := make([]byte, 8)
+		if _, err := rand.Read(id); err != nil {
+			return SavedSearch{}, err
+		}
+		search.ID = hex.EncodeToString(id)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.searches[search.ID] = search
+	return search, nil
+}
+
+// Get implements SavedSearchStore.
+func (s *memorySavedSearchStore) Get(ctx context.Context, id string) (SavedSearch, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	search, ok := s.searches[id]
+	if !ok {
+		return SavedSearch{}, ErrSavedSearchNotFound
+	}
+	return search, nil
+}
+
+// List implements SavedSearchStore. The searches are sorted by creation
+// time.
+func (s *memorySavedSearchStore) List(ctx context.Context, owner, table string) ([]SavedSearch, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var searches []SavedSearch
+	for _, search := range s.searches {
+		if search.Owner == owner && search.Table == table {
+			searches = append(searches, search)
+		}
+	}
+	slices.SortFunc(searches, func(a, b SavedSearch) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return searches, nil
+}
+
+// Delete implements SavedSearchStore.
+func (s *memorySavedSearchStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.searches, id)
+	return nil
+}
+
+// SaveSearch persists the DataTable's current request under the given name
+// for the owner. The draw counter and the start position are not saved, so
+// replaying the search starts from the first page.
+func (dt *DataTable) SaveSearch(ctx context.Context, store SavedSearchStore, owner, name string) (SavedSearch, error) {
+	req := dt.req
+	req.Draw = 0
+	req.Start = 0
+	return store.Save(ctx, SavedSearch{
+		Owner:     owner,
+		Name:      name,
+		Table:     dt.tableName(),
+		Request:   req,
+		CreatedAt: time.Now(),
+	})
+}
+
+// ApplySavedSearch replays the saved search of the owner with the given ID
+// on the DataTable, replacing the search, per-column filters, ordering, page
+// length, and filter presets of the current request. The draw counter of
+// the current request is kept so the client accepts the response.
+//
+// Returns an error if the saved search cannot be loaded, and
+// ErrSavedSearchNotFound if it belongs to another owner or another table,
+// so that IDs of other users cannot be probed.
+func (dt *DataTable) ApplySavedSearch(ctx context.Context, store SavedSearchStore, owner, id string) error {
+	search, err := store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if search.Owner != owner {
+		return ErrSavedSearchNotFound
+	}
+	if table := dt.tableName(); search.Table != "" && table != "" && search.Table != table {
+		return ErrSavedSearchNotFound
+	}
+
+	req := search.Request
+	req.Draw = dt.req.Draw
+	dt.Req(req)
+	return nil
+}
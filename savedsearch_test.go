@@ -0,0 +1,74 @@
+package datatables
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSavedSearches(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemorySavedSearchStore()
+
+	dt := New(nil)
+	dt.Model("users")
+	dt.Req(Request{
+		Draw:    4,
+		Start:   20,
+		Length:  25,
+		Search:  Search{Value: "John"},
+		Order:   []Order{{Column: 1, Dir: "desc"}},
+		Filters: []string{"active_only"},
+		Columns: []ColumnRequest{
+			{Name: "id", Data: "id"},
+			{Name: "name", Data: "name", Search: Search{Value: "Doe"}},
+		},
+	})
+
+	saved, err := dt.SaveSearch(ctx, store, "alice", "Johns")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if saved.ID == "" || saved.Table != "users" || saved.Request.Draw != 0 || saved.Request.Start != 0 {
+		t.Errorf("unexpected saved search %+v", saved)
+	}
+
+	list, err := store.List(ctx, "alice", "users")
+	if err != nil || len(list) != 1 || list[0].ID != saved.ID {
+		t.Errorf("expected saved search to be listed, got %v (%v)", list, err)
+	}
+
+	replay := New(nil)
+	replay.Model("users")
+	replay.Req(Request{Draw: 9})
+	if err := replay.ApplySavedSearch(ctx, store, "alice", saved.ID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := saved.Request
+	expected.Draw = 9
+	if !reflect.DeepEqual(replay.req, expected) {
+		t.Errorf("expected request %+v, got %+v", expected, replay.req)
+	}
+	if _, ok := replay.columnsMap["name"]; !ok {
+		t.Errorf("expected saved columns to be added")
+	}
+
+	if err := replay.ApplySavedSearch(ctx, store, "mallory", saved.ID); !errors.Is(err, ErrSavedSearchNotFound) {
+		t.Errorf("expected ErrSavedSearchNotFound for another owner, got %v", err)
+	}
+
+	other := New(nil)
+	other.Model("orders")
+	if err := other.ApplySavedSearch(ctx, store, "alice", saved.ID); !errors.Is(err, ErrSavedSearchNotFound) {
+		t.Errorf("expected ErrSavedSearchNotFound for another table, got %v", err)
+	}
+
+	if err := store.Delete(ctx, saved.ID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := replay.ApplySavedSearch(ctx, store, "alice", saved.ID); !errors.Is(err, ErrSavedSearchNotFound) {
+		t.Errorf("expected ErrSavedSearchNotFound after delete, got %v", err)
+	}
+}
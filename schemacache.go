@@ -0,0 +1,83 @@
+package datatables
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// modelInfo holds what is derived from the parsed schema of a model: the
+// field lookups and the column type detection. It is computed once per
+// schema and shared, read-only, by every DataTable using the model.
+//
+// Fields:
+//   - schema: The parsed schema of the model.
+//   - typ: The type of the model the schema was parsed from.
+//   - fields: The set of field names and database column names.
+//   - uuidFields: The fields holding a UUID.
+//   - citextFields: The fields of type citext.
+type modelInfo struct {
+	schema       *schema.Schema
+	typ          reflect.Type
+	fields       map[string]bool
+	uuidFields   []*schema.Field
+	citextFields []*schema.Field
+}
+
+// modelInfos caches the modelInfo of every parsed schema. GORM caches the
+// schemas per model type and naming strategy, so the schema is the key.
+var modelInfos sync.Map
+
+// newModelInfo derives the modelInfo of the given schema.
+func newModelInfo(s *schema.Schema, typ reflect.Type) *modelInfo {
+	info := &modelInfo{
+		schema: s,
+		typ:    typ,
+		fields: make(map[string]bool, len(s.Fields)*2),
+	}
+	for _, field := range s.Fields {
+		info.fields[field.Name] = true
+		if field.DBName != "" {
+			info.fields[field.DBName] = true
+		}
+		if isUUIDField(field.FieldType, string(field.DataType)) {
+			info.uuidFields = append(info.uuidFields, field)
+		}
+		if strings.EqualFold(string(field.DataType), ColumnTypeCIText) {
+			info.citextFields = append(info.citextFields, field)
+		}
+	}
+	return info
+}
+
+// modelInfo returns the modelInfo of the DataTable's model, or nil if the
+// model is not a struct that can be parsed. It is memoized on the DataTable
+// until the model changes, and cached per schema across DataTables. The
+// model is parsed on a statement of its own, since the statement of a
+// session is shared by concurrent requests.
+func (dt *DataTable) modelInfo() *modelInfo {
+	if dt.tx == nil || dt.tx.Statement == nil {
+		return nil
+	}
+	if _, ok := dt.model.(string); ok || dt.model == nil {
+		return nil
+	}
+	typ := reflect.TypeOf(dt.model)
+	if dt.info != nil && dt.info.typ == typ {
+		return dt.info
+	}
+
+	stmt := &gorm.Statement{DB: dt.tx}
+	if err := stmt.Parse(dt.model); err != nil {
+		return nil
+	}
+	cached, ok := modelInfos.Load(stmt.Schema)
+	if !ok {
+		cached, _ = modelInfos.LoadOrStore(stmt.Schema, newModelInfo(stmt.Schema, typ))
+	}
+	dt.info = cached.(*modelInfo)
+	return dt.info
+}
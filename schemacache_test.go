@@ -0,0 +1,53 @@
+package datatables
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestModelInfo(t *testing.T) {
+	db, _ := newMockDB(t)
+
+	first := New(db).Model(&User{}).modelInfo()
+	if first == nil {
+		t.Fatal("expected model info, got nil")
+	}
+	if !first.fields["name"] || !first.fields["Name"] {
+		t.Errorf("expected the name field, got %v", first.fields)
+	}
+	if second := New(db).Model(&User{}).modelInfo(); second != first {
+		t.Error("expected the model info to be shared")
+	}
+
+	dt := New(db).Model(&User{})
+	dt.modelInfo()
+	if info := dt.Model(&Article{}).modelInfo(); info == nil || info.schema.Table != "articles" {
+		t.Errorf("expected the model info of the new model, got %+v", info)
+	}
+	if info := dt.Model("articles").modelInfo(); info != nil {
+		t.Errorf("expected no model info for a table name, got %+v", info)
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if info := New(db).Model(&Article{}).modelInfo(); info == nil {
+				t.Error("expected model info, got nil")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkModelInfo(b *testing.B) {
+	db, _ := newMockDB(b)
+	b.ReportAllocs()
+	for b.Loop() {
+		dt := New(db).Model(&User{})
+		dt.detectUUIDColumns()
+		dt.detectCITextColumns()
+		dt.detectComputedColumns()
+	}
+}
@@ -0,0 +1,111 @@
+package datatables
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// collationPattern matches valid collation names, such as utf8mb4_general_ci.
+var collationPattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// validateCollation returns an error if the search collation of the config is
+// not a valid collation name. Collation names cannot be bound as arguments,
+// so they are checked before being written into the SQL.
+func validateCollation(cfg Config) error {
+	if cfg.SearchCollation != "" && !collationPattern.MatchString(cfg.SearchCollation) {
+		return fmt.Errorf("invalid search collation %q", cfg.SearchCollation)
+	}
+	return nil
+}
+
+// searchColumn returns the expression compared against the search value for
+// the given column. When a search collation is configured, the column is
+// followed by a COLLATE clause so that the matching does not depend on the
+// collation of the column.
+func (dt *DataTable) searchColumn(col Column) any {
+	if dt.config.SearchCollation == "" {
+		return col.sqlColumn()
+	}
+	return clause.Expr{
+		SQL:  "? COLLATE " + dt.config.SearchCollation,
+		Vars: []any{col.sqlColumn()},
+	}
+}
+
+// searchCondition returns the condition matching the given column against
+// the search value, with a REGEXP when the search is a regex and a LIKE
+// otherwise. When the search is case-insensitive, the value is lowercased,
+// and with Config.LowerColumns, both sides are wrapped in LOWER() instead.
+//
+// On Postgres, case-insensitive searches use ILIKE and ~* instead, except on
+// citext columns, which already compare case-insensitively and keep plain
+// LIKE and ~ so that their indexes can be used.
+func (dt *DataTable) searchCondition(col Column, val string, regex bool) clause.Expression {
+	if dt.tx != nil && dt.tx.Dialector.Name() == "postgres" {
+		insensitive := dt.config.CaseInsensitive && col.Type != ColumnTypeCIText
+		op := "LIKE"
+		switch {
+		case regex && insensitive:
+			op = "~*"
+		case regex:
+			op = "~"
+		case insensitive:
+			op = "ILIKE"
+		}
+		if !regex {
+			val = "%" + val + "%"
+		}
+		return clause.Expr{
+			SQL:  "? " + op + " ?",
+			Vars: []any{dt.searchColumn(col), val},
+		}
+	}
+
+	if dt.config.CaseInsensitive && dt.config.LowerColumns {
+		op := "LIKE"
+		if regex {
+			op = "REGEXP"
+		} else {
+			val = "%" + val + "%"
+		}
+		return clause.Expr{
+			SQL:  "LOWER(?) " + op + " LOWER(?)",
+			Vars: []any{dt.searchColumn(col), val},
+		}
+	}
+
+	if dt.config.CaseInsensitive {
+		val = strings.ToLower(val)
+	}
+	if regex {
+		return clause.Expr{
+			SQL:  "? REGEXP ?",
+			Vars: []any{dt.searchColumn(col), val},
+		}
+	}
+	return clause.Like{
+		Column: dt.searchColumn(col),
+		Value:  "%" + val + "%",
+	}
+}
+
+// detectCITextColumns sets the Type of the columns backed by a citext field
+// of the model to ColumnTypeCIText, unless a type is already set.
+func (dt *DataTable) detectCITextColumns() {
+	info := dt.modelInfo()
+	if info == nil {
+		return
+	}
+
+	for _, field := range info.citextFields {
+		for _, key := range []string{field.DBName, field.Name} {
+			if col, exists := dt.columnsMap[key]; exists && col.Type == "" {
+				col.Type = ColumnTypeCIText
+				dt.columnsMap[key] = col
+			}
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package datatables
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestSearchCollation(t *testing.T) {
+	tests := []struct {
+		name  string
+		regex bool
+		sql   string
+		arg   string
+	}{
+		{name: "like", sql: "SELECT count(*) FROM `users` WHERE `name` COLLATE utf8mb4_general_ci LIKE ?", arg: "%José%"},
+		{name: "regex", regex: true, sql: "SELECT count(*) FROM `users` WHERE `name` COLLATE utf8mb4_general_ci REGEXP ?", arg: "José"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+
+			mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(10)))
+			mock.ExpectQuery(qm(tt.sql)).
+				WithArgs(tt.arg).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+
+			cfg := defaultConfig()
+			cfg.SearchCollation = "utf8mb4_general_ci"
+			dt := New(db, WithConfig(cfg)).Model(&User{})
+			dt.Req(Request{
+				Draw:    1,
+				Search:  Search{Value: "José", Regex: tt.regex},
+				Columns: []ColumnRequest{{Name: "name", Data: "name", Searchable: true}},
+			})
+
+			if _, _, err := dt.Counts(); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateCollation(t *testing.T) {
+	tests := []struct {
+		name      string
+		collation string
+		wantErr   bool
+	}{
+		{name: "empty", collation: ""},
+		{name: "valid", collation: "utf8mb4_unicode_ci"},
+		{name: "injection", collation: "utf8mb4_general_ci; DROP TABLE users", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := defaultConfig()
+			cfg.SearchCollation = tt.collation
+			if err := validateCollation(cfg); (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+type Tag struct {
+	ID    int
+	Name  string
+	Slug  string `gorm:"type:citext"`
+	Label string
+}
+
+func TestPostgresSearch(t *testing.T) {
+	tests := []struct {
+		name            string
+		caseInsensitive bool
+		regex           bool
+		sql             string
+		args            []driver.Value
+	}{
+		{
+			name:            "case_insensitive",
+			caseInsensitive: true,
+			sql:             "SELECT count(*) FROM `tags` WHERE (`name` ILIKE ? OR `slug` LIKE ? OR `label` LIKE ?)",
+			args:            []driver.Value{"%Go%", "%Go%", "%Go%"},
+		},
+		{
+			name:            "case_insensitive_regex",
+			caseInsensitive: true,
+			regex:           true,
+			sql:             "SELECT count(*) FROM `tags` WHERE (`name` ~* ? OR `slug` ~ ? OR `label` ~ ?)",
+			args:            []driver.Value{"^Go", "^Go", "^Go"},
+		},
+		{
+			name: "case_sensitive",
+			sql:  "SELECT count(*) FROM `tags` WHERE (`name` LIKE ? OR `slug` LIKE ? OR `label` LIKE ?)",
+			args: []driver.Value{"%Go%", "%Go%", "%Go%"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDBWithDialect(t, "postgres")
+
+			mock.ExpectQuery(qm("SELECT count(*) FROM `tags`")).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(10)))
+			mock.ExpectQuery(qm(tt.sql)).
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+
+			search := "Go"
+			if tt.regex {
+				search = "^Go"
+			}
+			cfg := defaultConfig()
+			cfg.CaseInsensitive = tt.caseInsensitive
+			dt := New(db, WithConfig(cfg)).Model(&Tag{})
+			dt.AddColumn(Column{Name: "label", Data: "label", Type: ColumnTypeCIText})
+			dt.Req(Request{
+				Draw:   1,
+				Search: Search{Value: search, Regex: tt.regex},
+				Columns: []ColumnRequest{
+					{Name: "name", Data: "name", Searchable: true},
+					{Name: "slug", Data: "slug", Searchable: true},
+					{Name: "label", Data: "label", Searchable: true},
+				},
+			})
+
+			if _, _, err := dt.Counts(); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestSearchLowerColumns(t *testing.T) {
+	tests := []struct {
+		name         string
+		lowerColumns bool
+		regex        bool
+		sql          string
+		arg          string
+	}{
+		{name: "like", lowerColumns: true, sql: "SELECT count(*) FROM `users` WHERE LOWER(`name`) LIKE LOWER(?)", arg: "%JoHn%"},
+		{name: "regex", lowerColumns: true, regex: true, sql: "SELECT count(*) FROM `users` WHERE LOWER(`name`) REGEXP LOWER(?)", arg: "JoHn"},
+		{name: "disabled", sql: "SELECT count(*) FROM `users` WHERE `name` LIKE ?", arg: "%john%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+
+			mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(10)))
+			mock.ExpectQuery(qm(tt.sql)).
+				WithArgs(tt.arg).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(1)))
+
+			cfg := defaultConfig()
+			cfg.CaseInsensitive = true
+			cfg.LowerColumns = tt.lowerColumns
+			dt := New(db, WithConfig(cfg)).Model(&User{})
+			dt.Req(Request{
+				Draw:    1,
+				Search:  Search{Value: "JoHn", Regex: tt.regex},
+				Columns: []ColumnRequest{{Name: "name", Data: "name", Searchable: true}},
+			})
+
+			if _, _, err := dt.Counts(); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package datatables
+
+import "gorm.io/gorm"
+
+// Searcher applies the global search of a request to the filtered query, so
+// that a full-text or external-index search strategy can be plugged in while
+// the counts, the ordering, and the pagination stay with the DataTable.
+//
+// Search is called with the search of the request, which has a non-empty
+// value, and the columns it applies to: the defined columns of the request
+// that are allowed and searchable. It is called once per query the search is
+// applied to, the filtered count and the data query included.
+type Searcher interface {
+	Search(query *gorm.DB, search Search, columns []Column) *gorm.DB
+}
+
+// SearcherFunc is an adapter that allows the use of an ordinary function as a
+// Searcher.
+type SearcherFunc func(query *gorm.DB, search Search, columns []Column) *gorm.DB
+
+// Search calls f(query, search, columns).
+func (f SearcherFunc) Search(query *gorm.DB, search Search, columns []Column) *gorm.DB {
+	return f(query, search, columns)
+}
+
+// SetSearcher sets the strategy used to apply the global search, such as a
+// full-text search. When no searcher is set, the columns are matched with
+// LIKE, or with REGEXP for regex searches, according to the search settings
+// of the config.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) SetSearcher(searcher Searcher) *DataTable {
+	dt.searcher = searcher
+	return dt
+}
@@ -0,0 +1,56 @@
+package datatables
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestSetSearcher(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(10))
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE MATCH(`name`) AGAINST(? IN BOOLEAN MODE)")).
+		WithArgs("john").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(qm("SELECT * FROM `users` WHERE MATCH(`name`) AGAINST(? IN BOOLEAN MODE) LIMIT ?")).
+		WithArgs("john", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+
+	var searched []string
+	fullText := SearcherFunc(func(query *gorm.DB, search Search, columns []Column) *gorm.DB {
+		searched = searched[:0]
+		for _, col := range columns {
+			searched = append(searched, col.Data)
+		}
+		return query.Where("MATCH(?) AGAINST(? IN BOOLEAN MODE)", clause.Column{Name: "name"}, search.Value)
+	})
+
+	dt := New(db).Model(&User{}).SetSearcher(fullText)
+	dt.Req(Request{
+		Draw:   1,
+		Length: 10,
+		Search: Search{Value: "john"},
+		Columns: []ColumnRequest{
+			{Name: "id", Data: "id"},
+			{Name: "name", Data: "name", Searchable: true},
+		},
+	})
+
+	response, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if response["recordsFiltered"] != int64(1) {
+		t.Errorf("expected 1 filtered record, got %v", response["recordsFiltered"])
+	}
+	if len(searched) != 1 || searched[0] != "name" {
+		t.Errorf("expected the searcher to get the name column, got %v", searched)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
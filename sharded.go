@@ -0,0 +1,237 @@
+package datatables
+
+import (
+	"cmp"
+	"errors"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ShardedDataSource serves a logical table split across several shards,
+// such as databases or tables, by running the same DataTable request on
+// every shard and merging the results.
+type ShardedDataSource struct {
+	shards     []*gorm.DB
+	build      func(tx *gorm.DB) *DataTable
+	translator Translator
+}
+
+// NewShardedDataSource returns a ShardedDataSource querying the given shards
+// with the DataTables returned by build, which is called once per shard and
+// request with the DB of the shard, such as db.Table("orders_2024"), and
+// must define the same columns on every shard.
+func NewShardedDataSource(build func(tx *gorm.DB) *DataTable, shards ...*gorm.DB) *ShardedDataSource {
+	return &ShardedDataSource{shards: shards, build: build}
+}
+
+// SetTranslator sets the message catalog used for the errors detected before
+// any DataTable is built, such as a source without shards. The errors of the
+// shards are translated by their DataTables.
+//
+// Returns the updated ShardedDataSource instance.
+func (s *ShardedDataSource) SetTranslator(translator Translator) *ShardedDataSource {
+	s.translator = translator
+	return s
+}
+
+// Make processes the request on every shard concurrently and returns a
+// DataTables compatible response.
+//
+// Every shard fetches its filtered rows up to the end of the requested page,
+// ordered like the request. The partial results are merged, sorted again in
+// memory with the same order, and paginated, and the total and filtered
+// counts of the shards are summed. The merged page is then rendered by the
+// DataTable of the first shard, with its render functions, hooks, and
+// response format.
+//
+// The page length of the request is checked against Config.AllowedLengths
+// of the first shard's DataTable before the shards are queried, the shard
+// requests being exempt since they reach up to the end of the page.
+//
+// Numbers and times are compared by value when sorting, and other values by
+// their string representation, byte-wise, which may differ from the order
+// of the collation of the database, such as for case-insensitive or
+// accented strings. Cursor pagination is not supported.
+func (s *ShardedDataSource) Make(req Request) (map[string]any, error) {
+	if len(s.shards) == 0 {
+		return nil, errors.New(translatef(s.translator, MsgNoShards, "no shards provided"))
+	}
+
+	tables := make([]*DataTable, len(s.shards))
+	for i, shard := range s.shards {
+		tables[i] = s.build(shard)
+		if tables[i].config.CursorPagination {
+			return nil, errors.New(tables[i].translate(MsgShardedCursor, "cursor pagination is not supported on sharded data sources"))
+		}
+	}
+
+	length, err := tables[0].allowedLength(req.Length)
+	if err != nil {
+		return nil, err
+	}
+	req.Length = length
+	shardReq := req
+	shardReq.Start = 0
+	if req.Length >= 0 {
+		shardReq.Length = req.Start + req.Length
+	}
+	for _, dt := range tables {
+		dt.Req(shardReq)
+		dt.skipLengthCheck = true
+	}
+
+	rows := make([][]map[string]any, len(tables))
+	totals := make([]int64, len(tables))
+	filtereds := make([]int64, len(tables))
+	errs := make([]error, len(tables))
+	var wg sync.WaitGroup
+	for i, dt := range tables {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if errs[i] = dt.Validate(); errs[i] != nil {
+				return
+			}
+			var data any
+			data, totals[i], filtereds[i], errs[i] = dt.processQuery()
+			if errs[i] == nil {
+				rows[i] = data.([]map[string]any)
+			}
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	dt := tables[0]
+	var (
+		total, filtered int64
+		merged          []map[string]any
+	)
+	for i := range tables {
+		total += totals[i]
+		filtered += filtereds[i]
+		merged = append(merged, rows[i]...)
+	}
+	dt.sortRows(merged)
+
+	dt.req.Start, dt.req.Length = req.Start, req.Length
+	if dt.config.Paginate {
+		start := min(req.Start, len(merged))
+		end := len(merged)
+		if req.Length >= 0 {
+			end = min(start+req.Length, end)
+		}
+		merged = merged[start:end]
+	}
+
+	res, err := dt.render(merged, total, filtered)
+	if err != nil {
+		return nil, err
+	}
+	return dt.buildResponse(res), nil
+}
+
+// rowOrder is a column the rows are sorted by in memory.
+type rowOrder struct {
+	data string
+	desc bool
+}
+
+// rowOrders returns the columns the rows are sorted by, resolved like in
+// applyOrder: the allowed and orderable columns of the request orders or, if
+// the request has none, the columns of the default sort, sorted by name.
+func (dt *DataTable) rowOrders() []rowOrder {
+	if !dt.config.Orderable {
+		return nil
+	}
+
+	var orders []rowOrder
+	for _, order := range dt.req.Order {
+		if order.Column < 0 || order.Column >= len(dt.req.Columns) {
+			continue
+		}
+		data := dt.req.Columns[order.Column].Data
+		if col, exists := dt.columnsMap[data]; exists && col.Orderable && dt.isColumnAllowed(data) {
+			orders = append(orders, rowOrder{data: data, desc: strings.ToUpper(order.Dir) == orderDescending})
+		}
+	}
+
+	if len(orders) == 0 {
+		for _, data := range slices.Sorted(maps.Keys(dt.config.DefaultSort)) {
+			if _, exists := dt.columnsMap[data]; exists {
+				orders = append(orders, rowOrder{data: data, desc: strings.ToUpper(dt.config.DefaultSort[data]) == orderDescending})
+			}
+		}
+	}
+	return orders
+}
+
+// sortRows sorts the rows in memory by the order of the request, keeping the
+// relative order of equal rows.
+func (dt *DataTable) sortRows(rows []map[string]any) {
+	orders := dt.rowOrders()
+	if len(orders) == 0 {
+		return
+	}
+	slices.SortStableFunc(rows, func(a, b map[string]any) int {
+		for _, order := range orders {
+			c := compareValues(a[order.data], b[order.data])
+			if order.desc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+}
+
+// compareValues compares two values read from the database: NULL first,
+// then numbers and times by value, and other values by their string
+// representation, byte-wise rather than by the collation of the database.
+func compareValues(a, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	if x, ok := numericValue(a); ok {
+		if y, ok := numericValue(b); ok {
+			return cmp.Compare(x, y)
+		}
+	}
+	if x, ok := a.(time.Time); ok {
+		if y, ok := b.(time.Time); ok {
+			return x.Compare(y)
+		}
+	}
+	return strings.Compare(facetValue(a), facetValue(b))
+}
+
+// numericValue returns the value of a number as a float64, and false if the
+// value is not a number.
+func numericValue(value any) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
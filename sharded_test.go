@@ -0,0 +1,132 @@
+package datatables
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+func TestShardedDataSource(t *testing.T) {
+	db1, mock1 := newMockDB(t)
+	db2, mock2 := newMockDB(t)
+
+	expectShard := func(mock sqlmock.Sqlmock, total, filtered int64, amounts ...int) {
+		mock.ExpectQuery(qm("SELECT count(*) FROM `orders`")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(total))
+		mock.ExpectQuery(qm("SELECT count(*) FROM `orders` WHERE `status` LIKE ?")).
+			WithArgs("%paid%").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(filtered))
+		rows := sqlmock.NewRows([]string{"amount", "status"})
+		for _, amount := range amounts {
+			rows.AddRow(amount, "paid")
+		}
+		mock.ExpectQuery(qm("SELECT * FROM `orders` WHERE `status` LIKE ? ORDER BY `amount` DESC LIMIT ?")).
+			WithArgs("%paid%", 3).
+			WillReturnRows(rows)
+	}
+	expectShard(mock1, 5, 3, 50, 20, 10)
+	expectShard(mock2, 4, 2, 40, 30)
+
+	source := NewShardedDataSource(func(tx *gorm.DB) *DataTable {
+		return New(tx.Table("orders"))
+	}, db1, db2)
+
+	res, err := source.Make(Request{
+		Draw:   1,
+		Start:  1,
+		Length: 2,
+		Search: Search{Value: "paid"},
+		Order:  []Order{{Column: 0, Dir: "desc"}},
+		Columns: []ColumnRequest{
+			{Name: "amount", Data: "amount", Orderable: true},
+			{Name: "status", Data: "status", Searchable: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res["recordsTotal"] != int64(9) || res["recordsFiltered"] != int64(5) {
+		t.Errorf("expected 9 total and 5 filtered records, got %v and %v", res["recordsTotal"], res["recordsFiltered"])
+	}
+	expected := []map[string]any{
+		{"amount": int64(40), "status": "paid"},
+		{"amount": int64(30), "status": "paid"},
+	}
+	if !reflect.DeepEqual(res["data"], expected) {
+		t.Errorf("expected data %v, got %v", expected, res["data"])
+	}
+
+	for _, mock := range []sqlmock.Sqlmock{mock1, mock2} {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet expectations: %v", err)
+		}
+	}
+
+	t.Run("no_shards", func(t *testing.T) {
+		if _, err := NewShardedDataSource(func(tx *gorm.DB) *DataTable { return New(tx) }).Make(Request{Draw: 1}); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestShardedDataSourceAllowedLengths(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectQuery(qm("SELECT count(*) FROM `orders`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(200))
+	mock.ExpectQuery(qm("SELECT * FROM `orders` ORDER BY `amount` DESC LIMIT ?")).
+		WithArgs(125).
+		WillReturnRows(sqlmock.NewRows([]string{"amount"}))
+
+	build := func(tx *gorm.DB) *DataTable {
+		cfg := defaultConfig()
+		cfg.AllowedLengths = []int{10, 25, 100}
+		return New(tx.Table("orders"), WithConfig(cfg))
+	}
+	req := Request{
+		Draw:    1,
+		Start:   100,
+		Length:  25,
+		Order:   []Order{{Column: 0, Dir: "desc"}},
+		Columns: []ColumnRequest{{Name: "amount", Data: "amount", Orderable: true}},
+	}
+	if _, err := NewShardedDataSource(build, db).Make(req); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+
+	rejecting := func(tx *gorm.DB) *DataTable {
+		dt := build(tx)
+		dt.config.RejectInvalidLength = true
+		return dt
+	}
+	req.Length = 50
+	if _, err := NewShardedDataSource(rejecting, db).Make(req); err == nil {
+		t.Error("expected the invalid length of the request to be rejected")
+	}
+}
+
+func TestCompareValues(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		a, b     any
+		expected int
+	}{
+		{nil, nil, 0},
+		{nil, 1, -1},
+		{"a", nil, 1},
+		{int64(9), 10.5, -1},
+		{uint8(3), int32(3), 0},
+		{now.Add(time.Hour), now, 1},
+		{"b", []byte("a"), 1},
+	}
+	for _, tt := range tests {
+		if got := compareValues(tt.a, tt.b); got != tt.expected {
+			t.Errorf("compareValues(%v, %v) = %d, expected %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
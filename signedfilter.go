@@ -0,0 +1,212 @@
+package datatables
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvalidSignedFilter is returned when a signed filter of the request is
+// malformed, its signature does not match, it was issued for another
+// purpose, it has expired, or a required one is missing.
+var ErrInvalidSignedFilter = errors.New("invalid signed filter")
+
+// minSignedFilterKey is the minimum length in bytes of the keys signing the
+// signed filters, the size of an HMAC-SHA256 signature.
+const minSignedFilterKey = 32
+
+// signedFilters holds the verification settings of the signed filters.
+type signedFilters struct {
+	key      []byte
+	purpose  string
+	required bool
+}
+
+// signedPayload is the signed content of a signed filter token.
+type signedPayload struct {
+	Purpose    string         `json:"purpose"`
+	Expires    int64          `json:"expires"`
+	Predicates map[string]any `json:"predicates"`
+}
+
+// SignFilter returns a signed filter token encoding the given predicates,
+// which map a column to the value it must be equal to, or to a slice of the
+// values it must be in. The token is meant to be embedded in the page and
+// sent back by the client with the "signed_filter" request parameter, so
+// that mandatory constraints cannot be tampered with; see SignedFilters.
+//
+// The purpose identifies the table, or the page, the token is issued for,
+// such as "invoices", and the token is only accepted by the DataTables
+// verifying signed filters for the same purpose, until it expires after the
+// given time to live. A token issued for another table, or an old one,
+// can therefore not be replayed in place of the expected one.
+//
+// The token is the base64url encoded JSON of the purpose, the expiry, and
+// the predicates followed by their HMAC-SHA256 signature with the given
+// key, so the predicates are readable by the client and the values must be
+// JSON encodable. Returns an error if the key is shorter than 32 bytes, the
+// purpose is empty, or the time to live is not positive.
+func SignFilter(key []byte, purpose string, predicates map[string]any, ttl time.Duration) (string, error) {
+	if len(key) < minSignedFilterKey {
+		return "", fmt.Errorf("signed filter key must be at least %d bytes", minSignedFilterKey)
+	}
+	if purpose == "" {
+		return "", errors.New("signed filter purpose is required")
+	}
+	if ttl <= 0 {
+		return "", errors.New("signed filter time to live must be positive")
+	}
+	payload, err := json.Marshal(signedPayload{
+		Purpose:    purpose,
+		Expires:    time.Now().Add(ttl).Unix(),
+		Predicates: predicates,
+	})
+	if err != nil {
+		return "", err
+	}
+	return encodeSignedFilter(key, payload), nil
+}
+
+// encodeSignedFilter returns the token of the payload signed with the key.
+func encodeSignedFilter(key, payload []byte) string {
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(signFilter(key, payload))
+}
+
+// signFilter returns the HMAC-SHA256 signature of the payload.
+func signFilter(key, payload []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// verifySignedFilter verifies the signature, the purpose, and the expiry of
+// a token returned by SignFilter and returns its predicates. Numbers are
+// decoded as int64 when they are integers, and as float64 otherwise.
+func verifySignedFilter(key []byte, purpose, token string) (map[string]any, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidSignedFilter
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidSignedFilter
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sig, signFilter(key, payload)) {
+		return nil, ErrInvalidSignedFilter
+	}
+
+	var p signedPayload
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	if err := dec.Decode(&p); err != nil {
+		return nil, ErrInvalidSignedFilter
+	}
+	if p.Purpose != purpose || time.Now().Unix() >= p.Expires {
+		return nil, ErrInvalidSignedFilter
+	}
+	for col, value := range p.Predicates {
+		p.Predicates[col] = filterValue(value)
+	}
+	return p.Predicates, nil
+}
+
+// filterValue converts the JSON numbers of a decoded predicate value.
+func filterValue(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case []any:
+		for i := range v {
+			v[i] = filterValue(v[i])
+		}
+	}
+	return value
+}
+
+// SignedFilters enables the signed filters of the request, which are
+// verified with the given key before their predicates are applied to the
+// base query, like Filter. Tokens are issued with SignFilter for the given
+// purpose.
+//
+// A request carrying a token that is malformed, whose signature does not
+// match, that was issued for another purpose, or that has expired is
+// rejected with ErrInvalidSignedFilter, and so is a request
+// carrying no token when required is true. Should the verification be
+// bypassed, such as by Detail, which does not validate the request, the
+// base query matches no rows instead. Without SignedFilters, a request
+// carrying signed filters is rejected. A key shorter than 32 bytes, which
+// would let the signatures be forged, is reported by Validate.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) SignedFilters(key []byte, purpose string, required bool) *DataTable {
+	if len(key) < minSignedFilterKey {
+		dt.addError(errors.New(dt.translatef(MsgSignedFilterKey, "signed filter key must be at least %d bytes", minSignedFilterKey)))
+	}
+	dt.signedFilters = &signedFilters{key: key, purpose: purpose, required: required}
+	return dt
+}
+
+// signedPredicates verifies the signed filters of the request and returns
+// their predicates.
+func (dt *DataTable) signedPredicates() ([]map[string]any, error) {
+	if dt.signedFilters == nil {
+		if len(dt.req.SignedFilters) > 0 {
+			return nil, ErrInvalidSignedFilter
+		}
+		return nil, nil
+	}
+	if dt.signedFilters.required && len(dt.req.SignedFilters) == 0 {
+		return nil, ErrInvalidSignedFilter
+	}
+
+	predicates := make([]map[string]any, len(dt.req.SignedFilters))
+	for i, token := range dt.req.SignedFilters {
+		p, err := verifySignedFilter(dt.signedFilters.key, dt.signedFilters.purpose, token)
+		if err != nil {
+			return nil, err
+		}
+		predicates[i] = p
+	}
+	return predicates, nil
+}
+
+// applySignedFilters applies the predicates of the verified signed filters
+// of the request to the query, or a condition matching no rows if they
+// cannot be verified. Returns the updated query.
+func (dt *DataTable) applySignedFilters(query *gorm.DB) *gorm.DB {
+	if dt.signedFilters == nil {
+		return query
+	}
+	predicates, err := dt.signedPredicates()
+	if err != nil {
+		return query.Where("1 = 0")
+	}
+	for _, p := range predicates {
+		for _, col := range slices.Sorted(maps.Keys(p)) {
+			column := clause.Column{Name: col}
+			if values, ok := p[col].([]any); ok {
+				query = query.Where(clause.IN{Column: column, Values: values})
+			} else {
+				query = query.Where(clause.Eq{Column: column, Value: p[col]})
+			}
+		}
+	}
+	return query
+}
@@ -0,0 +1,138 @@
+package datatables
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var testSignKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestSignFilter(t *testing.T) {
+	key := testSignKey
+	token, err := SignFilter(key, "users", map[string]any{"tenant_id": 7, "status": []string{"open", "paid"}, "ratio": 0.5}, time.Hour)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	predicates, err := verifySignedFilter(key, "users", token)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := map[string]any{"tenant_id": int64(7), "status": []any{"open", "paid"}, "ratio": 0.5}
+	if !reflect.DeepEqual(predicates, expected) {
+		t.Errorf("expected %v, got %v", expected, predicates)
+	}
+
+	payload, signature, _ := strings.Cut(token, ".")
+	forged, _ := SignFilter([]byte("another-key-of-at-least-32-bytes"), "users", map[string]any{"tenant_id": 8}, time.Hour)
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+	otherPurpose, _ := SignFilter(key, "invoices", map[string]any{"tenant_id": 8}, time.Hour)
+	expired := encodeSignedFilter(key, []byte(fmt.Sprintf(`{"purpose":"users","expires":%d,"predicates":{"tenant_id":7}}`, time.Now().Add(-time.Minute).Unix())))
+	for name, token := range map[string]string{
+		"wrong_key":         forged,
+		"tampered_payload":  forgedPayload + "." + signature,
+		"missing_signature": payload,
+		"invalid_encoding":  "!!." + signature,
+		"other_purpose":     otherPurpose,
+		"expired":           expired,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := verifySignedFilter(key, "users", token); !errors.Is(err, ErrInvalidSignedFilter) {
+				t.Errorf("expected ErrInvalidSignedFilter, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMakeWithSignedFilters(t *testing.T) {
+	key := testSignKey
+	token, _ := SignFilter(key, "users", map[string]any{"tenant_id": 7, "status": []string{"open", "paid"}}, time.Hour)
+	replayed, _ := SignFilter(key, "invoices", map[string]any{"tenant_id": 8}, time.Hour)
+
+	db, mock := newMockDB(t)
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE `status` IN (?,?) AND `tenant_id` = ?")).
+		WithArgs("open", "paid", 7).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(qm("SELECT * FROM `users` WHERE `status` IN (?,?) AND `tenant_id` = ? LIMIT ?")).
+		WithArgs("open", "paid", 7, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John"))
+
+	r := &http.Request{Method: http.MethodGet, URL: &url.URL{RawQuery: url.Values{
+		"draw": {"1"}, "start": {"0"}, "length": {"10"}, "search[regex]": {"false"},
+		"columns[0][data]": {"name"}, "signed_filter": {token},
+	}.Encode()}}
+	req, err := ParseRequest(r)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	dt := New(db).Model(&User{}).SignedFilters(key, "users", true).Req(*req)
+	if _, err := dt.Make(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		dt   *DataTable
+	}{
+		{"missing_required", New(db).Model(&User{}).SignedFilters(key, "users", true).Req(Request{Draw: 1})},
+		{"tampered", New(db).Model(&User{}).SignedFilters(key, "users", false).Req(Request{Draw: 1, SignedFilters: []string{token + "x"}})},
+		{"replayed", New(db).Model(&User{}).SignedFilters(key, "users", true).Req(Request{Draw: 1, SignedFilters: []string{replayed}})},
+		{"not_enabled", New(db).Model(&User{}).Req(Request{Draw: 1, SignedFilters: []string{token}})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.dt.Make(); !errors.Is(err, ErrInvalidSignedFilter) {
+				t.Errorf("expected ErrInvalidSignedFilter, got %v", err)
+			}
+		})
+	}
+
+	t.Run("fail_closed", func(t *testing.T) {
+		mock.ExpectQuery(qm("SELECT * FROM `users` WHERE 1 = 0 AND `users`.`id` = ? LIMIT ?")).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		dt := New(db).Model(&User{}).SignedFilters(key, "users", true)
+		if _, err := dt.Detail(1); !errors.Is(err, ErrRowNotFound) {
+			t.Errorf("expected ErrRowNotFound, got %v", err)
+		}
+	})
+}
+
+func TestSignFilterErrors(t *testing.T) {
+	if _, err := SignFilter(testSignKey, "", map[string]any{"tenant_id": 7}, time.Hour); err == nil {
+		t.Errorf("expected an error for an empty purpose, got nil")
+	}
+	if _, err := SignFilter(testSignKey, "users", map[string]any{"tenant_id": 7}, 0); err == nil {
+		t.Errorf("expected an error for a non-positive time to live, got nil")
+	}
+	for _, key := range [][]byte{nil, []byte("secret")} {
+		if _, err := SignFilter(key, "users", map[string]any{"tenant_id": 7}, time.Hour); err == nil {
+			t.Errorf("expected an error for the %d bytes key, got nil", len(key))
+		}
+	}
+}
+
+func TestSignedFiltersShortKey(t *testing.T) {
+	db, mock := newMockDB(t)
+	for _, key := range [][]byte{nil, []byte("secret")} {
+		dt := New(db).Model(&User{}).SignedFilters(key, "users", false).Req(Request{Draw: 1})
+		if _, err := dt.Make(); err == nil || !strings.Contains(err.Error(), "signed filter key") {
+			t.Errorf("expected the %d bytes key to be rejected, got %v", len(key), err)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
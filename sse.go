@@ -0,0 +1,121 @@
+package datatables
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// Notifier broadcasts change notifications of a table to the clients
+// subscribed with SSEHandler. It is safe for concurrent use.
+type Notifier struct {
+	mu   sync.Mutex
+	subs map[chan struct{}]struct{}
+}
+
+// NewNotifier returns a Notifier without subscribers.
+func NewNotifier() *Notifier {
+	return &Notifier{subs: make(map[chan struct{}]struct{})}
+}
+
+// Notify signals a change to every subscriber. It never blocks: changes
+// notified while a subscriber is still handling the previous one are
+// coalesced into a single refresh.
+func (n *Notifier) Notify() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for sub := range n.subs {
+		select {
+		case sub <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Watch calls Notify for every value received from the channel, until the
+// channel is closed. It is meant to be run in its own goroutine to connect a
+// change source, such as a database listener, to the Notifier.
+func (n *Notifier) Watch(changes <-chan struct{}) {
+	for range changes {
+		n.Notify()
+	}
+}
+
+// subscribe registers a new subscriber and returns its channel.
+func (n *Notifier) subscribe() chan struct{} {
+	sub := make(chan struct{}, 1)
+	n.mu.Lock()
+	n.subs[sub] = struct{}{}
+	n.mu.Unlock()
+	return sub
+}
+
+// unsubscribe removes the given subscriber.
+func (n *Notifier) unsubscribe(sub chan struct{}) {
+	n.mu.Lock()
+	delete(n.subs, sub)
+	n.mu.Unlock()
+}
+
+// SSEHandler returns an http.Handler streaming the changes notified to n to
+// the client with server-sent events, so tables update without polling.
+//
+// If build is nil, a "reload" event is sent on every change and the client
+// is expected to redraw the table. Otherwise, build is called with the
+// client request on every change and a "data" event carrying the JSON
+// payload of Make is sent; errors are sent as "error" events. The stream
+// ends when the client disconnects.
+func SSEHandler(n *Notifier, build func(r *http.Request) (*DataTable, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		sub := n.subscribe()
+		defer n.unsubscribe(sub)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-sub:
+				event, payload := sseEvent(r, build)
+				if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+					return
+				}
+				flusher.Flush()
+			}
+		}
+	})
+}
+
+// sseEvent returns the name and the payload of the event sent to a client
+// on a change.
+func sseEvent(r *http.Request, build func(*http.Request) (*DataTable, error)) (string, []byte) {
+	if build == nil {
+		return "reload", []byte("{}")
+	}
+
+	dt, err := build(r)
+	var response map[string]any
+	if err == nil {
+		response, err = dt.Make()
+	}
+	if err == nil {
+		var payload []byte
+		if payload, err = json.Marshal(response); err == nil {
+			return "data", payload
+		}
+	}
+	payload, _ := json.Marshal(map[string]string{"error": err.Error()})
+	return "error", payload
+}
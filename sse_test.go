@@ -0,0 +1,111 @@
+package datatables
+
+import (
+	"bufio"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// readEvent reads the next server-sent event and returns its name and data.
+func readEvent(t *testing.T, r *bufio.Reader) (string, string) {
+	t.Helper()
+
+	var event, data string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read event: %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			return event, data
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestSSEHandler(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(qm("SELECT * FROM `users` LIMIT ?")).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+
+	tests := []struct {
+		name  string
+		build func(*http.Request) (*DataTable, error)
+		event string
+		data  string
+	}{
+		{name: "reload", event: "reload", data: "{}"},
+		{
+			name: "data",
+			build: func(r *http.Request) (*DataTable, error) {
+				dt := New(db).Model(&User{})
+				dt.Req(Request{Draw: 1, Length: 10, Columns: []ColumnRequest{{Name: "name", Data: "name"}}})
+				return dt, nil
+			},
+			event: "data",
+			data:  `{"data":[{"id":1,"name":"John Doe"}],"draw":1,"recordsFiltered":1,"recordsTotal":1}`,
+		},
+		{
+			name: "error",
+			build: func(r *http.Request) (*DataTable, error) {
+				return nil, errors.New("boom")
+			},
+			event: "error",
+			data:  `{"error":"boom"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := NewNotifier()
+			server := httptest.NewServer(SSEHandler(n, tt.build))
+			defer server.Close()
+
+			resp, err := http.Get(server.URL)
+			if err != nil {
+				t.Fatalf("failed to connect: %v", err)
+			}
+			defer resp.Body.Close()
+			if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+				t.Errorf("expected text/event-stream, got %q", ct)
+			}
+
+			n.Notify()
+			event, data := readEvent(t, bufio.NewReader(resp.Body))
+			if event != tt.event || data != tt.data {
+				t.Errorf("expected event %q with %s, got %q with %s", tt.event, tt.data, event, data)
+			}
+		})
+	}
+}
+
+func TestNotifierWatch(t *testing.T) {
+	n := NewNotifier()
+	sub := n.subscribe()
+	defer n.unsubscribe(sub)
+
+	changes := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		n.Watch(changes)
+		close(done)
+	}()
+
+	changes <- struct{}{}
+	<-sub
+	close(changes)
+	<-done
+}
@@ -0,0 +1,143 @@
+package datatables
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PageOverlap describes rows returned on two consecutive pages of the same
+// request, detected by VerifyPagination.
+//
+// Fields:
+//   - Table: The table that was queried.
+//   - Start: The start of the page on which the rows appeared again.
+//   - Length: The page length.
+//   - Order: The ordering applied, as "column direction" pairs.
+//   - Keys: The primary keys of the repeated rows, or their JSON encoding
+//     when the model has no primary key.
+type PageOverlap struct {
+	Table  string
+	Start  int
+	Length int
+	Order  []string
+	Keys   []string
+}
+
+// VerifyPagination enables a verification mode, meant for tests and
+// debugging, in which the page preceding the requested one is fetched too
+// and the rows found on both pages are reported with the ordering of the
+// request. Rows shuffling between pages is the sign of an ordering on
+// columns with duplicate values, which Config.StableOrder fixes.
+//
+// The verification doubles the data queries, so it should not be enabled in
+// production.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) VerifyPagination(report func(ctx context.Context, overlap PageOverlap)) *DataTable {
+	dt.pageVerifier = report
+	return dt
+}
+
+// applyTiebreaker appends the primary key of the model to the ordering of
+// the query when Config.StableOrder is set, unless the query is not ordered
+// or already ordered by the primary key.
+func (dt *DataTable) applyTiebreaker(query *gorm.DB) *gorm.DB {
+	if !dt.config.StableOrder {
+		return query
+	}
+	orderBy, ok := query.Statement.Clauses[queryOrderBy].Expression.(clause.OrderBy)
+	if !ok || len(orderBy.Columns) == 0 {
+		return query
+	}
+	s := dt.modelSchema()
+	if s == nil || s.PrioritizedPrimaryField == nil {
+		return query
+	}
+
+	pk := s.PrioritizedPrimaryField.DBName
+	for _, col := range orderBy.Columns {
+		if col.Column.Name == pk && !col.Column.Raw {
+			return query
+		}
+	}
+	return query.Order(clause.OrderByColumn{
+		Column: clause.Column{Table: clause.CurrentTable, Name: pk},
+	})
+}
+
+// verifyPage fetches the rows preceding the current page with the given
+// fetch query, moved to the previous offset and limited to the rows before
+// the current start, and reports the rows found on both pages. The query is reused as built, so the BeforeQuery hooks and
+// the interceptors don't run again. It does nothing unless
+// VerifyPagination is enabled and the current page is not the first one,
+// nor with a CursorPaginator, whose pages don't depend on the start.
+func (dt *DataTable) verifyPage(query *gorm.DB, data []map[string]any) {
+	if dt.pageVerifier == nil || !dt.config.Paginate || dt.req.Start == 0 || dt.req.Length <= 0 {
+		return
+	}
+	if _, ok := dt.paginator.(CursorPaginator); ok {
+		return
+	}
+
+	start := time.Now()
+	current := dt.req.Start
+	offset := max(current-dt.req.Length, 0)
+	limit := current - offset
+	if offset == 0 {
+		offset = -1 // a negative offset cancels the offset of the query
+	}
+	query = query.Session(&gorm.Session{}).Offset(offset).Limit(limit)
+	previous, err := dt.executeQuery(query.WithContext(dt.context()), limit)
+	dt.logPhase(phaseVerify, start, err)
+	if err != nil {
+		return
+	}
+
+	key := dt.rowKey()
+	seen := make(map[string]bool, len(previous))
+	for _, row := range previous {
+		seen[key(row)] = true
+	}
+	var keys []string
+	for _, row := range data {
+		if k := key(row); seen[k] {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	dt.pageVerifier(dt.context(), PageOverlap{
+		Table:  dt.tableName(),
+		Start:  current,
+		Length: dt.req.Length,
+		Order:  dt.orderPairs(),
+		Keys:   keys,
+	})
+}
+
+// rowKey returns a function identifying a row by its primary key, or by its
+// JSON encoding when the model has no primary key or the row does not
+// include it.
+func (dt *DataTable) rowKey() func(map[string]any) string {
+	if s := dt.modelSchema(); s != nil && s.PrioritizedPrimaryField != nil {
+		pk := s.PrioritizedPrimaryField.DBName
+		return func(row map[string]any) string {
+			if v, ok := row[pk]; ok {
+				return fmt.Sprint(v)
+			}
+			b, _ := json.Marshal(row)
+			return string(b)
+		}
+	}
+	return func(row map[string]any) string {
+		b, _ := json.Marshal(row)
+		return string(b)
+	}
+}
@@ -0,0 +1,150 @@
+package datatables
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+func TestStableOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []ColumnRequest
+		order   string
+	}{
+		{"tiebreaker", []ColumnRequest{{Data: "name", Name: "name", Orderable: true}}, "ORDER BY `name` DESC,`users`.`id`"},
+		{"ordered_by_pk", []ColumnRequest{{Data: "id", Name: "id", Orderable: true}}, "ORDER BY `id` DESC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := newMockDB(t)
+			config := defaultConfig()
+			config.StableOrder = true
+			dt := New(db).Model(&User{}).SetConfig(config).Req(Request{
+				Draw:    1,
+				Length:  10,
+				Order:   []Order{{Column: 0, Dir: "desc"}},
+				Columns: tt.columns,
+			})
+
+			_, _, data, err := dt.Statements()
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			expected := "SELECT * FROM `users` " + tt.order + " LIMIT ?"
+			if data.SQL != expected {
+				t.Errorf("expected %q, got %q", expected, data.SQL)
+			}
+		})
+	}
+}
+
+func TestVerifyPagination(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+	mock.ExpectQuery(qm("SELECT * FROM `users` ORDER BY `age` LIMIT ? OFFSET ?")).
+		WithArgs(2, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "age"}).AddRow(2, 30).AddRow(4, 30))
+	mock.ExpectQuery(qm("SELECT * FROM `users` ORDER BY `age` LIMIT ?")).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "age"}).AddRow(1, 20).AddRow(2, 30))
+
+	var overlaps []PageOverlap
+	var hooks int
+	dt := New(db).Model(&User{}).
+		VerifyPagination(func(ctx context.Context, overlap PageOverlap) {
+			overlaps = append(overlaps, overlap)
+		}).
+		BeforeQuery(func(query *gorm.DB) *gorm.DB {
+			hooks++
+			return query
+		}).
+		Req(Request{
+			Draw:    1,
+			Start:   2,
+			Length:  2,
+			Order:   []Order{{Column: 0, Dir: "asc"}},
+			Columns: []ColumnRequest{{Data: "age", Name: "age", Orderable: true}},
+		})
+
+	if _, err := dt.Make(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []PageOverlap{{Table: "users", Start: 2, Length: 2, Order: []string{"age asc"}, Keys: []string{"2"}}}
+	if !reflect.DeepEqual(overlaps, expected) {
+		t.Errorf("expected %+v, got %+v", expected, overlaps)
+	}
+	if hooks != 1 {
+		t.Errorf("expected the BeforeQuery hook to run once, got %d", hooks)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestVerifyPaginationCursorPaginator(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+	mock.ExpectQuery(qm("SELECT * FROM `users` ORDER BY `id` LIMIT ?") + "$").
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "age"}).AddRow(1, 20).AddRow(2, 30))
+
+	dt := New(db).Model(&User{}).
+		SetPaginator(KeysetPaginator("id", false)).
+		VerifyPagination(func(ctx context.Context, overlap PageOverlap) {
+			t.Errorf("expected no verification, got %+v", overlap)
+		}).
+		Req(Request{
+			Draw:    1,
+			Start:   2,
+			Length:  2,
+			Columns: []ColumnRequest{{Data: "age", Name: "age"}},
+		})
+
+	if _, err := dt.Make(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestVerifyPaginationUnalignedStart(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(20))
+	mock.ExpectQuery(qm("SELECT * FROM `users` ORDER BY `age` LIMIT ? OFFSET ?")).
+		WithArgs(10, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "age"}).AddRow(6, 30).AddRow(7, 31))
+	mock.ExpectQuery(qm("SELECT * FROM `users` ORDER BY `age` LIMIT ?") + "$").
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "age"}).AddRow(1, 20).AddRow(2, 21))
+
+	dt := New(db).Model(&User{}).
+		VerifyPagination(func(ctx context.Context, overlap PageOverlap) {
+			t.Errorf("expected no overlap, got %+v", overlap)
+		}).
+		Req(Request{
+			Draw:    1,
+			Start:   5,
+			Length:  10,
+			Order:   []Order{{Column: 0, Dir: "asc"}},
+			Columns: []ColumnRequest{{Data: "age", Name: "age", Orderable: true}},
+		})
+
+	if _, err := dt.Make(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
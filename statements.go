@@ -0,0 +1,55 @@
+package datatables
+
+import "gorm.io/gorm"
+
+// Statement is a SQL statement with its arguments, as sent to the database.
+type Statement struct {
+	SQL  string
+	Vars []any
+}
+
+// Statements returns the total count, filtered count, and data statements
+// that Make runs for the DataTable, rendered with GORM's DryRun feature
+// without querying the database. It is meant to assert the shape of the
+// generated queries in tests.
+//
+// A count that is not computed with a plain COUNT query, because it is set
+// with SetTotalRecords or SetFilteredRecords, or counts DISTINCT columns or
+// groups, is returned as an empty statement. Returns an error if the DataTable is invalid.
+func (dt *DataTable) Statements() (total, filtered, data Statement, err error) {
+	if err = dt.Validate(); err != nil {
+		return
+	}
+	if err = dt.applyCursor(); err != nil {
+		return
+	}
+	dt.prepare()
+
+	baseQuery := dt.buildBaseQuery()
+	countQuery := dt.buildCountQuery(baseQuery)
+	filteredQuery := dt.buildFilteredQuery(baseQuery)
+	plainCount := len(dt.config.DistinctColumns) == 0 && len(dt.config.GroupBy) == 0 &&
+		!hasGroupByColumns(countQuery)
+
+	if dt.totalRecords == nil && plainCount {
+		total = dryRunCount(dt.intercept(phaseCountTotal, countQuery))
+	}
+	if dt.filteredRecords == nil && plainCount {
+		filtered = dryRunCount(dt.intercept(phaseCountFiltered, filteredQuery))
+	}
+
+	var rows []map[string]any
+	query := dt.applyOrder(filteredQuery)
+	query = dt.applyPagination(query)
+	query = dt.applyProjection(query)
+	stmt := dt.intercept(phaseFetch, dt.applyBeforeQuery(query)).Session(&gorm.Session{DryRun: true}).Find(&rows).Statement
+	data = Statement{SQL: stmt.SQL.String(), Vars: stmt.Vars}
+	return
+}
+
+// dryRunCount renders the COUNT statement of the given query.
+func dryRunCount(query *gorm.DB) Statement {
+	var count int64
+	stmt := query.Session(&gorm.Session{DryRun: true}).Count(&count).Statement
+	return Statement{SQL: stmt.SQL.String(), Vars: stmt.Vars}
+}
@@ -0,0 +1,55 @@
+package datatables
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStatements(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	dt := New(db).Model(&User{}).Req(Request{
+		Draw:   1,
+		Start:  20,
+		Length: 10,
+		Search: Search{Value: "John"},
+		Order:  []Order{{Column: 0, Dir: "desc"}},
+		Columns: []ColumnRequest{
+			{Name: "name", Data: "name", Searchable: true, Orderable: true},
+		},
+	})
+
+	total, filtered, data, err := dt.Statements()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		got, want Statement
+	}{
+		{"total", total, Statement{SQL: "SELECT count(*) FROM `users`"}},
+		{"filtered", filtered, Statement{SQL: "SELECT count(*) FROM `users` WHERE `name` LIKE ?", Vars: []any{"%John%"}}},
+		{"data", data, Statement{SQL: "SELECT * FROM `users` WHERE `name` LIKE ? ORDER BY `name` DESC LIMIT ? OFFSET ?", Vars: []any{"%John%", 10, 20}}},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s: expected %+v, got %+v", tt.name, tt.want, tt.got)
+		}
+	}
+
+	t.Run("set_counts", func(t *testing.T) {
+		dt.SetTotalRecords(100).SetFilteredRecords(10)
+		total, filtered, _, err := dt.Statements()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if total.SQL != "" || filtered.SQL != "" {
+			t.Errorf("expected empty count statements, got %q and %q", total.SQL, filtered.SQL)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected queries: %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package datatables
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// summaryKey is the response key holding the summary row.
+const summaryKey = "summary"
+
+// summaryFuncs lists the aggregate functions allowed in a summary row.
+var summaryFuncs = map[string]bool{
+	"SUM":   true,
+	"AVG":   true,
+	"MIN":   true,
+	"MAX":   true,
+	"COUNT": true,
+}
+
+// SummaryRow sets the aggregates computed for the summary row.
+//
+// The aggregates map the Data field of a column to an aggregate function
+// (SUM, AVG, MIN, MAX, or COUNT). When set, a single aggregate query is
+// executed over the filtered set, ignoring pagination, and its result is
+// attached to the response under the "summary" key, so grids can show a
+// totals row matching the current filters. Columns that are not defined or
+// not allowed are ignored, and the request fails if a column is computed.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) SummaryRow(aggregates map[string]string) *DataTable {
+	dt.summaryAggs = aggregates
+	return dt
+}
+
+// getSummary executes the aggregate query of the summary row over the given
+// filtered query and returns the resulting row keyed by column Data. If the
+// query is grouped, the aggregates are computed over the grouped rows. An
+// error is returned if an aggregate function is not supported.
+func (dt *DataTable) getSummary(filteredQuery *gorm.DB) (map[string]any, error) {
+	selects, vars, err := dt.aggregateSelects(dt.summaryAggs)
+	if err != nil || len(selects) == 0 {
+		return nil, err
+	}
+
+	summary := make(map[string]any)
+	err = dt.aggregateQuery(filteredQuery).Select(strings.Join(selects, ", "), vars...).Scan(&summary).Error
+	return summary, err
+}
+
+// Summarize runs a grouped aggregate query over the filtered query of the
+// DataTable, so that breakdowns, such as per status or per owner, are
+// consistent with the rows of the grid.
+//
+// The aggregates map the Data field of a column to an aggregate function
+// (SUM, AVG, MIN, MAX, or COUNT), like in SummaryRow, and groupBy is the Data
+// field of the column to group by. One row is returned per value of the
+// groupBy column, ordered by it, holding the value and the aggregates keyed
+// by column Data. Aggregated columns that are not defined or not allowed are
+// ignored, and an error is returned if the groupBy column is not defined or
+// not allowed, if an aggregated column is computed, or if an aggregate
+// function is not supported.
+func (dt *DataTable) Summarize(groupBy string, aggregates map[string]string) ([]map[string]any, error) {
+	if err := dt.Validate(); err != nil {
+		return nil, err
+	}
+	col, exists := dt.columnsMap[groupBy]
+	if !exists || !dt.isColumnAllowed(groupBy) {
+		return nil, errors.New(dt.translatef(MsgUnknownGroupBy, "unknown group by column %q", groupBy))
+	}
+	dt.prepare()
+	selects, vars, err := dt.aggregateSelects(aggregates)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := dt.aggregateColumn(col)
+	selects = append([]string{"? AS ?"}, selects...)
+	vars = append([]any{ref, clause.Column{Name: groupBy}}, vars...)
+
+	rows := make([]map[string]any, 0)
+	err = dt.aggregateQuery(dt.buildFilteredQuery(dt.buildBaseQuery())).
+		Select(strings.Join(selects, ", "), vars...).
+		Clauses(clause.GroupBy{Columns: []clause.Column{ref}}).
+		Order(clause.OrderByColumn{Column: ref}).
+		WithContext(dt.context()).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// aggregateSelects returns the select expressions, and their arguments, of
+// the given aggregates keyed by column Data. Columns that are not defined or
+// not allowed are skipped. An error is returned if an aggregate function is
+// not supported or if a column is computed, having no SQL column to
+// aggregate. The DataTable must be prepared.
+func (dt *DataTable) aggregateSelects(aggregates map[string]string) ([]string, []any, error) {
+	var (
+		selects []string
+		vars    []any
+	)
+	for _, data := range slices.Sorted(maps.Keys(aggregates)) {
+		fn := strings.ToUpper(strings.TrimSpace(aggregates[data]))
+		if !summaryFuncs[fn] {
+			return nil, nil, errors.New(dt.translatef(MsgUnsupportedSummary, "unsupported summary function %q for column %q", fn, data))
+		}
+		col, exists := dt.columnsMap[data]
+		if !exists || !dt.isColumnAllowed(data) {
+			continue
+		}
+		if dt.computedColumns[data] {
+			return nil, nil, errors.New(dt.translatef(MsgComputedSummary, "cannot summarize computed column %q", data))
+		}
+		selects = append(selects, fn+"(?) AS ?")
+		vars = append(vars, dt.aggregateColumn(col), clause.Column{Name: data})
+	}
+	return selects, vars, nil
+}
+
+// aggregateColumn returns the reference of a column in an aggregate query:
+// its SQL column, or its Data field if it has neither a Name nor an SQL
+// expression or if the query is grouped, as the aggregates are then computed
+// over the grouped rows.
+func (dt *DataTable) aggregateColumn(col Column) clause.Column {
+	if (col.Name == "" && col.SQL == "") || len(dt.config.GroupBy) > 0 {
+		return clause.Column{Name: col.Data}
+	}
+	return col.sqlColumn()
+}
+
+// aggregateQuery returns the query aggregates are computed on from the
+// filtered query: a new session of it, or, if the query is grouped, a
+// subquery wrapping it.
+func (dt *DataTable) aggregateQuery(filteredQuery *gorm.DB) *gorm.DB {
+	query := filteredQuery.Session(&gorm.Session{})
+	if len(dt.config.GroupBy) > 0 {
+		query = dt.tx.Session(&gorm.Session{NewDB: true}).Table("(?) subquery", query)
+	}
+	return query
+}
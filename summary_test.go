@@ -0,0 +1,162 @@
+package datatables
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestSummaryRow(t *testing.T) {
+	dt := New(nil)
+	aggregates := map[string]string{"amount": "SUM"}
+
+	result := dt.SummaryRow(aggregates)
+	if !reflect.DeepEqual(result.summaryAggs, aggregates) {
+		t.Errorf("expected summaryAggs to be %v, got %v", aggregates, result.summaryAggs)
+	}
+}
+
+func TestGetSummary(t *testing.T) {
+	t.Run("unsupported_function", func(t *testing.T) {
+		db, _ := newMockDB(t)
+		dt := New(db)
+		dt.AddColumn(Column{Name: "amount", Data: "amount"})
+		dt.SummaryRow(map[string]string{"amount": "DROP"})
+
+		if _, err := dt.getSummary(db.Table("orders")); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+
+	t.Run("computed_column", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		dt := New(db).Model(&User{})
+		dt.AddColumn(Column{Name: "id", Data: "id"})
+		dt.AddColumnFunc("score", func(row map[string]any) any { return 1 })
+		dt.SummaryRow(map[string]string{"id": "COUNT", "score": "SUM"})
+		dt.prepare()
+
+		if _, err := dt.getSummary(db.Table("users")); err == nil || err.Error() != `cannot summarize computed column "score"` {
+			t.Errorf("expected the computed column to be rejected, got %v", err)
+		}
+		if _, err := dt.Req(Request{Draw: 1}).Summarize("id", map[string]string{"score": "MAX"}); err == nil {
+			t.Error("expected Summarize to reject the computed column, got nil")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet expectations: %v", err)
+		}
+	})
+
+	t.Run("no_known_columns", func(t *testing.T) {
+		db, _ := newMockDB(t)
+		dt := New(db)
+		dt.SummaryRow(map[string]string{"unknown": "SUM"})
+
+		summary, err := dt.getSummary(db.Table("orders"))
+		if err != nil || summary != nil {
+			t.Errorf("expected nil summary and error, got %v, %v", summary, err)
+		}
+	})
+}
+
+func TestMakeWithSummaryRow(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `orders`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(qm("SELECT count(*) FROM `orders` WHERE `status` LIKE ?")).
+		WithArgs("%paid%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(qm("SELECT SUM(`amount`) AS `amount`, AVG(`qty`) AS `qty` FROM `orders` WHERE `status` LIKE ?")).
+		WithArgs("%paid%").
+		WillReturnRows(sqlmock.NewRows([]string{"amount", "qty"}).AddRow(30, 1.5))
+	mock.ExpectQuery(qm("SELECT * FROM `orders` WHERE `status` LIKE ? LIMIT ?")).
+		WithArgs("%paid%", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"amount", "qty", "status"}).
+			AddRow(10, 1, "paid").
+			AddRow(20, 2, "paid"))
+
+	dt := New(db.Table("orders"))
+	dt.Req(Request{
+		Draw:   1,
+		Length: 10,
+		Search: Search{Value: "paid"},
+		Columns: []ColumnRequest{
+			{Name: "amount", Data: "amount"},
+			{Name: "qty", Data: "qty"},
+			{Name: "status", Data: "status", Searchable: true},
+		},
+	})
+	dt.SummaryRow(map[string]string{"amount": "sum", "qty": "AVG"})
+
+	response, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	summary, ok := response["summary"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected summary row in response, got %v", response)
+	}
+	expected := map[string]any{"amount": int64(30), "qty": 1.5}
+	if !reflect.DeepEqual(summary, expected) {
+		t.Errorf("expected summary %v, got %v", expected, summary)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT `status` AS `status`, SUM(`amount`) AS `amount`, COUNT(`id`) AS `id` FROM `orders` WHERE `status` LIKE ? GROUP BY `status` ORDER BY `status`")).
+		WithArgs("%paid%").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "amount", "id"}).
+			AddRow("paid", 30, 2).
+			AddRow("unpaid", 5, 1))
+
+	newDataTable := func() *DataTable {
+		dt := New(db.Table("orders"))
+		dt.Req(Request{
+			Draw:   1,
+			Search: Search{Value: "paid"},
+			Columns: []ColumnRequest{
+				{Name: "id", Data: "id"},
+				{Name: "amount", Data: "amount"},
+				{Name: "status", Data: "status", Searchable: true},
+			},
+		})
+		return dt
+	}
+
+	rows, err := newDataTable().Summarize("status", map[string]string{"amount": "sum", "id": "COUNT", "unknown": "SUM"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := []map[string]any{
+		{"status": "paid", "id": int64(2), "amount": int64(30)},
+		{"status": "unpaid", "id": int64(1), "amount": int64(5)},
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected %v, got %v", expected, rows)
+	}
+
+	t.Run("unknown_group_by", func(t *testing.T) {
+		if _, err := newDataTable().Summarize("owner", map[string]string{"amount": "SUM"}); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+
+	t.Run("unsupported_function", func(t *testing.T) {
+		if _, err := newDataTable().Summarize("status", map[string]string{"amount": "DROP"}); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package datatables
+
+import (
+	"errors"
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// TimeBucket is the width of the buckets a time series is grouped by.
+type TimeBucket string
+
+// Constants for the widths of the buckets of a time series.
+const (
+	BucketDay   TimeBucket = "day"   // One bucket per day.
+	BucketWeek  TimeBucket = "week"  // One bucket per ISO week, starting on Monday.
+	BucketMonth TimeBucket = "month" // One bucket per month.
+)
+
+// timeBucketKey is the key of the start of the bucket in the rows of a time
+// series.
+const timeBucketKey = "bucket"
+
+// bucketExpression returns the SQL expression truncating a time column,
+// bound to every placeholder, to the start of its bucket for the given
+// dialect name. An empty string is returned when the dialect or the bucket
+// is not supported.
+func bucketExpression(dialect string, bucket TimeBucket) string {
+	switch dialect {
+	case "postgres", "postgresql":
+		switch bucket {
+		case BucketDay, BucketWeek, BucketMonth:
+			return "date_trunc('" + string(bucket) + "', ?)"
+		}
+	case "mysql":
+		switch bucket {
+		case BucketDay:
+			return "DATE_FORMAT(?, '%Y-%m-%d')"
+		case BucketWeek:
+			return "DATE_FORMAT(DATE_SUB(?, INTERVAL WEEKDAY(?) DAY), '%Y-%m-%d')"
+		case BucketMonth:
+			return "DATE_FORMAT(?, '%Y-%m-01')"
+		}
+	case "sqlite", "sqlite3":
+		switch bucket {
+		case BucketDay:
+			return "date(?)"
+		case BucketWeek:
+			return "date(?, 'weekday 0', '-6 days')"
+		case BucketMonth:
+			return "strftime('%Y-%m-01', ?)"
+		}
+	}
+	return ""
+}
+
+// TimeSeries groups the filtered query of the DataTable by time bucket, so
+// that a chart above the table always reflects its current filters.
+//
+// The column is the Data field of the time column to group by, and the
+// buckets are computed with date_trunc on Postgres and DATE_FORMAT on MySQL
+// (date and strftime on SQLite). One row is returned per bucket, ordered by
+// it, holding the start of the bucket under the "bucket" key, the number of
+// rows under the "count" key, and the aggregates, which map the Data field of
+// a column to an aggregate function like in SummaryRow, keyed by column Data.
+// Aggregated columns that are not defined or not allowed are ignored, and an
+// error is returned if the time column is not defined or not allowed, or if
+// the bucket or the dialect is not supported.
+func (dt *DataTable) TimeSeries(column string, bucket TimeBucket, aggregates map[string]string) ([]map[string]any, error) {
+	if err := dt.Validate(); err != nil {
+		return nil, err
+	}
+	col, exists := dt.columnsMap[column]
+	if !exists || !dt.isColumnAllowed(column) {
+		return nil, errors.New(dt.translatef(MsgUnknownTimeColumn, "unknown time column %q", column))
+	}
+	expr := bucketExpression(dt.tx.Dialector.Name(), bucket)
+	if expr == "" {
+		return nil, errors.New(dt.translatef(MsgUnsupportedDialect, "time bucket %q is not supported for dialect %s", bucket, dt.tx.Dialector.Name()))
+	}
+	selects, vars, err := dt.aggregateSelects(aggregates)
+	if err != nil {
+		return nil, err
+	}
+
+	dt.prepare()
+	ref := dt.aggregateColumn(col)
+	var bucketVars []any
+	for range strings.Count(expr, "?") {
+		bucketVars = append(bucketVars, ref)
+	}
+	selects = append([]string{expr + " AS ?", queryCount}, selects...)
+	vars = append(append(bucketVars, clause.Column{Name: timeBucketKey}), vars...)
+
+	key := clause.Column{Name: timeBucketKey}
+	rows := make([]map[string]any, 0)
+	err = dt.aggregateQuery(dt.buildFilteredQuery(dt.buildBaseQuery())).
+		Select(strings.Join(selects, ", "), vars...).
+		Clauses(clause.GroupBy{Columns: []clause.Column{key}}).
+		Order(clause.OrderByColumn{Column: key}).
+		WithContext(dt.context()).
+		Scan(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,82 @@
+package datatables
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+func TestBucketExpression(t *testing.T) {
+	tests := []struct {
+		dialect  string
+		bucket   TimeBucket
+		expected string
+	}{
+		{"postgres", BucketWeek, "date_trunc('week', ?)"},
+		{"mysql", BucketDay, "DATE_FORMAT(?, '%Y-%m-%d')"},
+		{"mysql", BucketWeek, "DATE_FORMAT(DATE_SUB(?, INTERVAL WEEKDAY(?) DAY), '%Y-%m-%d')"},
+		{"sqlite", BucketMonth, "strftime('%Y-%m-01', ?)"},
+		{"mysql", "year", ""},
+		{"sqlserver", BucketDay, ""},
+	}
+	for _, tt := range tests {
+		if got := bucketExpression(tt.dialect, tt.bucket); got != tt.expected {
+			t.Errorf("bucketExpression(%q, %q) = %q, expected %q", tt.dialect, tt.bucket, got, tt.expected)
+		}
+	}
+}
+
+func TestTimeSeries(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT DATE_FORMAT(`created_at`, '%Y-%m-01') AS `bucket`, COUNT(*) AS count, SUM(`amount`) AS `amount` FROM `orders` WHERE `status` LIKE ? GROUP BY `bucket` ORDER BY `bucket`")).
+		WithArgs("%paid%").
+		WillReturnRows(sqlmock.NewRows([]string{"bucket", "count", "amount"}).
+			AddRow("2024-01-01", 2, 30).
+			AddRow("2024-02-01", 1, 5))
+
+	newDataTable := func(db *gorm.DB) *DataTable {
+		dt := New(db.Table("orders"))
+		dt.Req(Request{
+			Draw:   1,
+			Search: Search{Value: "paid"},
+			Columns: []ColumnRequest{
+				{Name: "created_at", Data: "created_at"},
+				{Name: "amount", Data: "amount"},
+				{Name: "status", Data: "status", Searchable: true},
+			},
+		})
+		return dt
+	}
+
+	rows, err := newDataTable(db).TimeSeries("created_at", BucketMonth, map[string]string{"amount": "SUM"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := []map[string]any{
+		{"bucket": "2024-01-01", "count": int64(2), "amount": int64(30)},
+		{"bucket": "2024-02-01", "count": int64(1), "amount": int64(5)},
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected %v, got %v", expected, rows)
+	}
+
+	t.Run("unknown_column", func(t *testing.T) {
+		if _, err := newDataTable(db).TimeSeries("updated_at", BucketDay, nil); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+
+	t.Run("unsupported_dialect", func(t *testing.T) {
+		db, _ := newMockDBWithDialect(t, "sqlserver")
+		if _, err := newDataTable(db).TimeSeries("created_at", BucketDay, nil); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
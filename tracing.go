@@ -0,0 +1,69 @@
+package datatables
+
+import "context"
+
+// Tracer starts the spans of the processing phases of a DataTable.
+// Implementations must be safe for concurrent use, as a single tracer is
+// typically shared by every DataTable of an application.
+//
+// The otel sub-package provides an OpenTelemetry implementation.
+type Tracer interface {
+	// Start starts a span with the given name for the given table as a child
+	// of the span found in ctx, and returns the context carrying the new span.
+	Start(ctx context.Context, name, table string) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	// End records the error, if any, and the number n under the given key
+	// (e.g. "count" or "rows") on the span and ends it.
+	End(err error, key string, n int64)
+}
+
+// SetTracer sets the tracer used by the DataTable.
+//
+// When a tracer is set, the DataTable starts a span for each phase of the
+// request (datatables.count_total, datatables.count_filtered, datatables.fetch,
+// and datatables.render) carrying the table name and row counts. The spans are
+// children of the span found in the context of the gorm statement, and the
+// queries are executed with the span context so that database spans nest
+// correctly. Passing nil disables tracing, which is the default.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) SetTracer(tracer Tracer) *DataTable {
+	dt.tracer = tracer
+	return dt
+}
+
+// context returns the context set with WithContext, or else the context of
+// the DataTable's gorm statement, or context.Background if none is available.
+// The values set with Set are attached to it.
+func (dt *DataTable) context() context.Context {
+	ctx := context.Background()
+	switch {
+	case dt.ctx != nil:
+		ctx = dt.ctx
+	case dt.tx != nil && dt.tx.Statement != nil && dt.tx.Statement.Context != nil:
+		ctx = dt.tx.Statement.Context
+	}
+	if len(dt.values) > 0 {
+		ctx = context.WithValue(ctx, valuesKey{}, dt.values)
+	}
+	return ctx
+}
+
+// noopSpan is the span of the phases when no tracer is set.
+type noopSpan struct{}
+
+func (noopSpan) End(error, string, int64) {}
+
+// startSpan starts a span with the given name as a child of the DataTable's
+// context. If no tracer is set, the context is returned unchanged together
+// with a no-op span.
+func (dt *DataTable) startSpan(name string) (context.Context, Span) {
+	ctx := dt.context()
+	if dt.tracer == nil {
+		return ctx, noopSpan{}
+	}
+	return dt.tracer.Start(ctx, name, dt.tableName())
+}
@@ -0,0 +1,116 @@
+package datatables
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type recordedSpan struct {
+	name   string
+	table  string
+	counts map[string]int64
+	ended  bool
+}
+
+func (s *recordedSpan) End(err error, key string, n int64) {
+	s.counts[key] = n
+	s.ended = true
+}
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+func (r *recordingTracer) Start(ctx context.Context, name, table string) (context.Context, Span) {
+	span := &recordedSpan{name: name, table: table, counts: make(map[string]int64)}
+	r.mu.Lock()
+	r.spans = append(r.spans, span)
+	r.mu.Unlock()
+	return ctx, span
+}
+
+func TestSetTracer(t *testing.T) {
+	dt := New(nil)
+	tracer := &recordingTracer{}
+
+	result := dt.SetTracer(tracer)
+	if result.tracer != tracer {
+		t.Errorf("expected tracer to be set, got %v", result.tracer)
+	}
+}
+
+func TestMakeWithTracer(t *testing.T) {
+	dbMock, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer dbMock.Close()
+
+	dialector := mysql.New(mysql.Config{
+		Conn:                      dbMock,
+		SkipInitializeWithVersion: true,
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm DB: %v", err)
+	}
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	mock.ExpectQuery(qm("SELECT * FROM `users` LIMIT ?")).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(1, "John Doe").
+			AddRow(2, "Jane Smith"))
+
+	tracer := &recordingTracer{}
+	dt := New(db)
+	dt.Model(&User{})
+	dt.SetTracer(tracer)
+	dt.Req(Request{
+		Draw:   1,
+		Length: 10,
+		Columns: []ColumnRequest{
+			{Name: "id", Data: "id"},
+			{Name: "name", Data: "name"},
+		},
+	})
+
+	if _, err := dt.Make(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []string{
+		"datatables." + phaseCountTotal,
+		"datatables." + phaseFetch,
+		"datatables." + phaseRender,
+	}
+	if len(tracer.spans) != len(expected) {
+		t.Fatalf("expected %d spans, got %d", len(expected), len(tracer.spans))
+	}
+	for i, name := range expected {
+		span := tracer.spans[i]
+		if span.name != name {
+			t.Errorf("expected span %d to be %q, got %q", i, name, span.name)
+		}
+		if !span.ended {
+			t.Errorf("expected span %q to be ended", name)
+		}
+		if span.table != "users" {
+			t.Errorf("expected span %q to carry table name, got %q", name, span.table)
+		}
+	}
+	if got := tracer.spans[2].counts["rows"]; got != 2 {
+		t.Errorf("expected fetch span to report 2 rows, got %d", got)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
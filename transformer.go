@@ -0,0 +1,43 @@
+package datatables
+
+// Transformer maps a rendered row into the shape returned in the response,
+// such as an API resource with nested objects or renamed fields.
+type Transformer interface {
+	Transform(row map[string]any) any
+}
+
+// TransformerFunc is an adapter that allows the use of an ordinary function
+// as a Transformer.
+type TransformerFunc func(row map[string]any) any
+
+// Transform calls f(row).
+func (f TransformerFunc) Transform(row map[string]any) any {
+	return f(row)
+}
+
+// SetTransformer sets the transformer used to map each row of the response.
+//
+// The transformer is applied once every row has been rendered, filtered to
+// the selected columns, and passed to the AfterRender hooks. When a
+// transformer is set, the "data" field of the response holds the transformed
+// values ([]any) instead of the rows.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) SetTransformer(transformer Transformer) *DataTable {
+	dt.transformer = transformer
+	return dt
+}
+
+// applyTransformer maps every row of the data with the DataTable's
+// transformer and returns the result. If no transformer is set, the data is
+// returned unchanged.
+func (dt *DataTable) applyTransformer(data []map[string]any) any {
+	if dt.transformer == nil {
+		return data
+	}
+	transformed := make([]any, len(data))
+	for i, row := range data {
+		transformed[i] = dt.transformer.Transform(row)
+	}
+	return transformed
+}
@@ -0,0 +1,76 @@
+package datatables
+
+import (
+	"reflect"
+	"testing"
+)
+
+type userResource struct {
+	ID      any            `json:"id"`
+	Profile map[string]any `json:"profile"`
+}
+
+type userTransformer struct{}
+
+func (userTransformer) Transform(row map[string]any) any {
+	return userResource{ID: row["id"], Profile: map[string]any{"name": row["name"]}}
+}
+
+func TestSetTransformer(t *testing.T) {
+	dt := New(nil)
+	transformer := userTransformer{}
+
+	result := dt.SetTransformer(transformer)
+	if result.transformer != transformer {
+		t.Errorf("expected transformer to be set, got %v", result.transformer)
+	}
+}
+
+func TestApplyTransformer(t *testing.T) {
+	data := []map[string]any{
+		{"id": 1, "name": "John Doe"},
+		{"id": 2, "name": "Jane Smith"},
+	}
+
+	tests := []struct {
+		name        string
+		transformer Transformer
+		expected    any
+	}{
+		{
+			name:        "no_transformer",
+			transformer: nil,
+			expected:    data,
+		},
+		{
+			name:        "struct_transformer",
+			transformer: userTransformer{},
+			expected: []any{
+				userResource{ID: 1, Profile: map[string]any{"name": "John Doe"}},
+				userResource{ID: 2, Profile: map[string]any{"name": "Jane Smith"}},
+			},
+		},
+		{
+			name: "func_transformer",
+			transformer: TransformerFunc(func(row map[string]any) any {
+				return map[string]any{"key": row["id"]}
+			}),
+			expected: []any{
+				map[string]any{"key": 1},
+				map[string]any{"key": 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dt := New(nil)
+			dt.transformer = tt.transformer
+
+			result := dt.applyTransformer(data)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package datatables
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+)
+
+// Response is a strongly typed DataTables response.
+//
+// Fields:
+//   - Draw: The draw counter of the request.
+//   - RecordsTotal: The total number of records.
+//   - RecordsFiltered: The number of records after filtering.
+//   - Data: The rows decoded into T.
+//   - Extra: The additional data set with WithData and WithMeta, merged into
+//     the top-level object when the response is encoded to JSON.
+type Response[T any] struct {
+	Draw            int            `json:"draw"`
+	RecordsTotal    int64          `json:"recordsTotal"`
+	RecordsFiltered int64          `json:"recordsFiltered"`
+	Data            []T            `json:"data"`
+	Extra           map[string]any `json:"-"`
+}
+
+// MarshalJSON encodes the response as a DataTables compatible JSON object,
+// merging the Extra fields into the top-level object.
+func (r Response[T]) MarshalJSON() ([]byte, error) {
+	out := make(map[string]any, len(r.Extra)+4)
+	maps.Copy(out, r.Extra)
+	out["draw"] = r.Draw
+	out["recordsTotal"] = r.RecordsTotal
+	out["recordsFiltered"] = r.RecordsFiltered
+	out["data"] = r.Data
+	return json.Marshal(out)
+}
+
+// MakeAs processes the DataTable like Make and decodes the resulting rows
+// into a slice of T.
+//
+// The rows are decoded with a JSON round-trip, so T should declare json tags
+// matching the keys of the rows (or the shape returned by the transformer, if
+// one is set). Returns the typed response or an error if processing or
+// decoding fails.
+func MakeAs[T any](dt *DataTable) (*Response[T], error) {
+	res, err := dt.make()
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(res.data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode data: %w", err)
+	}
+
+	response := &Response[T]{
+		Draw:            dt.req.Draw,
+		RecordsTotal:    res.total,
+		RecordsFiltered: res.filtered,
+		Data:            []T{},
+		Extra:           maps.Clone(dt.additionalData),
+	}
+	maps.Copy(response.Extra, res.meta)
+	if err := json.Unmarshal(raw, &response.Data); err != nil {
+		return nil, fmt.Errorf("failed to decode data: %w", err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,70 @@
+package datatables
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+type userDTO struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestMakeAs(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(qm("SELECT * FROM `users` LIMIT ?")).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+			AddRow(1, "John Doe").
+			AddRow(2, "Jane Smith"))
+
+	dt := New(db)
+	dt.Model(&User{})
+	dt.WithData("version", "v1")
+	dt.Req(Request{
+		Draw:   3,
+		Length: 10,
+		Columns: []ColumnRequest{
+			{Name: "id", Data: "id"},
+			{Name: "name", Data: "name"},
+		},
+	})
+
+	response, err := MakeAs[userDTO](dt)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := &Response[userDTO]{
+		Draw:            3,
+		RecordsTotal:    2,
+		RecordsFiltered: 2,
+		Data:            []userDTO{{ID: 1, Name: "John Doe"}, {ID: 2, Name: "Jane Smith"}},
+		Extra:           map[string]any{"version": "v1"},
+	}
+	if !reflect.DeepEqual(response, expected) {
+		t.Errorf("expected %+v, got %+v", expected, response)
+	}
+
+	encoded, err := json.Marshal(response)
+	if err != nil {
+		t.Fatalf("failed to encode response: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if decoded["version"] != "v1" || decoded["draw"] != float64(3) {
+		t.Errorf("expected extra fields to be merged, got %v", decoded)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package datatables
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UnionSource is one of the sources of a DataTable composed with FromUnion.
+//
+// Fields:
+//   - Query: The query selecting the rows of the source, such as
+//     db.Model(&Invoice{}) or db.Table("credit_notes").Where("voided = ?", false).
+//   - Columns: Maps a shared column to the SQL expression producing it in the
+//     source, such as "total" or "-amount". The shared columns that are not
+//     mapped are NULL in the rows of the source.
+type UnionSource struct {
+	Query   *gorm.DB
+	Columns map[string]string
+}
+
+// FromUnion composes the DataTable from heterogeneous sources, such as
+// invoices and credit notes, sharing the given set of columns:
+//
+//	dt.FromUnion([]string{"number", "amount", "kind"},
+//		datatables.UnionSource{Query: db.Model(&Invoice{}), Columns: map[string]string{"number": "number", "amount": "total", "kind": "'invoice'"}},
+//		datatables.UnionSource{Query: db.Model(&CreditNote{}), Columns: map[string]string{"number": "ref", "amount": "-amount", "kind": "'credit'"}},
+//	)
+//
+// Each source selects the shared columns, in order, from its mapping, and
+// the sources are combined with UNION ALL into a derived table, like with
+// FromRaw, so the search, ordering, pagination, and counts operate over the
+// shared columns of all the sources. FromUnion replaces the model.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) FromUnion(columns []string, sources ...UnionSource) *DataTable {
+	if dt.tx == nil {
+		dt.addError(errors.New(dt.translate(MsgNoTxOrModel, "no tx or model provided")))
+		return dt
+	}
+	if len(columns) == 0 || len(sources) == 0 {
+		dt.addError(errors.New(dt.translate(MsgUnionRequired, "union requires columns and sources")))
+		return dt
+	}
+
+	parts := make([]string, len(sources))
+	vars := make([]any, len(sources))
+	for i, src := range sources {
+		query, err := dt.unionQuery(i, src, columns)
+		if err != nil {
+			dt.addError(err)
+			return dt
+		}
+		parts[i] = fmt.Sprintf("SELECT * FROM (?) AS source_%d", i)
+		vars[i] = query
+	}
+	dt.fromDerived(dt.tx.Session(&gorm.Session{NewDB: true}).Raw(strings.Join(parts, " UNION ALL "), vars...))
+	return dt
+}
+
+// unionQuery returns the query of the i-th source selecting the given
+// shared columns, in order. An error is returned if the source has no query
+// or maps a column that is not shared.
+func (dt *DataTable) unionQuery(i int, src UnionSource, columns []string) (*gorm.DB, error) {
+	if src.Query == nil {
+		return nil, errors.New(dt.translatef(MsgUnionQuery, "union source %d: missing query", i))
+	}
+	for col := range src.Columns {
+		if !slices.Contains(columns, col) {
+			return nil, errors.New(dt.translatef(MsgUnionColumn, "union source %d: unknown column %q", i, col))
+		}
+	}
+
+	selects := make([]string, len(columns))
+	vars := make([]any, 0, 2*len(columns))
+	for i, col := range columns {
+		expr := src.Columns[col]
+		if expr == "" {
+			expr = "NULL"
+		}
+		selects[i] = "? AS ?"
+		vars = append(vars, clause.Expr{SQL: expr}, clause.Column{Name: col})
+	}
+	return src.Query.Session(&gorm.Session{}).Select(strings.Join(selects, ", "), vars...), nil
+}
@@ -0,0 +1,69 @@
+package datatables
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestFromUnion(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	base := "(SELECT * FROM (SELECT number AS `number`, total AS `amount`, 'invoice' AS `kind` FROM `invoices` WHERE tenant_id = ?) AS source_0" +
+		" UNION ALL SELECT * FROM (SELECT ref AS `number`, -amount AS `amount`, NULL AS `kind` FROM `credit_notes`) AS source_1) AS datatables_base"
+	mock.ExpectQuery(qm("SELECT count(*) FROM " + base)).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(3)))
+	mock.ExpectQuery(qm("SELECT count(*) FROM "+base+" WHERE `number` LIKE ?")).
+		WithArgs(7, "%A1%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(2)))
+	mock.ExpectQuery(qm("SELECT * FROM "+base+" WHERE `number` LIKE ? ORDER BY `amount` DESC LIMIT ?")).
+		WithArgs(7, "%A1%", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"number", "amount", "kind"}).
+			AddRow("A1", 30, "invoice").
+			AddRow("A10", -5, nil))
+
+	dt := New(db).FromUnion([]string{"number", "amount", "kind"},
+		UnionSource{Query: db.Table("invoices").Where("tenant_id = ?", 7), Columns: map[string]string{"number": "number", "amount": "total", "kind": "'invoice'"}},
+		UnionSource{Query: db.Table("credit_notes"), Columns: map[string]string{"number": "ref", "amount": "-amount"}},
+	)
+	dt.Req(Request{
+		Draw:   1,
+		Length: 10,
+		Search: Search{Value: "A1"},
+		Order:  []Order{{Column: 1, Dir: "desc"}},
+		Columns: []ColumnRequest{
+			{Name: "number", Data: "number", Searchable: true},
+			{Name: "amount", Data: "amount", Orderable: true},
+		},
+	})
+
+	res, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if res["recordsTotal"] != int64(3) || res["recordsFiltered"] != int64(2) {
+		t.Errorf("expected 3 total and 2 filtered records, got %v and %v", res["recordsTotal"], res["recordsFiltered"])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestFromUnionInvalid(t *testing.T) {
+	db, _ := newMockDB(t)
+
+	tests := map[string]*DataTable{
+		"no_tx":          New(nil).FromUnion([]string{"number"}, UnionSource{}),
+		"no_sources":     New(db).FromUnion([]string{"number"}),
+		"missing_query":  New(db).FromUnion([]string{"number"}, UnionSource{}),
+		"unknown_column": New(db).FromUnion([]string{"number"}, UnionSource{Query: db.Table("invoices"), Columns: map[string]string{"total": "total"}}),
+	}
+	for name, dt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := dt.Validate(); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
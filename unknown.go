@@ -0,0 +1,71 @@
+package datatables
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+)
+
+// Policies for the unknown request columns, selected with
+// Config.UnknownColumns.
+const (
+	UnknownColumnsIgnore = "ignore" // Unknown columns are processed like the others (default).
+	UnknownColumnsWarn   = "warn"   // Unknown columns are reported to the OnUnknownColumns hook and logged.
+	UnknownColumnsFail   = "fail"   // Requests with unknown columns fail validation.
+)
+
+// OnUnknownColumns sets the function called with the unknown request
+// columns when Config.UnknownColumns is UnknownColumnsWarn.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) OnUnknownColumns(fn func(ctx context.Context, columns []string)) *DataTable {
+	dt.unknownHook = fn
+	return dt
+}
+
+// unknownColumns returns the Data of the request columns that are neither
+// declared with AddColumn and the like nor fields of the model, in the order
+// of the request. Columns without Data, such as action buttons, are not
+// reported.
+func (dt *DataTable) unknownColumns() []string {
+	fields := dt.modelFields()
+	var unknown []string
+	for _, col := range dt.req.Columns {
+		if col.Data != "" && !dt.declaredColumns[col.Data] && !fields[col.Data] {
+			unknown = append(unknown, col.Data)
+		}
+	}
+	return unknown
+}
+
+// checkUnknownColumns applies the unknown columns policy of the config to
+// the request. It returns a validation error listing the unknown columns
+// when the policy is UnknownColumnsFail.
+func (dt *DataTable) checkUnknownColumns() error {
+	policy := dt.config.UnknownColumns
+	if policy != UnknownColumnsWarn && policy != UnknownColumnsFail {
+		return nil
+	}
+	unknown := dt.unknownColumns()
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	if policy == UnknownColumnsFail {
+		list := strings.Join(unknown, ", ")
+		return errors.New(dt.translatef(MsgUnknownColumns, "unknown columns: %s", list))
+	}
+
+	ctx := dt.context()
+	if dt.unknownHook != nil {
+		dt.unknownHook(ctx, unknown)
+	}
+	if dt.logger != nil {
+		dt.logger.LogAttrs(ctx, slog.LevelWarn, "datatables: unknown request columns",
+			slog.String("table", dt.tableName()),
+			slog.Any("columns", unknown),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package datatables
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestUnknownColumns(t *testing.T) {
+	req := Request{
+		Draw: 1,
+		Columns: []ColumnRequest{
+			{Data: "name"},
+			{Data: "label"},
+			{Data: ""},
+			{Data: "legacy_field"},
+			{Data: "id"},
+		},
+	}
+	newTable := func(t *testing.T, policy string) *DataTable {
+		db, _ := newMockDB(t)
+		cfg := defaultConfig()
+		cfg.UnknownColumns = policy
+		return New(db, WithConfig(cfg)).Model(&User{}).
+			AddColumn(Column{Name: "CONCAT(name, id)", Data: "label"}).
+			Req(req)
+	}
+
+	t.Run("ignore", func(t *testing.T) {
+		if err := newTable(t, UnknownColumnsIgnore).Validate(); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("warn", func(t *testing.T) {
+		var reported []string
+		dt := newTable(t, UnknownColumnsWarn).OnUnknownColumns(func(ctx context.Context, columns []string) {
+			reported = columns
+		})
+		if err := dt.Validate(); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if expected := []string{"legacy_field"}; !reflect.DeepEqual(reported, expected) {
+			t.Errorf("expected %v, got %v", expected, reported)
+		}
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		err := newTable(t, UnknownColumnsFail).Validate()
+		if err == nil || err.Error() != "unknown columns: legacy_field" {
+			t.Errorf("expected unknown columns error, got %v", err)
+		}
+	})
+}
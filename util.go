@@ -2,40 +2,8 @@ package datatables
 
 import (
 	"regexp"
-	"strings"
 )
 
-// extractFields takes a SQL GROUP BY or HAVING clause as input and returns
-// a slice of strings representing the fields in the clause. The input string
-// should not include the keyword "GROUP BY" or "HAVING". If the clause is
-// empty, an empty slice is returned.
-//
-// The function removes any leading or trailing parentheses and whitespace
-// from the input string, splits the string on commas, trims any remaining
-// whitespace from the resulting fields, and returns the fields as a slice.
-func extractFields(clause string) []string {
-	if clause == "" {
-		return []string{}
-	}
-
-	openParenIndex := strings.Index(clause, "(")
-	if openParenIndex != -1 {
-		clause = clause[openParenIndex+1:]
-	}
-
-	closeParenIndex := strings.Index(clause, ")")
-	if closeParenIndex != -1 {
-		clause = clause[:closeParenIndex]
-	}
-
-	fields := strings.Split(clause, ",")
-	for i, field := range fields {
-		fields[i] = strings.TrimSpace(field)
-	}
-
-	return fields
-}
-
 // qm takes a string as input and returns a string with any special
 // characters properly escaped for use in a regular expression. This
 // function is useful for protecting against user input that may contain
@@ -6,30 +6,6 @@ import (
 	"testing"
 )
 
-func TestExtractFields(t *testing.T) {
-	tests := []struct {
-		name     string
-		clause   string
-		expected []string
-	}{
-		{"empty_string", "", []string{}},
-		{"no_parentheses", "field_1, field_2", []string{"field_1", "field_2"}},
-		{"with_parentheses", "(field_1, field_2)", []string{"field_1", "field_2"}},
-		{"multiple_fields", "field_1, field_2, field_3", []string{"field_1", "field_2", "field_3"}},
-		{"leading_or_trailing_whitespace", " (field_1, field_2) ", []string{"field_1", "field_2"}},
-		{"whitespace_between_fields", "field_1 , field_2 , field_3", []string{"field_1", "field_2", "field_3"}},
-	}
-
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			actual := extractFields(test.clause)
-			if !reflect.DeepEqual(actual, test.expected) {
-				t.Errorf("expected %#v, got %#v", test.expected, actual)
-			}
-		})
-	}
-}
-
 func TestQM(t *testing.T) {
 	tests := []struct {
 		name     string
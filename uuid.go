@@ -0,0 +1,138 @@
+package datatables
+
+import (
+	"encoding/hex"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// Column types understood by the DataTable.
+const (
+	ColumnTypeUUID   = "uuid"   // UUID stored as BINARY(16), bytea, or a native uuid type.
+	ColumnTypeCIText = "citext" // Case-insensitive text, such as the Postgres citext type.
+)
+
+// formatUUID returns the canonical string form of a 16-byte UUID.
+func formatUUID(b []byte) string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], b[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], b[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], b[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], b[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], b[10:])
+	return string(buf[:])
+}
+
+// parseUUID parses a UUID in canonical form, with or without hyphens, and
+// returns its 16 bytes. The second return value is false if s is not a UUID.
+func parseUUID(s string) ([]byte, bool) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), "-", "")
+	if len(s) != 32 {
+		return nil, false
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, false
+	}
+	return b, true
+}
+
+// uuidCondition returns the condition matching the UUID column with the
+// search value, and false if the value is not a UUID. The value is bound as
+// the canonical string for the columns of a native uuid type, see
+// isNativeUUID, and as its 16 bytes otherwise.
+func (dt *DataTable) uuidCondition(col Column, value string) (clause.Expression, bool) {
+	id, ok := parseUUID(value)
+	if !ok {
+		return nil, false
+	}
+	if dt.isNativeUUID(col) {
+		return clause.Eq{Column: col.sqlColumn(), Value: formatUUID(id)}, true
+	}
+	return clause.Eq{Column: col.sqlColumn(), Value: id}, true
+}
+
+// isNativeUUID reports whether the UUID column has a native uuid type, such
+// as the Postgres uuid type, rather than a binary one. A column backed by a
+// model field of database type uuid is native, one of a binary or bytea
+// type is not, and other columns are native on Postgres.
+func (dt *DataTable) isNativeUUID(col Column) bool {
+	if info := dt.modelInfo(); info != nil {
+		for _, field := range info.uuidFields {
+			if field.DBName != col.Name && field.Name != col.Name && field.DBName != col.Data && field.Name != col.Data {
+				continue
+			}
+			dataType := strings.ToLower(string(field.DataType))
+			if dataType == "uuid" {
+				return true
+			}
+			if strings.Contains(dataType, "binary") || strings.Contains(dataType, "bytea") {
+				return false
+			}
+			break
+		}
+	}
+	return dt.tx != nil && dt.tx.Dialector != nil && dt.tx.Dialector.Name() == "postgres"
+}
+
+// detectUUIDColumns sets the Type of the columns backed by a UUID field of
+// the model to ColumnTypeUUID, unless a type is already set. A field is
+// considered a UUID when its Go type is a 16-byte array (such as uuid.UUID)
+// or its database type is uuid or binary(16).
+func (dt *DataTable) detectUUIDColumns() {
+	info := dt.modelInfo()
+	if info == nil {
+		return
+	}
+
+	for _, field := range info.uuidFields {
+		for _, key := range []string{field.DBName, field.Name} {
+			if col, exists := dt.columnsMap[key]; exists && col.Type == "" {
+				col.Type = ColumnTypeUUID
+				dt.columnsMap[key] = col
+			}
+		}
+	}
+}
+
+// isUUIDField reports whether a field with the given Go type and database
+// type holds a UUID.
+func isUUIDField(fieldType reflect.Type, dataType string) bool {
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() == reflect.Array && fieldType.Len() == 16 && fieldType.Elem().Kind() == reflect.Uint8 {
+		return true
+	}
+	dataType = strings.ToLower(strings.ReplaceAll(dataType, " ", ""))
+	return dataType == "uuid" || dataType == "binary(16)"
+}
+
+// applyUUIDColumns converts the binary values of the UUID columns in the
+// data to their canonical string form. Values that are not 16 bytes long are
+// left unchanged.
+func (dt *DataTable) applyUUIDColumns(data []map[string]any) {
+	var columns []string
+	for data, col := range dt.columnsMap {
+		if col.Type == ColumnTypeUUID {
+			columns = append(columns, data)
+		}
+	}
+	if len(columns) == 0 {
+		return
+	}
+
+	for _, row := range data {
+		for _, key := range columns {
+			if b, ok := row[key].([]byte); ok && len(b) == 16 {
+				row[key] = formatUUID(b)
+			}
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package datatables
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+type Device struct {
+	ID     [16]byte
+	Serial string
+	Owner  []byte `gorm:"type:binary(16)"`
+}
+
+var deviceUUID = []byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+
+func TestFormatAndParseUUID(t *testing.T) {
+	const canonical = "123e4567-e89b-12d3-a456-426614174000"
+	if got := formatUUID(deviceUUID); got != canonical {
+		t.Errorf("expected %q, got %q", canonical, got)
+	}
+
+	for _, s := range []string{canonical, "123e4567e89b12d3a456426614174000"} {
+		b, ok := parseUUID(s)
+		if !ok || !reflect.DeepEqual(b, deviceUUID) {
+			t.Errorf("expected %q to parse to %v, got %v", s, deviceUUID, b)
+		}
+	}
+
+	for _, s := range []string{"", "john", "123e4567-e89b-12d3-a456-42661417400z"} {
+		if _, ok := parseUUID(s); ok {
+			t.Errorf("expected %q not to parse", s)
+		}
+	}
+}
+
+func TestDetectUUIDColumns(t *testing.T) {
+	db, _ := newMockDB(t)
+	dt := New(db)
+	dt.Model(&Device{})
+	dt.AddColumns(
+		Column{Name: "id", Data: "id"},
+		Column{Name: "serial", Data: "serial"},
+		Column{Name: "owner", Data: "owner"},
+	)
+
+	dt.detectUUIDColumns()
+
+	for data, expected := range map[string]string{"id": ColumnTypeUUID, "serial": "", "owner": ColumnTypeUUID} {
+		if got := dt.columnsMap[data].Type; got != expected {
+			t.Errorf("expected column %q to have type %q, got %q", data, expected, got)
+		}
+	}
+}
+
+func TestApplyUUIDColumns(t *testing.T) {
+	dt := New(nil)
+	dt.AddColumns(
+		Column{Name: "id", Data: "id", Type: ColumnTypeUUID},
+		Column{Name: "serial", Data: "serial"},
+	)
+
+	data := []map[string]any{
+		{"id": deviceUUID, "serial": []byte("0123456789abcdef")},
+		{"id": "already-a-string", "serial": "A1"},
+	}
+	dt.applyUUIDColumns(data)
+
+	expected := []map[string]any{
+		{"id": "123e4567-e89b-12d3-a456-426614174000", "serial": []byte("0123456789abcdef")},
+		{"id": "already-a-string", "serial": "A1"},
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("expected %v, got %v", expected, data)
+	}
+}
+
+func TestSearchUUIDColumns(t *testing.T) {
+	tests := []struct {
+		name   string
+		search string
+		query  string
+		args   []driver.Value
+	}{
+		{
+			name:   "uuid_search",
+			search: "123e4567-e89b-12d3-a456-426614174000",
+			query:  "SELECT count(*) FROM `devices` WHERE (`id` = ? OR `serial` LIKE ?)",
+			args:   []driver.Value{deviceUUID, "%123e4567-e89b-12d3-a456-426614174000%"},
+		},
+		{
+			name:   "text_search",
+			search: "A1",
+			query:  "SELECT count(*) FROM `devices` WHERE `serial` LIKE ?",
+			args:   []driver.Value{"%A1%"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			dt := New(db)
+			dt.Model(&Device{})
+			dt.Req(Request{
+				Draw:   1,
+				Search: Search{Value: tt.search},
+				Columns: []ColumnRequest{
+					{Name: "id", Data: "id", Searchable: true},
+					{Name: "serial", Data: "serial", Searchable: true},
+				},
+			})
+			dt.detectUUIDColumns()
+
+			mock.ExpectQuery(qm(tt.query)).WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+			var count int64
+			if err := dt.applySearch(dt.tx.Model(&Device{})).Count(&count).Error; err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+type LoginSession struct {
+	ID    string `gorm:"type:uuid"`
+	Token string
+}
+
+func TestSearchNativeUUIDColumns(t *testing.T) {
+	const canonical = "123e4567-e89b-12d3-a456-426614174000"
+	tests := []struct {
+		name    string
+		dialect string
+		model   any
+		table   string
+		value   driver.Value
+	}{
+		{name: "uuid_type", dialect: "mysql", model: &LoginSession{}, table: "login_sessions", value: canonical},
+		{name: "postgres", dialect: "postgres", model: &Device{}, table: "devices", value: canonical},
+		{name: "binary", dialect: "mysql", model: &Device{}, table: "devices", value: deviceUUID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDBWithDialect(t, tt.dialect)
+			dt := New(db).Model(tt.model)
+			dt.Req(Request{
+				Draw:    1,
+				Search:  Search{Value: canonical},
+				Columns: []ColumnRequest{{Name: "id", Data: "id", Searchable: true}},
+			})
+			dt.detectUUIDColumns()
+
+			mock.ExpectQuery(qm("SELECT count(*) FROM `" + tt.table + "` WHERE `id` = ?")).
+				WithArgs(tt.value).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+			var count int64
+			if err := dt.applySearch(dt.tx.Model(tt.model)).Count(&count).Error; err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestColumnSearchUUIDColumns(t *testing.T) {
+	tests := []struct {
+		name   string
+		search string
+		query  string
+		args   []driver.Value
+	}{
+		{
+			name:   "uuid_search",
+			search: "123e4567-e89b-12d3-a456-426614174000",
+			query:  "SELECT count(*) FROM `devices` WHERE `id` = ?",
+			args:   []driver.Value{deviceUUID},
+		},
+		{
+			name:   "text_search",
+			search: "123e",
+			query:  "SELECT count(*) FROM `devices` WHERE 1 = 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			dt := New(db).Model(&Device{})
+			dt.Req(Request{
+				Draw: 1,
+				Columns: []ColumnRequest{
+					{Name: "id", Data: "id", Searchable: true, Search: Search{Value: tt.search}},
+				},
+			})
+			dt.detectUUIDColumns()
+
+			mock.ExpectQuery(qm(tt.query) + "$").WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+			var count int64
+			if err := dt.applyColumnSearches(dt.tx.Model(&Device{}), "").Count(&count).Error; err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package datatables
+
+import "context"
+
+// valuesKey is the context key of the values set with Set.
+type valuesKey struct{}
+
+// Set stores a request-scoped value, such as the current user, their
+// permissions, or their locale, under the given key, so that it is
+// available to the callbacks of the DataTable without package-level
+// globals.
+//
+// The values are attached to the context of the DataTable, which is passed
+// to the row and column policies and the audit actor, and which the queries
+// given to the filters, filter presets, and hooks carry in their statement
+// context. Callbacks read them with Value, and render functions capturing
+// the DataTable can read them with Get.
+//
+// Returns the updated DataTable instance.
+func (dt *DataTable) Set(key string, value any) *DataTable {
+	if dt.values == nil {
+		dt.values = make(map[string]any)
+	}
+	dt.values[key] = value
+	return dt
+}
+
+// Get returns the value stored under the given key with Set, and whether it
+// was set.
+func (dt *DataTable) Get(key string) (any, bool) {
+	value, ok := dt.values[key]
+	return value, ok
+}
+
+// Value returns the value stored under the given key with Set on the
+// DataTable the context comes from, and whether it was set. In a filter, the
+// context is the statement context of the query:
+//
+//	dt.Filter(func(db *gorm.DB) *gorm.DB {
+//		tenant, _ := datatables.Value(db.Statement.Context, "tenant")
+//		return db.Where("tenant_id = ?", tenant)
+//	})
+func Value(ctx context.Context, key string) (any, bool) {
+	values, _ := ctx.Value(valuesKey{}).(map[string]any)
+	value, ok := values[key]
+	return value, ok
+}
@@ -0,0 +1,68 @@
+package datatables
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+func TestSetGet(t *testing.T) {
+	dt := New(nil)
+	if _, ok := dt.Get("user"); ok {
+		t.Error("expected no value before Set")
+	}
+	dt.Set("user", "alice")
+	if value, ok := dt.Get("user"); !ok || value != "alice" {
+		t.Errorf("expected alice, got %v, %v", value, ok)
+	}
+	if value, ok := Value(dt.context(), "user"); !ok || value != "alice" {
+		t.Errorf("expected the value in the context, got %v, %v", value, ok)
+	}
+	if _, ok := Value(context.Background(), "user"); ok {
+		t.Error("expected no value in a foreign context")
+	}
+}
+
+func TestMakeWithValues(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users` WHERE owner = ? AND tenant_id = ?")).
+		WithArgs("alice", 7).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(qm("SELECT * FROM `users` WHERE owner = ? AND tenant_id = ? LIMIT ?")).
+		WithArgs("alice", 7, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John"))
+
+	dt := New(db).Model(&User{})
+	dt.Req(Request{
+		Draw:    1,
+		Length:  10,
+		Columns: []ColumnRequest{{Name: "id", Data: "id"}, {Name: "name", Data: "name"}},
+	})
+	dt.Set("user", "alice").Set("tenant", 7)
+	dt.RowPolicy(func(ctx context.Context) func(*gorm.DB) *gorm.DB {
+		user, _ := Value(ctx, "user")
+		return func(db *gorm.DB) *gorm.DB { return db.Where("owner = ?", user) }
+	})
+	dt.Filter(func(db *gorm.DB) *gorm.DB {
+		tenant, _ := Value(db.Statement.Context, "tenant")
+		return db.Where("tenant_id = ?", tenant)
+	})
+	dt.AddColumn(Column{Data: "viewer", RenderFunc: func(row map[string]any) any {
+		user, _ := dt.Get("user")
+		return user
+	}})
+
+	res, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if row := res["data"].([]map[string]any)[0]; row["viewer"] != "alice" {
+		t.Errorf("expected the value in the render function, got %v", row)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package datatables
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestHasChanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		column string
+		sql    string
+		row    func() *sqlmock.Rows
+	}{
+		{
+			name:   "count and max",
+			column: "updated_at",
+			sql:    "SELECT COUNT(*), MAX(`updated_at`) FROM `users`",
+			row: func() *sqlmock.Rows {
+				return sqlmock.NewRows([]string{"count", "max"}).AddRow(3, "2026-01-02 10:00:00")
+			},
+		},
+		{
+			name: "count only",
+			sql:  "SELECT COUNT(*) FROM `users`",
+			row: func() *sqlmock.Rows {
+				return sqlmock.NewRows([]string{"count"}).AddRow(3)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			mock.ExpectQuery(qm(tt.sql)).WillReturnRows(tt.row())
+
+			dt := New(db).Model(&User{}).WithVersion(tt.column)
+			dt.Req(Request{Draw: 1, Columns: []ColumnRequest{{Name: "name", Data: "name"}}})
+			changed, version, err := dt.HasChanges("")
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !changed || len(version) != 16 {
+				t.Errorf("expected a change and a 16 characters version, got %v and %q", changed, version)
+			}
+
+			mock.ExpectQuery(qm(tt.sql)).WillReturnRows(tt.row())
+			changed, again, err := dt.HasChanges(version)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if changed || again != version {
+				t.Errorf("expected no change, got %v with version %q instead of %q", changed, again, version)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestMakeWithVersion(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectQuery(qm("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(qm("SELECT * FROM `users` LIMIT ?")).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+	mock.ExpectQuery(qm("SELECT COUNT(*), MAX(`updated_at`) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "max"}).AddRow(1, "2026-01-02 10:00:00"))
+
+	dt := New(db).Model(&User{}).WithVersion("updated_at")
+	dt.Req(Request{Draw: 1, Length: 10, Columns: []ColumnRequest{{Name: "name", Data: "name"}}})
+
+	response, err := dt.Make()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if version, _ := response[versionKey].(string); len(version) != 16 {
+		t.Errorf("expected a version token, got %v", response[versionKey])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+// Package ws provides a WebSocket handler serving DataTables over a single
+// connection, for real-time grids and very chatty tables that would pay the
+// HTTP overhead on every draw.
+//
+// Every message sent by the client is a DataTables request encoded as JSON,
+// and every message sent back is the matching response:
+//
+//	http.Handle("/users/ws", ws.Handler(func(r *http.Request, req datatables.Request) (*datatables.DataTable, error) {
+//		return datatables.New(db.WithContext(r.Context())).Model(&User{}).Req(req), nil
+//	}, nil))
+package ws
+
+import (
+	"net/http"
+
+	datatables "github.com/ZihxS/golang-gorm-datatables"
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+)
+
+// BuildFunc returns the DataTable answering a request received on the
+// connection opened by the given HTTP request.
+type BuildFunc func(r *http.Request, req datatables.Request) (*datatables.DataTable, error)
+
+// Handler returns an http.Handler accepting WebSocket connections and
+// answering every DataTables request received on them with the response of
+// the DataTable returned by build. Requests of a connection are answered in
+// order. A request that fails is answered with its draw counter and the
+// error message under the "error" key, as DataTables expects, and the
+// connection stays open. The options are passed to websocket.Accept and may
+// be nil.
+func Handler(build BuildFunc, opts *websocket.AcceptOptions) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, opts)
+		if err != nil {
+			return
+		}
+		defer conn.CloseNow()
+
+		ctx := r.Context()
+		for {
+			var req datatables.Request
+			if err := wsjson.Read(ctx, conn, &req); err != nil {
+				return
+			}
+			if err := wsjson.Write(ctx, conn, respond(r, req, build)); err != nil {
+				return
+			}
+		}
+	})
+}
+
+// respond returns the response to the given request.
+func respond(r *http.Request, req datatables.Request, build BuildFunc) map[string]any {
+	dt, err := build(r, req)
+	var response map[string]any
+	if err == nil {
+		response, err = dt.Make()
+	}
+	if err != nil {
+		return map[string]any{"draw": req.Draw, "error": err.Error()}
+	}
+	return response
+}
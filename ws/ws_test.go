@@ -0,0 +1,96 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	datatables "github.com/ZihxS/golang-gorm-datatables"
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type User struct {
+	ID   int
+	Name string
+}
+
+func TestHandler(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm DB: %v", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` LIMIT ?")).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John Doe"))
+
+	server := httptest.NewServer(Handler(func(r *http.Request, req datatables.Request) (*datatables.DataTable, error) {
+		if req.Draw == 2 {
+			return nil, errors.New("boom")
+		}
+		return datatables.New(db).Model(&User{}).Req(req), nil
+	}, nil))
+	defer server.Close()
+
+	ctx := context.Background()
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	defer conn.CloseNow()
+
+	tests := []struct {
+		name     string
+		request  string
+		expected map[string]any
+	}{
+		{
+			name:    "response",
+			request: `{"draw":1,"start":0,"length":10,"columns":[{"data":"name","name":"name"}]}`,
+			expected: map[string]any{
+				"draw":            float64(1),
+				"recordsTotal":    float64(1),
+				"recordsFiltered": float64(1),
+				"data":            []any{map[string]any{"id": float64(1), "name": "John Doe"}},
+			},
+		},
+		{
+			name:     "error",
+			request:  `{"draw":2}`,
+			expected: map[string]any{"draw": float64(2), "error": "boom"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := conn.Write(ctx, websocket.MessageText, []byte(tt.request)); err != nil {
+				t.Fatalf("failed to write: %v", err)
+			}
+			var response map[string]any
+			if err := wsjson.Read(ctx, conn, &response); err != nil {
+				t.Fatalf("failed to read: %v", err)
+			}
+			if !reflect.DeepEqual(response, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, response)
+			}
+		})
+	}
+
+	conn.Close(websocket.StatusNormalClosure, "")
+}